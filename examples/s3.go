@@ -1,24 +1,31 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 
-	"github.com/easynetwork/aws-sdk-go-bindings/internal/configuration"
 	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
 	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/s3"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/config"
 )
 
 const (
 
 	// S3OBJECTPATH path to an example image
 	S3OBJECTPATH = "assets/compare_faces_test-source.jpg"
+
+	// CONFIGPATH path to an example configuration file
+	CONFIGPATH = "testdata/configuration.json"
 )
 
 func main() {
 
-	// Getting Configuration
-	cfg, err := configuration.Get()
+	configPath := flag.String("config", CONFIGPATH, "path to a json or yaml configuration file")
+	flag.Parse()
+
+	// Getting Configuration, AWSB_* environment variables override the file
+	cfg, err := config.Load(*configPath)
 	if err != nil {
 		log.Fatal(err)
 	}
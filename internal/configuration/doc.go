@@ -1,2 +0,0 @@
-// Package configuration allows reading configuration parameters from a given configuration file
-package configuration
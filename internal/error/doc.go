@@ -1,2 +1,3 @@
-// Package error formats errors to contain more information
+// Package error defines the typed errors returned by the bindings, so that they
+// can be inspected through errors.Is and errors.As
 package error
@@ -1,22 +1,134 @@
 package error
 
 import (
-	"errors"
-	"fmt"
 	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
 )
 
-// Format returns a new formatted error given an error string and an input
-func Format(input interface{}, err string) error {
+// ValidationError is returned when a parameter fails validation before reaching AWS
+type ValidationError struct {
+	// Code describes what went wrong, like EmptyParameter
+	Code string
+	// Parameter is the name of the parameter that failed validation, if any
+	Parameter string
+}
+
+// NewValidationError returns a new *ValidationError given an error code and a parameter name
+func NewValidationError(code, parameter string) error {
+	return &ValidationError{
+		Code:      code,
+		Parameter: parameter,
+	}
+}
+
+// Error returns the error as `Code : Parameter`
+func (e *ValidationError) Error() string {
+
+	if e.Parameter == "" {
+		return e.Code
+	}
+
+	return strings.Join([]string{e.Code, e.Parameter}, " : ")
+
+}
+
+// Is reports whether target is a *ValidationError with the same Code.
+// An empty target Parameter matches any parameter
+func (e *ValidationError) Is(target error) bool {
+
+	t, ok := target.(*ValidationError)
+	if !ok {
+		return false
+	}
+
+	return t.Code == e.Code && (t.Parameter == "" || t.Parameter == e.Parameter)
+
+}
+
+// AWSError wraps an awserr.Error returned by aws-sdk-go.
+// It implements awserr.RequestFailure so it can still be type asserted like the original error
+type AWSError struct {
+	err        awserr.Error
+	requestID  string
+	statusCode int
+}
+
+// NewAWSError returns a new *AWSError given an aws error code, a message and an optional original error
+func NewAWSError(code, message string, origErr error) error {
+	return &AWSError{
+		err: awserr.New(code, message, origErr),
+	}
+}
+
+// Error returns the string representation of the wrapped error
+func (e *AWSError) Error() string {
+	return e.err.Error()
+}
+
+// Code returns the aws error code, like ResourceNotFoundException
+func (e *AWSError) Code() string {
+	return e.err.Code()
+}
+
+// Message returns the aws error message
+func (e *AWSError) Message() string {
+	return e.err.Message()
+}
+
+// OrigErr returns the original error, if any
+func (e *AWSError) OrigErr() error {
+	return e.err.OrigErr()
+}
+
+// RequestID returns the id of the failed request, if any
+func (e *AWSError) RequestID() string {
+	return e.requestID
+}
+
+// StatusCode returns the http status code of the failed request, if any
+func (e *AWSError) StatusCode() int {
+	return e.statusCode
+}
+
+// Unwrap returns the wrapped awserr.Error
+func (e *AWSError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is an *AWSError with the same Code
+func (e *AWSError) Is(target error) bool {
+
+	t, ok := target.(*AWSError)
+	if !ok {
+		return false
+	}
+
+	return t.Code() == e.Code()
+
+}
+
+// Wrap returns err as an *AWSError when it is an awserr.Error, err itself otherwise
+func Wrap(err error) error {
+
+	if _, ok := err.(*AWSError); ok {
+		return err
+	}
+
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return err
+	}
+
+	out := &AWSError{
+		err: awsErr,
+	}
 
-	s := []string{
-		err,
-		`:`,
-		`%v`,
+	if reqErr, ok := err.(awserr.RequestFailure); ok {
+		out.requestID = reqErr.RequestID()
+		out.statusCode = reqErr.StatusCode()
 	}
-	f := strings.Join(s, " ")
-	r := fmt.Sprintf(f, input)
 
-	return errors.New(r)
+	return out
 
 }
@@ -1,18 +1,66 @@
 package error
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestFormat(t *testing.T) {
+func TestNewValidationError(t *testing.T) {
 
-	err := "SomeError"
+	code := "SomeError"
 	val := "SomeParameterName"
 
-	s := Format(val, err)
+	err := NewValidationError(code, val)
 
-	assert.Contains(t, s.Error(), err, val)
+	assert.Equal(t, "SomeError : SomeParameterName", err.Error())
+	assert.Equal(t, code, NewValidationError(code, "").Error())
+
+	var vErr *ValidationError
+
+	assert.True(t, errors.As(err, &vErr))
+	assert.Equal(t, code, vErr.Code)
+	assert.Equal(t, val, vErr.Parameter)
+
+	assert.True(t, errors.Is(err, &ValidationError{Code: code}))
+	assert.True(t, errors.Is(err, &ValidationError{Code: code, Parameter: val}))
+	assert.False(t, errors.Is(err, &ValidationError{Code: code, Parameter: "OtherParameter"}))
+	assert.False(t, errors.Is(err, &ValidationError{Code: "OtherError"}))
+
+}
+
+func TestWrap(t *testing.T) {
+
+	origErr := errors.New("some_error")
+
+	assert.Equal(t, origErr, Wrap(origErr))
+	assert.Nil(t, Wrap(nil))
+
+	reqErr := awserr.NewRequestFailure(
+		awserr.New("SomeCode", "some message", origErr),
+		400,
+		"some_request_id",
+	)
+
+	err := Wrap(reqErr)
+
+	var awsErr *AWSError
+
+	assert.True(t, errors.As(err, &awsErr))
+	assert.Equal(t, "SomeCode", awsErr.Code())
+	assert.Equal(t, "some message", awsErr.Message())
+	assert.Equal(t, "some_request_id", awsErr.RequestID())
+	assert.Equal(t, 400, awsErr.StatusCode())
+	assert.Equal(t, reqErr.Error(), err.Error())
+
+	assert.True(t, errors.Is(err, NewAWSError("SomeCode", "", nil)))
+	assert.False(t, errors.Is(err, NewAWSError("OtherCode", "", nil)))
+	assert.Equal(t, err, Wrap(err))
+
+	_, ok := err.(awserr.RequestFailure)
+
+	assert.True(t, ok)
 
 }
@@ -0,0 +1,77 @@
+package aws
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// ClientOption overrides a setting of the configuration used by a single service client
+type ClientOption func(*aws.Config)
+
+// WithEndpoint overrides the endpoint of a service client
+func WithEndpoint(endpoint string) ClientOption {
+	return func(cfg *aws.Config) {
+		cfg.Endpoint = aws.String(endpoint)
+	}
+}
+
+// WithRegion overrides the region of a service client
+func WithRegion(region string) ClientOption {
+	return func(cfg *aws.Config) {
+		cfg.Region = aws.String(region)
+	}
+}
+
+// WithMaxRetries overrides the maximum number of retries of a service client
+func WithMaxRetries(maxRetries int) ClientOption {
+	return func(cfg *aws.Config) {
+		cfg.MaxRetries = aws.Int(maxRetries)
+	}
+}
+
+// WithRetryer overrides the request.Retryer of a service client
+func WithRetryer(retryer request.Retryer) ClientOption {
+	return func(cfg *aws.Config) {
+		request.WithRetryer(cfg, retryer)
+	}
+}
+
+// WithRetryPolicy overrides the retry policy of a service client
+func WithRetryPolicy(policy *RetryPolicy) ClientOption {
+	return func(cfg *aws.Config) {
+		policy.apply(cfg)
+	}
+}
+
+// WithHTTPClient overrides the *http.Client of a service client
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(cfg *aws.Config) {
+		cfg.HTTPClient = client
+	}
+}
+
+// WithS3ForcePathStyle makes an S3 client address buckets as http://endpoint/bucket
+// instead of http://bucket.endpoint
+func WithS3ForcePathStyle(force bool) ClientOption {
+	return func(cfg *aws.Config) {
+		cfg.S3ForcePathStyle = aws.Bool(force)
+	}
+}
+
+// ServiceSession returns a copy of the underlying *session.Session to be used by
+// a single service client. opts are applied to the copy only, so svc and every
+// other client built from it are never affected
+func (svc *Session) ServiceSession(opts ...ClientOption) *session.Session {
+
+	cfg := &aws.Config{}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return svc.Session.Copy(cfg)
+
+}
@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/testdata"
+)
+
+func TestSession_ServiceSession(t *testing.T) {
+
+	cfg := testdata.MockConfiguration(t)
+
+	in, err := NewSessionInput(cfg.Region)
+
+	assert.NoError(t, err)
+
+	svc, err := New(in)
+
+	assert.NoError(t, err)
+
+	httpClient := &http.Client{}
+	retryer := client.DefaultRetryer{NumMaxRetries: 7}
+
+	first := svc.ServiceSession(
+		WithEndpoint(cfg.S3.Endpoint),
+		WithRegion("some_region"),
+		WithHTTPClient(httpClient),
+		WithRetryer(retryer),
+	)
+
+	assert.Equal(t, cfg.S3.Endpoint, *first.Config.Endpoint)
+	assert.Equal(t, "some_region", *first.Config.Region)
+	assert.Equal(t, httpClient, first.Config.HTTPClient)
+	assert.Equal(t, retryer, first.Config.Retryer)
+
+	second := svc.ServiceSession(WithMaxRetries(3), WithS3ForcePathStyle(true))
+
+	assert.Nil(t, second.Config.Endpoint)
+	assert.Equal(t, cfg.Region, *second.Config.Region)
+	assert.Equal(t, 3, *second.Config.MaxRetries)
+	assert.True(t, *second.Config.S3ForcePathStyle)
+
+	assert.Nil(t, svc.Config.Endpoint)
+	assert.Equal(t, cfg.Region, *svc.Config.Region)
+	assert.NotEqual(t, httpClient, svc.Config.HTTPClient)
+	assert.Nil(t, svc.Config.S3ForcePathStyle)
+
+}
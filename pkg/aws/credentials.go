@@ -0,0 +1,150 @@
+package aws
+
+import (
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+const (
+
+	// WebIdentityProviderName is the name of the provider retrieving web identity credentials
+	WebIdentityProviderName = "WebIdentityProvider"
+
+	// defaultExpiryWindow is how early temporary credentials are refreshed before they expire
+	defaultExpiryWindow = 10 * time.Second
+)
+
+// webIdentityRoler is implemented by *sts.STS
+type webIdentityRoler interface {
+	AssumeRoleWithWebIdentity(*sts.AssumeRoleWithWebIdentityInput) (*sts.AssumeRoleWithWebIdentityOutput, error)
+}
+
+// webIdentityProvider retrieves temporary credentials by exchanging
+// the token stored in tokenFile through sts.AssumeRoleWithWebIdentity
+type webIdentityProvider struct {
+	credentials.Expiry
+
+	client          webIdentityRoler
+	roleARN         string
+	roleSessionName string
+	tokenFile       string
+}
+
+// Retrieve reads the token file and assumes the configured role with it
+func (p *webIdentityProvider) Retrieve() (credentials.Value, error) {
+
+	token, err := ioutil.ReadFile(p.tokenFile)
+	if err != nil {
+		return credentials.Value{ProviderName: WebIdentityProviderName}, err
+	}
+
+	roleSessionName := p.roleSessionName
+	if roleSessionName == "" {
+		roleSessionName = strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+
+	in := &sts.AssumeRoleWithWebIdentityInput{}
+	in = in.SetRoleArn(p.roleARN)
+	in = in.SetRoleSessionName(roleSessionName)
+	in = in.SetWebIdentityToken(string(token))
+
+	out, err := p.client.AssumeRoleWithWebIdentity(in)
+	if err != nil {
+		return credentials.Value{ProviderName: WebIdentityProviderName}, err
+	}
+
+	if out.Credentials == nil {
+		return credentials.Value{ProviderName: WebIdentityProviderName}, intErr.NewAWSError(
+			ErrNoCredentialsReturned,
+			"AssumeRoleWithWebIdentity returned no credentials",
+			nil,
+		)
+	}
+
+	p.SetExpiration(aws.TimeValue(out.Credentials.Expiration), defaultExpiryWindow)
+
+	return credentials.Value{
+		AccessKeyID:     aws.StringValue(out.Credentials.AccessKeyId),
+		SecretAccessKey: aws.StringValue(out.Credentials.SecretAccessKey),
+		SessionToken:    aws.StringValue(out.Credentials.SessionToken),
+		ProviderName:    WebIdentityProviderName,
+	}, nil
+
+}
+
+// newSessionOptions returns the session.Options matching the credential sources of input
+func newSessionOptions(input *SessionInput) session.Options {
+
+	opts := session.Options{
+		Config: aws.Config{
+			Region: aws.String(input.region),
+		},
+	}
+
+	if c := input.staticCredentials; c != nil {
+		opts.Config.Credentials = credentials.NewStaticCredentials(
+			c.accessKeyID,
+			c.secretAccessKey,
+			c.sessionToken,
+		)
+	}
+
+	if input.profile != "" {
+		opts.Profile = input.profile
+		opts.SharedConfigState = session.SharedConfigEnable
+		opts.SharedConfigFiles = input.sharedConfigFiles
+	}
+
+	return opts
+
+}
+
+// withRoleCredentials returns a copy of base using web identity and assumed role
+// credentials when requested by input. base is returned untouched otherwise
+func withRoleCredentials(base *session.Session, input *SessionInput) *session.Session {
+
+	out := base
+
+	if w := input.webIdentity; w != nil {
+		stsSvc := sts.New(base, &aws.Config{
+			Credentials: credentials.AnonymousCredentials,
+		})
+		out = out.Copy(&aws.Config{
+			Credentials: credentials.NewCredentials(&webIdentityProvider{
+				client:          stsSvc,
+				roleARN:         w.roleARN,
+				roleSessionName: w.roleSessionName,
+				tokenFile:       w.tokenFile,
+			}),
+		})
+	}
+
+	if r := input.assumeRole; r != nil {
+		creds := stscreds.NewCredentials(out, r.roleARN, func(p *stscreds.AssumeRoleProvider) {
+			if r.roleSessionName != "" {
+				p.RoleSessionName = r.roleSessionName
+			}
+			if r.externalID != "" {
+				p.ExternalID = aws.String(r.externalID)
+			}
+			if r.duration != 0 {
+				p.Duration = r.duration
+			}
+		})
+		out = out.Copy(&aws.Config{
+			Credentials: creds,
+		})
+	}
+
+	return out
+
+}
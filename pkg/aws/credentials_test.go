@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockWebIdentityRoler struct {
+	in  *sts.AssumeRoleWithWebIdentityInput
+	out *sts.AssumeRoleWithWebIdentityOutput
+	err error
+}
+
+func (m *mockWebIdentityRoler) AssumeRoleWithWebIdentity(in *sts.AssumeRoleWithWebIdentityInput) (*sts.AssumeRoleWithWebIdentityOutput, error) {
+
+	m.in = in
+
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.out != nil {
+		return m.out, nil
+	}
+
+	return &sts.AssumeRoleWithWebIdentityOutput{
+		Credentials: &sts.Credentials{
+			AccessKeyId:     aws.String("some_key"),
+			SecretAccessKey: aws.String("some_secret"),
+			SessionToken:    aws.String("some_token"),
+			Expiration:      aws.Time(time.Now().Add(time.Hour)),
+		},
+	}, nil
+
+}
+
+func TestWebIdentityProvider_Retrieve(t *testing.T) {
+
+	tokenFile, err := ioutil.TempFile("", "token")
+
+	assert.NoError(t, err)
+
+	defer os.Remove(tokenFile.Name())
+
+	_, err = tokenFile.WriteString("some_web_token")
+
+	assert.NoError(t, err)
+	assert.NoError(t, tokenFile.Close())
+
+	client := &mockWebIdentityRoler{}
+
+	p := &webIdentityProvider{
+		client:          client,
+		roleARN:         "some_role",
+		roleSessionName: "some_session",
+		tokenFile:       tokenFile.Name(),
+	}
+
+	creds, err := p.Retrieve()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_key", creds.AccessKeyID)
+	assert.Equal(t, WebIdentityProviderName, creds.ProviderName)
+	assert.Equal(t, "some_web_token", *client.in.WebIdentityToken)
+	assert.Equal(t, "some_role", *client.in.RoleArn)
+	assert.False(t, p.IsExpired())
+
+	client.out = &sts.AssumeRoleWithWebIdentityOutput{}
+
+	_, err = p.Retrieve()
+
+	assert.True(t, errors.Is(err, NewAWSError(ErrNoCredentialsReturned, "", nil)))
+
+	client.out = &sts.AssumeRoleWithWebIdentityOutput{Credentials: &sts.Credentials{}}
+
+	creds, err = p.Retrieve()
+
+	assert.NoError(t, err)
+	assert.Empty(t, creds.AccessKeyID)
+	assert.True(t, p.IsExpired())
+
+	client.err = errors.New("some_error")
+
+	_, err = p.Retrieve()
+
+	assert.Error(t, err)
+
+	p.tokenFile = "missing_token_file"
+
+	_, err = p.Retrieve()
+
+	assert.Error(t, err)
+
+}
@@ -0,0 +1,18 @@
+package dynamodb
+
+import (
+	"context"
+)
+
+// DynamoDBAPI contains the helper methods of *DynamoDB.
+// Depend on it instead of *DynamoDB to swap the real client with a mock in unit tests
+type DynamoDBAPI interface {
+	DynamoPutItem(input interface{}, table string) error
+	DynamoPutItemWithContext(ctx context.Context, input interface{}, table string) error
+	DynamoGetItem(table, keyName, keyValue string) (*GetItemOutput, error)
+	DynamoGetItemWithContext(ctx context.Context, table, keyName, keyValue string) (*GetItemOutput, error)
+	DynamoScan(table, keyName string, keyValue interface{}) (*ScanOutput, error)
+	DynamoScanWithContext(ctx context.Context, table, keyName string, keyValue interface{}) (*ScanOutput, error)
+}
+
+var _ DynamoDBAPI = (*DynamoDB)(nil)
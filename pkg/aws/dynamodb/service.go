@@ -1,5 +1,11 @@
 package dynamodb
 
+import (
+	"context"
+
+	intError "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
 // GetItemOutput embeds *dynamodb.GetItemOutput
 type GetItemOutput interface{}
 
@@ -8,15 +14,20 @@ type ScanOutput interface{}
 
 // DynamoPutItem puts a given input in a dynamodb table
 func (svc *DynamoDB) DynamoPutItem(input interface{}, table string) error {
+	return svc.DynamoPutItemWithContext(context.Background(), input, table)
+}
+
+// DynamoPutItemWithContext is the same as DynamoPutItem with the addition of a context.Context
+func (svc *DynamoDB) DynamoPutItemWithContext(ctx context.Context, input interface{}, table string) error {
 
 	newPutItemIn, err := NewPutItemInput(input, table)
 	if err != nil {
 		return err
 	}
 
-	_, err = svc.PutItem(newPutItemIn)
+	_, err = svc.PutItemWithContext(ctx, newPutItemIn)
 	if err != nil {
-		return err
+		return intError.Wrap(err)
 	}
 
 	return nil
@@ -26,6 +37,11 @@ func (svc *DynamoDB) DynamoPutItem(input interface{}, table string) error {
 // DynamoGetItem gets an item from DynamoDB given a key and its value.
 // A *GetItemOutput will be returned
 func (svc *DynamoDB) DynamoGetItem(table, keyName, keyValue string) (*GetItemOutput, error) {
+	return svc.DynamoGetItemWithContext(context.Background(), table, keyName, keyValue)
+}
+
+// DynamoGetItemWithContext is the same as DynamoGetItem with the addition of a context.Context
+func (svc *DynamoDB) DynamoGetItemWithContext(ctx context.Context, table, keyName, keyValue string) (*GetItemOutput, error) {
 
 	in, err := NewGetItemInput(
 		table,
@@ -36,9 +52,9 @@ func (svc *DynamoDB) DynamoGetItem(table, keyName, keyValue string) (*GetItemOut
 		return nil, err
 	}
 
-	item, err := svc.GetItem(in)
+	item, err := svc.GetItemWithContext(ctx, in)
 	if err != nil {
-		return nil, err
+		return nil, intError.Wrap(err)
 	}
 
 	out := new(GetItemOutput)
@@ -55,14 +71,19 @@ func (svc *DynamoDB) DynamoGetItem(table, keyName, keyValue string) (*GetItemOut
 // DynamoScan gets items from DynamoDB given a key and its value.
 // A *ScanOutput will be returned
 func (svc *DynamoDB) DynamoScan(table, keyName string, keyValue interface{}) (*ScanOutput, error) {
+	return svc.DynamoScanWithContext(context.Background(), table, keyName, keyValue)
+}
+
+// DynamoScanWithContext is the same as DynamoScan with the addition of a context.Context
+func (svc *DynamoDB) DynamoScanWithContext(ctx context.Context, table, keyName string, keyValue interface{}) (*ScanOutput, error) {
 
 	scanInput, err := NewScanInput(table, keyName, keyValue)
 	if err != nil {
 		return nil, err
 	}
-	scanOutput, err := svc.Scan(scanInput)
+	scanOutput, err := svc.ScanWithContext(ctx, scanInput)
 	if err != nil {
-		return nil, err
+		return nil, intError.Wrap(err)
 	}
 
 	result := new(ScanOutput)
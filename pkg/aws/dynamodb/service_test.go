@@ -1,13 +1,18 @@
 package dynamodb
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/stretchr/testify/assert"
 
-	"github.com/easynetwork/aws-sdk-go-bindings/internal/configuration"
+	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/config"
 	"github.com/easynetwork/aws-sdk-go-bindings/testdata"
 )
 
@@ -19,13 +24,18 @@ func TestDynamoDB_Methods(t *testing.T) {
 
 	cfg := testdata.MockConfiguration(t)
 
+	srv := fake.New()
+	defer srv.Close()
+
+	cfg.DynamoDB.Endpoint = srv.URL
+
 	testDynamoDBDynamoPutItem(t, cfg)
 	testDynamoDBDynamoGetItem(t, cfg)
 	testDynamoDBDynamoScan(t, cfg)
 
 }
 
-func testDynamoDBDynamoPutItem(t *testing.T, cfg *configuration.Configuration) {
+func testDynamoDBDynamoPutItem(t *testing.T, cfg *config.Configuration) {
 
 	dynamoSvc := testdata.MockDynamoDB(t, cfg)
 
@@ -46,7 +56,7 @@ func testDynamoDBDynamoPutItem(t *testing.T, cfg *configuration.Configuration) {
 
 }
 
-func testDynamoDBDynamoGetItem(t *testing.T, cfg *configuration.Configuration) {
+func testDynamoDBDynamoGetItem(t *testing.T, cfg *config.Configuration) {
 
 	dynamoSvc := testdata.MockDynamoDB(t, cfg)
 
@@ -74,7 +84,7 @@ func testDynamoDBDynamoGetItem(t *testing.T, cfg *configuration.Configuration) {
 
 }
 
-func testDynamoDBDynamoScan(t *testing.T, cfg *configuration.Configuration) {
+func testDynamoDBDynamoScan(t *testing.T, cfg *config.Configuration) {
 	dynamoSvc := testdata.MockDynamoDB(t, cfg)
 
 	tableName := cfg.DynamoDB.PkgTableName
@@ -100,3 +110,34 @@ func testDynamoDBDynamoScan(t *testing.T, cfg *configuration.Configuration) {
 	assert.NotEmpty(t, *scanOut)
 
 }
+
+func TestDynamoDB_DynamoGetItemWithContext(t *testing.T) {
+
+	cfg := testdata.MockConfiguration(t)
+
+	svcIn, err := pkgAws.NewSessionInput(cfg.Region, pkgAws.WithStaticCredentials("some_key", "some_secret", ""))
+
+	assert.NoError(t, err)
+
+	awsSvc, err := pkgAws.New(svcIn)
+
+	assert.NoError(t, err)
+
+	dynamoNewSvc, err := New(awsSvc, cfg.DynamoDB.Endpoint)
+
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = dynamoNewSvc.DynamoGetItemWithContext(
+		ctx,
+		cfg.DynamoDB.PkgTableName,
+		cfg.DynamoDB.PrimaryKey,
+		cfg.DynamoDB.PrimaryKey,
+	)
+
+	assert.Error(t, err)
+	assert.Equal(t, request.CanceledErrorCode, err.(awserr.Error).Code())
+
+}
@@ -1,8 +1,6 @@
 package dynamodb
 
 import (
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 
 	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
@@ -13,17 +11,15 @@ type DynamoDB struct {
 	*dynamodb.DynamoDB
 }
 
-// New returns a new *DynamoDB
-func New(svc *pkgAws.Session, endpoint string) (*DynamoDB, error) {
+// New returns a new *DynamoDB.
+// endpoint and opts only apply to the returned client, svc is left untouched
+func New(svc *pkgAws.Session, endpoint string, opts ...pkgAws.ClientOption) (*DynamoDB, error) {
 
 	if endpoint != "" {
-		svc.Config.Endpoint = aws.String(endpoint)
+		opts = append([]pkgAws.ClientOption{pkgAws.WithEndpoint(endpoint)}, opts...)
 	}
 
-	newSvc, err := session.NewSession(svc.Config)
-	if err != nil {
-		return nil, err
-	}
+	newSvc := svc.ServiceSession(opts...)
 
 	dynamoSvc := &DynamoDB{
 		DynamoDB: dynamodb.New(newSvc),
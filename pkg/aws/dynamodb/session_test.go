@@ -26,5 +26,7 @@ func TestNew(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, snsSvc)
+	assert.Equal(t, cfg.DynamoDB.Endpoint, *snsSvc.Config.Endpoint)
+	assert.Nil(t, awsSvc.Config.Endpoint)
 
 }
@@ -17,10 +17,10 @@ import (
 func NewPutItemInput(input interface{}, table string) (*dynamodb.PutItemInput, error) {
 
 	if reflect.DeepEqual(input, reflect.Zero(reflect.TypeOf(input)).Interface()) {
-		return nil, intError.Format(ErrEmptyParameter, Input)
+		return nil, intError.NewValidationError(ErrEmptyParameter, Input)
 	}
 	if table == "" {
-		return nil, intError.Format(ErrEmptyParameter, Table)
+		return nil, intError.NewValidationError(ErrEmptyParameter, Table)
 	}
 
 	dynamoInput, err := dynamodbattribute.MarshalMap(input)
@@ -40,13 +40,13 @@ func NewPutItemInput(input interface{}, table string) (*dynamodb.PutItemInput, e
 func NewGetItemInput(table, keyName, keyValue string) (*dynamodb.GetItemInput, error) {
 
 	if table == "" {
-		return nil, intError.Format(ErrEmptyParameter, Table)
+		return nil, intError.NewValidationError(ErrEmptyParameter, Table)
 	}
 	if keyName == "" {
-		return nil, intError.Format(ErrEmptyParameter, KeyName)
+		return nil, intError.NewValidationError(ErrEmptyParameter, KeyName)
 	}
 	if keyValue == "" {
-		return nil, intError.Format(ErrEmptyParameter, KeyValue)
+		return nil, intError.NewValidationError(ErrEmptyParameter, KeyValue)
 	}
 
 	out := &dynamodb.GetItemInput{}
@@ -67,13 +67,13 @@ func NewGetItemInput(table, keyName, keyValue string) (*dynamodb.GetItemInput, e
 func NewScanInput(table, keyName string, keyValue interface{}) (*dynamodb.ScanInput, error) {
 
 	if table == "" {
-		return nil, intError.Format(ErrEmptyParameter, table)
+		return nil, intError.NewValidationError(ErrEmptyParameter, Table)
 	}
 	if keyName == "" {
-		return nil, intError.Format(ErrEmptyParameter, KeyName)
+		return nil, intError.NewValidationError(ErrEmptyParameter, KeyName)
 	}
 	if keyValue == nil {
-		return nil, intError.Format(ErrEmptyParameter, KeyValue)
+		return nil, intError.NewValidationError(ErrEmptyParameter, KeyValue)
 	}
 
 	filter := expression.Name(keyName).Equal(expression.Value(keyValue))
@@ -98,15 +98,15 @@ func UnmarshalStreamImage(input events.DynamoDBEventRecord, output interface{})
 	img := input.Change.NewImage
 
 	if reflect.DeepEqual(input, reflect.Zero(reflect.TypeOf(input)).Interface()) {
-		return intError.Format(ErrEmptyParameter, Input)
+		return intError.NewValidationError(ErrEmptyParameter, Input)
 	}
 
 	if reflect.ValueOf(output).Kind() != reflect.Ptr {
-		return intError.Format(ErrNoPointerParameter, Output)
+		return intError.NewValidationError(ErrNoPointerParameter, Output)
 	}
 
 	if len(img) == 0 {
-		return intError.Format(ErrNoPointerParameter, NewImage)
+		return intError.NewValidationError(ErrNoPointerParameter, NewImage)
 	}
 
 	dbAttrMap := make(map[string]*dynamodb.AttributeValue)
@@ -133,7 +133,7 @@ func UnmarshalStreamImage(input events.DynamoDBEventRecord, output interface{})
 func UnmarshalGetItemOutput(input *dynamodb.GetItemOutput, out interface{}) error {
 
 	if reflect.ValueOf(out).Kind() != reflect.Ptr {
-		return intError.Format(ErrNoPointerParameter, Input)
+		return intError.NewValidationError(ErrNoPointerParameter, Input)
 	}
 
 	unmarshalError := dynamodbattribute.UnmarshalMap(input.Item, out)
@@ -148,7 +148,7 @@ func UnmarshalGetItemOutput(input *dynamodb.GetItemOutput, out interface{}) erro
 // UnmarshalScanOutput unmarshals a *dynamodb.ScanOutput into a passed interface reference
 func UnmarshalScanOutput(input *dynamodb.ScanOutput, out interface{}) error {
 	if reflect.ValueOf(out).Kind() != reflect.Ptr {
-		return intError.Format(ErrNoPointerParameter, Input)
+		return intError.NewValidationError(ErrNoPointerParameter, Input)
 	}
 	unmarshalError := dynamodbattribute.UnmarshalListOfMaps(input.Items, out)
 	if unmarshalError != nil {
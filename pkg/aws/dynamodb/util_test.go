@@ -2,12 +2,15 @@ package dynamodb
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/stretchr/testify/assert"
+
+	intError "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
 )
 
 type TestUnmarshalStreamImageType struct {
@@ -30,6 +33,7 @@ func TestNewPutItemInput(t *testing.T) {
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), ErrEmptyParameter)
+	assert.True(t, errors.Is(err, &intError.ValidationError{Code: ErrEmptyParameter, Parameter: Table}))
 
 }
 
@@ -1,8 +1,47 @@
 package aws
 
+import (
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
 const (
 
 	// ErrNoRegionProvided is used when no region was provided
 	// to initialize a new aws  session
 	ErrNoRegionProvided = "NoRegionProvided"
+
+	// ErrEmptyParameter is used when a required parameter is empty
+	ErrEmptyParameter = "EmptyParameter"
+
+	// ErrInvalidParameter is used when a parameter is out of its allowed range
+	ErrInvalidParameter = "InvalidParameter"
+
+	// ErrMultipleCredentialSources is used when more than one credential source
+	// has been set on the same *SessionInput
+	ErrMultipleCredentialSources = "MultipleCredentialSources"
+
+	// ErrNoCredentialsReturned is used when sts.AssumeRoleWithWebIdentity
+	// succeeds without returning any credentials
+	ErrNoCredentialsReturned = "NoCredentialsReturned"
 )
+
+// ValidationError is returned by every package when a parameter fails validation.
+// Its Code is one of the Err* constants of the package returning it and its Parameter
+// is one of the parameter names of the same package, so it can be matched like:
+//
+//	errors.Is(err, &aws.ValidationError{Code: s3.ErrEmptyParameter, Parameter: s3.BucketName})
+type ValidationError = intErr.ValidationError
+
+// AWSError is returned by every package when aws-sdk-go returns an awserr.Error.
+// It exposes the aws error code, the request id and the http status code:
+//
+//	var awsErr *aws.AWSError
+//	if errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeResourceNotFoundException {
+//		...
+//	}
+type AWSError = intErr.AWSError
+
+// NewAWSError returns a new *AWSError to be used as an errors.Is target or by fakes
+func NewAWSError(code, message string, origErr error) error {
+	return intErr.NewAWSError(code, message, origErr)
+}
@@ -0,0 +1,33 @@
+// Package fake serves an in-memory implementation of the aws wire protocol on an
+// httptest.Server, covering the operations used by the bindings:
+//
+//	S3:       CreateBucket, HeadBucket, GetBucketLocation, PutObject, PostObject, GetObject, HeadObject,
+//	          ListObjectsV2, multipart uploads, CopyObject, UploadPartCopy, GetObjectTagging, PutObjectTagging,
+//	          DeleteObjectTagging, DeleteObject, DeleteObjects, ListObjectVersions, bucket versioning,
+//	          lifecycle, CORS, public access block, policy and default encryption, server-side encryption
+//	DynamoDB: CreateTable, DescribeTable, DeleteTable, PutItem, GetItem, Scan
+//	SQS:      CreateQueue, GetQueueUrl, GetQueueAttributes, SendMessage, ReceiveMessage
+//	SNS:      Publish
+//
+// Every service constructor can be pointed to it by passing Server.URL as endpoint.
+// S3 clients also need path style addressing:
+//
+//	srv := fake.New()
+//	defer srv.Close()
+//
+//	s3Svc, err := s3.New(awsSvc, srv.URL, aws.WithS3ForcePathStyle(true))
+//
+// Requests are not authenticated but still need to be signed, so the session
+// has to be created with some credentials, like aws.WithStaticCredentials.
+// Server.Session returns an already configured session. Presigned URLs and POST
+// policies are accepted without checking their signature, though POST policy
+// conditions and expiration are enforced.
+//
+// Server-side encryption is recorded but objects are kept in clear, and SSE-C keys are checked
+// against the MD5 of the key they were written with. As on S3, the ETags of objects encrypted with
+// SSE-KMS or SSE-C are not the MD5 of their body. The SDK only sends SSE-C keys over HTTPS,
+// served by NewTLS: clients have to trust its certificate with aws.WithHTTPClient(srv.Client())
+//
+// Bucket lifecycle, CORS, public access block, policy and default encryption configurations
+// are stored as sent and never enforced
+package fake
@@ -0,0 +1,433 @@
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const dynamoDBTargetPrefix = "DynamoDB_20120810."
+
+// table is an in-memory DynamoDB table
+type table struct {
+	name                  string
+	keySchema             []keySchemaElement
+	attributeDefinitions  json.RawMessage
+	provisionedThroughput json.RawMessage
+	creationDateTime      time.Time
+	items                 map[string]dynamoItem
+	order                 []string
+}
+
+// dynamoItem maps attribute names to their raw json attribute values
+type dynamoItem map[string]json.RawMessage
+
+type keySchemaElement struct {
+	AttributeName string `json:"AttributeName"`
+	KeyType       string `json:"KeyType"`
+}
+
+type tableDescription struct {
+	TableName             string             `json:"TableName"`
+	TableArn              string             `json:"TableArn"`
+	TableStatus           string             `json:"TableStatus"`
+	KeySchema             []keySchemaElement `json:"KeySchema"`
+	AttributeDefinitions  json.RawMessage    `json:"AttributeDefinitions,omitempty"`
+	ProvisionedThroughput json.RawMessage    `json:"ProvisionedThroughput,omitempty"`
+	CreationDateTime      float64            `json:"CreationDateTime"`
+	ItemCount             int                `json:"ItemCount"`
+}
+
+type dynamoRequest struct {
+	TableName                 string                     `json:"TableName"`
+	KeySchema                 []keySchemaElement         `json:"KeySchema"`
+	AttributeDefinitions      json.RawMessage            `json:"AttributeDefinitions"`
+	ProvisionedThroughput     json.RawMessage            `json:"ProvisionedThroughput"`
+	Item                      dynamoItem                 `json:"Item"`
+	Key                       dynamoItem                 `json:"Key"`
+	FilterExpression          string                     `json:"FilterExpression"`
+	ProjectionExpression      string                     `json:"ProjectionExpression"`
+	ExpressionAttributeNames  map[string]string          `json:"ExpressionAttributeNames"`
+	ExpressionAttributeValues map[string]json.RawMessage `json:"ExpressionAttributeValues"`
+}
+
+// dynamoError is returned by the operations to be written as a DynamoDB error response
+type dynamoError struct {
+	code    string
+	message string
+}
+
+func (e *dynamoError) Error() string {
+	return e.code + ": " + e.message
+}
+
+var errResourceNotFound = &dynamoError{
+	code:    "ResourceNotFoundException",
+	message: "Requested resource not found",
+}
+
+// Item returns the raw json attribute values of the item stored in tableName under key,
+// where key maps the key attribute names to raw json attribute values like {"S":"some_value"}
+func (srv *Server) Item(tableName string, key map[string]json.RawMessage) (map[string]json.RawMessage, bool) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	t, ok := srv.tables[tableName]
+	if !ok {
+		return nil, false
+	}
+
+	k, err := t.itemKey(key)
+	if err != nil {
+		return nil, false
+	}
+
+	item, ok := t.items[k]
+
+	return item, ok
+
+}
+
+// serveDynamoDB handles a DynamoDB json 1.0 request
+func (srv *Server) serveDynamoDB(w http.ResponseWriter, r *http.Request) {
+
+	var in dynamoRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeDynamoError(w, &dynamoError{code: "SerializationException", message: err.Error()})
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	var out interface{}
+	var err error
+
+	switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), dynamoDBTargetPrefix) {
+	case "CreateTable":
+		out, err = srv.dynamoCreateTable(&in)
+	case "DescribeTable":
+		out, err = srv.dynamoDescribeTable(&in)
+	case "DeleteTable":
+		out, err = srv.dynamoDeleteTable(&in)
+	case "PutItem":
+		out, err = srv.dynamoPutItem(&in)
+	case "GetItem":
+		out, err = srv.dynamoGetItem(&in)
+	case "Scan":
+		out, err = srv.dynamoScan(&in)
+	default:
+		err = &dynamoError{code: "UnknownOperationException", message: "The requested operation is not supported by the fake backend."}
+	}
+
+	if err != nil {
+		writeDynamoError(w, err.(*dynamoError))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	w.Header().Set("X-Amzn-Requestid", newRequestID())
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(out)
+
+}
+
+func (srv *Server) dynamoCreateTable(in *dynamoRequest) (interface{}, error) {
+
+	if _, ok := srv.tables[in.TableName]; ok {
+		return nil, &dynamoError{code: "ResourceInUseException", message: "Table already exists: " + in.TableName}
+	}
+	if len(in.KeySchema) == 0 {
+		return nil, &dynamoError{code: "ValidationException", message: "No key schema provided"}
+	}
+
+	t := &table{
+		name:                  in.TableName,
+		keySchema:             in.KeySchema,
+		attributeDefinitions:  in.AttributeDefinitions,
+		provisionedThroughput: in.ProvisionedThroughput,
+		creationDateTime:      time.Now(),
+		items:                 make(map[string]dynamoItem),
+	}
+
+	srv.tables[in.TableName] = t
+
+	return map[string]interface{}{
+		"TableDescription": t.description("ACTIVE"),
+	}, nil
+
+}
+
+func (srv *Server) dynamoDescribeTable(in *dynamoRequest) (interface{}, error) {
+
+	t, ok := srv.tables[in.TableName]
+	if !ok {
+		return nil, errResourceNotFound
+	}
+
+	return map[string]interface{}{
+		"Table": t.description("ACTIVE"),
+	}, nil
+
+}
+
+func (srv *Server) dynamoDeleteTable(in *dynamoRequest) (interface{}, error) {
+
+	t, ok := srv.tables[in.TableName]
+	if !ok {
+		return nil, errResourceNotFound
+	}
+
+	delete(srv.tables, in.TableName)
+
+	return map[string]interface{}{
+		"TableDescription": t.description("DELETING"),
+	}, nil
+
+}
+
+func (srv *Server) dynamoPutItem(in *dynamoRequest) (interface{}, error) {
+
+	t, ok := srv.tables[in.TableName]
+	if !ok {
+		return nil, errResourceNotFound
+	}
+
+	k, err := t.itemKey(in.Item)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := t.items[k]; !ok {
+		t.order = append(t.order, k)
+	}
+
+	t.items[k] = in.Item
+
+	return struct{}{}, nil
+
+}
+
+func (srv *Server) dynamoGetItem(in *dynamoRequest) (interface{}, error) {
+
+	t, ok := srv.tables[in.TableName]
+	if !ok {
+		return nil, errResourceNotFound
+	}
+
+	k, err := t.itemKey(in.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	item, ok := t.items[k]
+	if !ok {
+		return struct{}{}, nil
+	}
+
+	return map[string]interface{}{
+		"Item": item,
+	}, nil
+
+}
+
+func (srv *Server) dynamoScan(in *dynamoRequest) (interface{}, error) {
+
+	t, ok := srv.tables[in.TableName]
+	if !ok {
+		return nil, errResourceNotFound
+	}
+
+	items := make([]dynamoItem, 0)
+
+	for _, k := range t.order {
+
+		item := t.items[k]
+
+		match, err := matchFilter(in, item)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+
+		items = append(items, project(in, item))
+
+	}
+
+	return map[string]interface{}{
+		"Items":        items,
+		"Count":        len(items),
+		"ScannedCount": len(t.order),
+	}, nil
+
+}
+
+// description returns the json description of t
+func (t *table) description(status string) *tableDescription {
+	return &tableDescription{
+		TableName:             t.name,
+		TableArn:              "arn:aws:dynamodb:" + Region + ":" + AccountID + ":table/" + t.name,
+		TableStatus:           status,
+		KeySchema:             t.keySchema,
+		AttributeDefinitions:  t.attributeDefinitions,
+		ProvisionedThroughput: t.provisionedThroughput,
+		CreationDateTime:      float64(t.creationDateTime.Unix()),
+		ItemCount:             len(t.items),
+	}
+}
+
+// itemKey returns the string identifying item in t, built from its key attributes
+func (t *table) itemKey(item dynamoItem) (string, error) {
+
+	parts := make([]string, 0, len(t.keySchema))
+
+	for _, k := range t.keySchema {
+
+		v, ok := item[k.AttributeName]
+		if !ok {
+			return "", &dynamoError{code: "ValidationException", message: "One of the required keys was not given a value"}
+		}
+
+		parts = append(parts, compact(v))
+
+	}
+
+	return strings.Join(parts, "\x00"), nil
+
+}
+
+// matchFilter evaluates the FilterExpression of in against item.
+// Only comparisons like `#name = :value` or `#name <> :value` joined by AND are supported
+func matchFilter(in *dynamoRequest, item dynamoItem) (bool, error) {
+
+	expr := strings.TrimSpace(in.FilterExpression)
+	if expr == "" {
+		return true, nil
+	}
+
+	expr = strings.NewReplacer("(", " ", ")", " ").Replace(expr)
+
+	for _, cond := range splitAnd(expr) {
+
+		op := "="
+		if strings.Contains(cond, "<>") {
+			op = "<>"
+		}
+
+		operands := strings.SplitN(cond, op, 2)
+		if len(operands) != 2 {
+			return false, &dynamoError{code: "ValidationException", message: "Unsupported FilterExpression: " + in.FilterExpression}
+		}
+
+		left, lok := resolveOperand(in, item, strings.TrimSpace(operands[0]))
+		right, rok := resolveOperand(in, item, strings.TrimSpace(operands[1]))
+
+		equal := lok && rok && left == right
+
+		if (op == "=" && !equal) || (op == "<>" && equal) {
+			return false, nil
+		}
+
+	}
+
+	return true, nil
+
+}
+
+// resolveOperand returns the compacted raw value of an expression operand
+func resolveOperand(in *dynamoRequest, item dynamoItem, operand string) (string, bool) {
+
+	if strings.HasPrefix(operand, ":") {
+		v, ok := in.ExpressionAttributeValues[operand]
+		return compact(v), ok
+	}
+
+	name := operand
+	if strings.HasPrefix(operand, "#") {
+		name = in.ExpressionAttributeNames[operand]
+	}
+
+	v, ok := item[name]
+
+	return compact(v), ok
+
+}
+
+// project returns the attributes of item listed in the ProjectionExpression of in
+func project(in *dynamoRequest, item dynamoItem) dynamoItem {
+
+	if strings.TrimSpace(in.ProjectionExpression) == "" {
+		return item
+	}
+
+	out := make(dynamoItem)
+
+	for _, p := range strings.Split(in.ProjectionExpression, ",") {
+
+		name := strings.TrimSpace(p)
+		if strings.HasPrefix(name, "#") {
+			name = in.ExpressionAttributeNames[name]
+		}
+
+		if v, ok := item[name]; ok {
+			out[name] = v
+		}
+
+	}
+
+	return out
+
+}
+
+// splitAnd splits a condition expression on its AND operators
+func splitAnd(expr string) []string {
+
+	fields := strings.Fields(expr)
+	out := make([]string, 0)
+	cur := make([]string, 0)
+
+	for _, f := range fields {
+		if strings.EqualFold(f, "AND") {
+			out = append(out, strings.Join(cur, " "))
+			cur = cur[:0]
+			continue
+		}
+		cur = append(cur, f)
+	}
+
+	return append(out, strings.Join(cur, " "))
+
+}
+
+// compact returns the compacted form of a raw json value
+func compact(v json.RawMessage) string {
+
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, v); err != nil {
+		return string(v)
+	}
+
+	return buf.String()
+
+}
+
+// writeDynamoError writes a DynamoDB error response
+func writeDynamoError(w http.ResponseWriter, err *dynamoError) {
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	w.Header().Set("X-Amzn-Requestid", newRequestID())
+	w.WriteHeader(http.StatusBadRequest)
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"__type":  "com.amazonaws.dynamodb.v20120810#" + err.code,
+		"message": err.message,
+	})
+
+}
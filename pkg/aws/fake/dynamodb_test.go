@@ -0,0 +1,152 @@
+package fake
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_DynamoDB(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+
+	svc := dynamodb.New(srv.Session())
+
+	_, err := svc.DescribeTable(&dynamodb.DescribeTableInput{TableName: aws.String("some_table")})
+
+	assert.Error(t, err)
+	assert.Equal(t, dynamodb.ErrCodeResourceNotFoundException, err.(awserr.Error).Code())
+
+	_, err = svc.CreateTable(&dynamodb.CreateTableInput{
+		TableName: aws.String("some_table"),
+		AttributeDefinitions: []*dynamodb.AttributeDefinition{
+			{AttributeName: aws.String("id"), AttributeType: aws.String("S")},
+		},
+		KeySchema: []*dynamodb.KeySchemaElement{
+			{AttributeName: aws.String("id"), KeyType: aws.String("HASH")},
+		},
+		ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(1),
+			WriteCapacityUnits: aws.Int64(1),
+		},
+	})
+
+	assert.NoError(t, err)
+
+	desc, err := svc.DescribeTable(&dynamodb.DescribeTableInput{TableName: aws.String("some_table")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ACTIVE", *desc.Table.TableStatus)
+	assert.Equal(t, "id", *desc.Table.KeySchema[0].AttributeName)
+
+	for _, item := range []map[string]*dynamodb.AttributeValue{
+		{"id": {S: aws.String("1")}, "color": {S: aws.String("red")}},
+		{"id": {S: aws.String("2")}, "color": {S: aws.String("blue")}},
+		{"id": {S: aws.String("3")}, "color": {S: aws.String("red")}},
+	} {
+		_, err = svc.PutItem(&dynamodb.PutItemInput{
+			TableName: aws.String("some_table"),
+			Item:      item,
+		})
+		assert.NoError(t, err)
+	}
+
+	_, err = svc.PutItem(&dynamodb.PutItemInput{
+		TableName: aws.String("some_table"),
+		Item:      map[string]*dynamodb.AttributeValue{"color": {S: aws.String("red")}},
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "ValidationException", err.(awserr.Error).Code())
+
+	got, err := svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("some_table"),
+		Key:       map[string]*dynamodb.AttributeValue{"id": {S: aws.String("2")}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "blue", *got.Item["color"].S)
+
+	got, err = svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("some_table"),
+		Key:       map[string]*dynamodb.AttributeValue{"id": {S: aws.String("4")}},
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, got.Item)
+
+	item, ok := srv.Item("some_table", map[string]json.RawMessage{"id": json.RawMessage(`{"S":"1"}`)})
+
+	assert.True(t, ok)
+	assert.JSONEq(t, `{"S":"red"}`, string(item["color"]))
+
+	scan, err := svc.Scan(&dynamodb.ScanInput{
+		TableName:                 aws.String("some_table"),
+		FilterExpression:          aws.String("#0 = :0"),
+		ExpressionAttributeNames:  map[string]*string{"#0": aws.String("color")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":0": {S: aws.String("red")}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), *scan.Count)
+	assert.Equal(t, int64(3), *scan.ScannedCount)
+	assert.Equal(t, "1", *scan.Items[0]["id"].S)
+	assert.Equal(t, "3", *scan.Items[1]["id"].S)
+
+	scan, err = svc.Scan(&dynamodb.ScanInput{
+		TableName:                aws.String("some_table"),
+		ProjectionExpression:     aws.String("#0"),
+		ExpressionAttributeNames: map[string]*string{"#0": aws.String("id")},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, scan.Items, 3)
+	assert.Nil(t, scan.Items[0]["color"])
+
+	_, err = svc.DeleteTable(&dynamodb.DeleteTableInput{TableName: aws.String("some_table")})
+
+	assert.NoError(t, err)
+
+	_, err = svc.GetItem(&dynamodb.GetItemInput{
+		TableName: aws.String("some_table"),
+		Key:       map[string]*dynamodb.AttributeValue{"id": {S: aws.String("1")}},
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, dynamodb.ErrCodeResourceNotFoundException, err.(awserr.Error).Code())
+
+}
+
+func TestMatchFilter(t *testing.T) {
+
+	in := &dynamoRequest{
+		FilterExpression:         "(#0 = :0) AND (#1 <> :1)",
+		ExpressionAttributeNames: map[string]string{"#0": "a", "#1": "b"},
+		ExpressionAttributeValues: map[string]json.RawMessage{
+			":0": json.RawMessage(`{"S": "x"}`),
+			":1": json.RawMessage(`{"N":"1"}`),
+		},
+	}
+
+	match, err := matchFilter(in, dynamoItem{"a": json.RawMessage(`{"S":"x"}`), "b": json.RawMessage(`{"N":"2"}`)})
+
+	assert.NoError(t, err)
+	assert.True(t, match)
+
+	match, err = matchFilter(in, dynamoItem{"a": json.RawMessage(`{"S":"x"}`), "b": json.RawMessage(`{"N":"1"}`)})
+
+	assert.NoError(t, err)
+	assert.False(t, match)
+
+	in.FilterExpression = "begins_with(#0, :0)"
+
+	_, err = matchFilter(in, dynamoItem{})
+
+	assert.Error(t, err)
+
+}
@@ -0,0 +1,424 @@
+package fake
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bucket is an in-memory S3 bucket
+type bucket struct {
+	// objects holds the current version of every key
+	objects map[string]*object
+	// versions holds every version of every key, the current one last
+	versions   map[string][]*object
+	versioning string
+	location   string
+	// configs holds the bucket configurations by subresource, see bucketConfigs
+	configs map[string][]byte
+	// seq is the sequence number of the last version written
+	seq uint64
+}
+
+// object is an in-memory S3 object
+type object struct {
+	body         []byte
+	header       http.Header
+	tags         map[string]string
+	etag         string
+	lastModified time.Time
+	versionID    string
+	deleteMarker bool
+	seq          uint64
+}
+
+// s3Error is the body of an S3 error response
+type s3Error struct {
+	XMLName   xml.Name `xml:"Error"`
+	Code      string   `xml:"Code"`
+	Message   string   `xml:"Message"`
+	RequestID string   `xml:"RequestId"`
+}
+
+// storedHeaders are the request headers kept with an object and returned on reads
+var storedHeaders = []string{
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Content-Language",
+	"Content-Type",
+	"Expires",
+	"X-Amz-Storage-Class",
+}
+
+// Object returns the body of the object stored under bucketName and key
+func (srv *Server) Object(bucketName, key string) ([]byte, bool) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		return nil, false
+	}
+
+	o, ok := b.objects[key]
+	if !ok {
+		return nil, false
+	}
+
+	return o.body, true
+
+}
+
+// serveS3 handles a path style S3 request
+func (srv *Server) serveS3(w http.ResponseWriter, r *http.Request) {
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	parts := strings.SplitN(path, "/", 2)
+
+	bucketName := parts[0]
+	key := ""
+	if len(parts) == 2 {
+		key = parts[1]
+	}
+
+	if bucketName == "" {
+		writeS3Error(w, http.StatusBadRequest, "InvalidBucketName", "The specified bucket is not valid.")
+		return
+	}
+
+	query := r.URL.Query()
+	_, uploads := query["uploads"]
+	_, versions := query["versions"]
+	_, versioning := query["versioning"]
+	_, del := query["delete"]
+	_, tagging := query["tagging"]
+	_, location := query["location"]
+	config := bucketConfig(query)
+	uploadID := query.Get("uploadId")
+	copySource := r.Header.Get("X-Amz-Copy-Source")
+
+	switch {
+	case key != "" && r.Method == http.MethodPut && uploadID != "" && copySource != "":
+		srv.s3UploadPartCopy(w, r, uploadID)
+	case key != "" && r.Method == http.MethodPut && copySource != "":
+		srv.s3CopyObject(w, r, bucketName, key)
+	case key != "" && r.Method == http.MethodGet && tagging:
+		srv.s3GetObjectTagging(w, r, bucketName, key)
+	case key != "" && r.Method == http.MethodPut && tagging:
+		srv.s3PutObjectTagging(w, r, bucketName, key)
+	case key != "" && r.Method == http.MethodDelete && tagging:
+		srv.s3DeleteObjectTagging(w, r, bucketName, key)
+	case key != "" && r.Method == http.MethodPost && uploads:
+		srv.s3CreateMultipartUpload(w, r, bucketName, key)
+	case key != "" && r.Method == http.MethodPut && uploadID != "":
+		srv.s3UploadPart(w, r, uploadID)
+	case key != "" && r.Method == http.MethodPost && uploadID != "":
+		srv.s3CompleteMultipartUpload(w, r, bucketName, key, uploadID)
+	case key != "" && r.Method == http.MethodDelete && uploadID != "":
+		srv.s3AbortMultipartUpload(w, r, uploadID)
+	case key != "" && r.Method == http.MethodGet && uploadID != "":
+		srv.s3ListParts(w, r, bucketName, key, uploadID)
+	case key == "" && r.Method == http.MethodGet && versioning:
+		srv.s3GetBucketVersioning(w, r, bucketName)
+	case key == "" && r.Method == http.MethodPut && versioning:
+		srv.s3PutBucketVersioning(w, r, bucketName)
+	case key == "" && r.Method == http.MethodGet && location:
+		srv.s3GetBucketLocation(w, r, bucketName)
+	case key == "" && r.Method == http.MethodGet && config != "":
+		srv.s3GetBucketConfig(w, r, bucketName, config)
+	case key == "" && r.Method == http.MethodPut && config != "":
+		srv.s3PutBucketConfig(w, r, bucketName, config)
+	case key == "" && r.Method == http.MethodDelete && config != "":
+		srv.s3DeleteBucketConfig(w, r, bucketName, config)
+	case key == "" && r.Method == http.MethodGet && versions:
+		srv.s3ListObjectVersions(w, r, bucketName)
+	case key == "" && r.Method == http.MethodPost && del:
+		srv.s3DeleteObjects(w, r, bucketName)
+	case key == "" && r.Method == http.MethodPost:
+		srv.s3PostObject(w, r, bucketName)
+	case key == "" && r.Method == http.MethodGet:
+		srv.s3ListObjectsV2(w, r, bucketName)
+	case key == "" && r.Method == http.MethodPut:
+		srv.s3CreateBucket(w, r, bucketName)
+	case key == "" && r.Method == http.MethodHead:
+		srv.s3HeadBucket(w, r, bucketName)
+	case key != "" && r.Method == http.MethodPut:
+		srv.s3PutObject(w, r, bucketName, key)
+	case key != "" && (r.Method == http.MethodGet || r.Method == http.MethodHead):
+		srv.s3GetObject(w, r, bucketName, key)
+	case key != "" && r.Method == http.MethodDelete:
+		srv.s3DeleteObject(w, r, bucketName, key)
+	default:
+		writeS3Error(w, http.StatusNotImplemented, "NotImplemented", "The requested operation is not supported by the fake backend.")
+	}
+
+}
+
+func (srv *Server) s3CreateBucket(w http.ResponseWriter, r *http.Request, bucketName string) {
+
+	in := createBucketConfiguration{}
+	if body, _ := ioutil.ReadAll(r.Body); len(body) > 0 {
+		if err := xml.Unmarshal(body, &in); err != nil {
+			writeS3Error(w, http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema")
+			return
+		}
+	}
+
+	// us-east-1 is the region of buckets created without location constraint
+	if in.LocationConstraint == "us-east-1" {
+		writeS3Error(w, http.StatusBadRequest, "InvalidLocationConstraint", "The specified location-constraint is not valid")
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	if _, ok := srv.buckets[bucketName]; ok {
+		writeS3Error(w, http.StatusConflict, "BucketAlreadyOwnedByYou", "Your previous request to create the named bucket succeeded and you already own it.")
+		return
+	}
+
+	srv.buckets[bucketName] = &bucket{
+		objects:  make(map[string]*object),
+		versions: make(map[string][]*object),
+		location: in.LocationConstraint,
+		configs:  make(map[string][]byte),
+	}
+
+	w.Header().Set("Location", "/"+bucketName)
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(http.StatusOK)
+
+}
+
+func (srv *Server) s3PutObject(w http.ResponseWriter, r *http.Request, bucketName, key string) {
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeS3Error(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+
+	tags, ok := parseTagging(r.Header.Get("X-Amz-Tagging"))
+	if !ok {
+		writeS3Error(w, http.StatusBadRequest, "InvalidTag", "The tag provided was not a valid tag.")
+		return
+	}
+
+	enc, sseErr := encryption(r)
+	if sseErr != nil {
+		sseErr.write(w)
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	o := &object{
+		body:         body,
+		header:       withEncryption(objectHeader(r.Header), enc),
+		tags:         tags,
+		etag:         objectETag(body, enc),
+		lastModified: time.Now().UTC(),
+	}
+
+	b.put(key, o)
+
+	setVersionID(w, b, o)
+	setEncryption(w, o.header)
+	w.Header().Set("ETag", o.etag)
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(http.StatusOK)
+
+}
+
+func (srv *Server) s3GetObject(w http.ResponseWriter, r *http.Request, bucketName, key string) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	o, ok := b.objects[key]
+	if versionID := r.URL.Query().Get("versionId"); versionID != "" {
+		o, ok = b.version(key, versionID)
+	}
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+		return
+	}
+	if o.deleteMarker {
+		w.Header().Set("X-Amz-Delete-Marker", "true")
+		writeS3Error(w, http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+		return
+	}
+	if sseErr := checkCustomerKey(r, customerKeyPrefix, o.header); sseErr != nil {
+		sseErr.write(w)
+		return
+	}
+
+	if m := r.Header.Get("If-Match"); m != "" && m != o.etag {
+		writeS3Error(w, http.StatusPreconditionFailed, "PreconditionFailed", "At least one of the pre-conditions you specified did not hold")
+		return
+	}
+
+	body := o.body
+	status := http.StatusOK
+
+	if rng := r.Header.Get("Range"); rng != "" {
+
+		start, end, ok := byteRange(rng, len(o.body))
+		if !ok {
+			writeS3Error(w, http.StatusRequestedRangeNotSatisfiable, "InvalidRange", "The requested range is not satisfiable")
+			return
+		}
+
+		body = o.body[start : end+1]
+		status = http.StatusPartialContent
+
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(o.body)))
+
+	}
+
+	for h, v := range o.header {
+		w.Header()[h] = v
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "binary/octet-stream")
+	}
+	for param, h := range responseHeaders {
+		if v := r.URL.Query().Get(param); v != "" {
+			w.Header().Set(h, v)
+		}
+	}
+
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.Header().Set("ETag", o.etag)
+	w.Header().Set("Last-Modified", o.lastModified.Format(http.TimeFormat))
+	setVersionID(w, b, o)
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(status)
+
+	if r.Method != http.MethodHead {
+		w.Write(body)
+	}
+
+}
+
+// byteRange parses a single "bytes=start-end", "bytes=start-" or "bytes=-suffix" range
+// of a body of size bytes, returning its inclusive bounds
+func byteRange(rng string, size int) (int, int, bool) {
+
+	spec := strings.TrimPrefix(rng, "bytes=")
+	if spec == rng || strings.Contains(spec, ",") {
+		return 0, 0, false
+	}
+
+	bounds := strings.SplitN(spec, "-", 2)
+	if len(bounds) != 2 {
+		return 0, 0, false
+	}
+
+	if bounds[0] == "" {
+		suffix, err := strconv.Atoi(bounds[1])
+		if err != nil || suffix <= 0 || size == 0 {
+			return 0, 0, false
+		}
+		if suffix > size {
+			suffix = size
+		}
+		return size - suffix, size - 1, true
+	}
+
+	start, err := strconv.Atoi(bounds[0])
+	if err != nil || start >= size {
+		return 0, 0, false
+	}
+
+	end := size - 1
+	if bounds[1] != "" {
+		if end, err = strconv.Atoi(bounds[1]); err != nil || end < start {
+			return 0, 0, false
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+
+	return start, end, true
+
+}
+
+// responseHeaders are the query parameters of GetObject overriding a header of the response
+var responseHeaders = map[string]string{
+	"response-cache-control":       "Cache-Control",
+	"response-content-disposition": "Content-Disposition",
+	"response-content-encoding":    "Content-Encoding",
+	"response-content-language":    "Content-Language",
+	"response-content-type":        "Content-Type",
+	"response-expires":             "Expires",
+}
+
+// objectHeader returns the headers of a write request to be kept with an object
+func objectHeader(h http.Header) http.Header {
+
+	out := make(http.Header)
+
+	for _, k := range storedHeaders {
+		if v := h.Get(k); v != "" {
+			out.Set(k, v)
+		}
+	}
+	for k, v := range h {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			out[k] = v
+		}
+	}
+
+	return out
+
+}
+
+// setVersionID sets the version ID header of a response about o, once versioning
+// has been configured on b
+func setVersionID(w http.ResponseWriter, b *bucket, o *object) {
+
+	if b.versioning != "" {
+		w.Header().Set("X-Amz-Version-Id", o.versionID)
+	}
+
+}
+
+// writeS3Error writes an S3 error response
+func writeS3Error(w http.ResponseWriter, status int, code, message string) {
+
+	requestID := newRequestID()
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("X-Amz-Request-Id", requestID)
+	w.WriteHeader(status)
+
+	xml.NewEncoder(w).Encode(s3Error{
+		Code:      code,
+		Message:   message,
+		RequestID: requestID,
+	})
+
+}
@@ -0,0 +1,153 @@
+package fake
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+)
+
+// bucketConfigs are the bucket configurations stored as sent and returned as they are,
+// along with the error code of a bucket without them
+var bucketConfigs = map[string]string{
+	"lifecycle":         "NoSuchLifecycleConfiguration",
+	"cors":              "NoSuchCORSConfiguration",
+	"publicAccessBlock": "NoSuchPublicAccessBlockConfiguration",
+	"policy":            "NoSuchBucketPolicy",
+	"encryption":        "ServerSideEncryptionConfigurationNotFoundError",
+}
+
+// createBucketConfiguration is the optional body of CreateBucket requests
+type createBucketConfiguration struct {
+	XMLName            xml.Name `xml:"CreateBucketConfiguration"`
+	LocationConstraint string   `xml:"LocationConstraint"`
+}
+
+// locationConstraint is the body of a GetBucketLocation response
+type locationConstraint struct {
+	XMLName  xml.Name `xml:"LocationConstraint"`
+	Location string   `xml:",chardata"`
+}
+
+// bucketConfig returns the bucket configuration subresource of query, if any
+func bucketConfig(query map[string][]string) string {
+
+	for name := range bucketConfigs {
+		if _, ok := query[name]; ok {
+			return name
+		}
+	}
+
+	return ""
+
+}
+
+func (srv *Server) s3HeadBucket(w http.ResponseWriter, r *http.Request, bucketName string) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+
+	if _, ok := srv.buckets[bucketName]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+
+}
+
+func (srv *Server) s3GetBucketLocation(w http.ResponseWriter, r *http.Request, bucketName string) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	writeS3Response(w, locationConstraint{Location: b.location})
+
+}
+
+func (srv *Server) s3GetBucketConfig(w http.ResponseWriter, r *http.Request, bucketName, name string) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	config, ok := b.configs[name]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, bucketConfigs[name], "The specified configuration does not exist")
+		return
+	}
+
+	if name == "policy" {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "application/xml")
+	}
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(http.StatusOK)
+	w.Write(config)
+
+}
+
+func (srv *Server) s3PutBucketConfig(w http.ResponseWriter, r *http.Request, bucketName, name string) {
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeS3Error(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+
+	if name == "policy" && !json.Valid(body) {
+		writeS3Error(w, http.StatusBadRequest, "MalformedPolicy", "Policies must be valid JSON")
+		return
+	}
+	if name != "policy" && xml.Unmarshal(body, new(struct{})) != nil {
+		writeS3Error(w, http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema")
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	b.configs[name] = body
+
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(http.StatusOK)
+
+}
+
+func (srv *Server) s3DeleteBucketConfig(w http.ResponseWriter, r *http.Request, bucketName, name string) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	delete(b.configs, name)
+
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(http.StatusNoContent)
+
+}
@@ -0,0 +1,107 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_S3Bucket(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+
+	svc := s3.New(srv.Session())
+
+	_, err := svc.HeadBucket(&s3.HeadBucketInput{Bucket: aws.String("some_bucket")})
+
+	assert.Error(t, err)
+	assert.Equal(t, 404, err.(awserr.RequestFailure).StatusCode())
+
+	_, err = svc.CreateBucket(&s3.CreateBucketInput{
+		Bucket:                    aws.String("some_bucket"),
+		CreateBucketConfiguration: &s3.CreateBucketConfiguration{LocationConstraint: aws.String("eu-west-1")},
+	})
+
+	assert.NoError(t, err)
+
+	_, err = svc.HeadBucket(&s3.HeadBucketInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+
+	location, err := svc.GetBucketLocation(&s3.GetBucketLocationInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "eu-west-1", aws.StringValue(location.LocationConstraint))
+
+	_, err = svc.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String("some_bucket")})
+
+	assert.Error(t, err)
+	assert.Equal(t, s3.ErrCodeBucketAlreadyOwnedByYou, err.(awserr.Error).Code())
+
+	_, err = svc.CreateBucket(&s3.CreateBucketInput{
+		Bucket:                    aws.String("other_bucket"),
+		CreateBucketConfiguration: &s3.CreateBucketConfiguration{LocationConstraint: aws.String("us-east-1")},
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "InvalidLocationConstraint", err.(awserr.Error).Code())
+
+	_, err = svc.GetBucketCors(&s3.GetBucketCorsInput{Bucket: aws.String("some_bucket")})
+
+	assert.Error(t, err)
+	assert.Equal(t, "NoSuchCORSConfiguration", err.(awserr.Error).Code())
+
+	_, err = svc.PutBucketCors(&s3.PutBucketCorsInput{
+		Bucket: aws.String("some_bucket"),
+		CORSConfiguration: &s3.CORSConfiguration{CORSRules: []*s3.CORSRule{{
+			AllowedMethods: aws.StringSlice([]string{"GET"}),
+			AllowedOrigins: aws.StringSlice([]string{"*"}),
+		}}},
+	})
+
+	assert.NoError(t, err)
+
+	cors, err := svc.GetBucketCors(&s3.GetBucketCorsInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+	assert.Len(t, cors.CORSRules, 1)
+	assert.Equal(t, []string{"GET"}, aws.StringValueSlice(cors.CORSRules[0].AllowedMethods))
+
+	_, err = svc.DeleteBucketCors(&s3.DeleteBucketCorsInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+
+	_, err = svc.GetBucketCors(&s3.GetBucketCorsInput{Bucket: aws.String("some_bucket")})
+
+	assert.Error(t, err)
+
+	_, err = svc.PutBucketPolicy(&s3.PutBucketPolicyInput{
+		Bucket: aws.String("some_bucket"),
+		Policy: aws.String("some_policy"),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "MalformedPolicy", err.(awserr.Error).Code())
+
+	_, err = svc.PutBucketPolicy(&s3.PutBucketPolicyInput{
+		Bucket: aws.String("some_bucket"),
+		Policy: aws.String(`{"Version":"2012-10-17","Statement":[]}`),
+	})
+
+	assert.NoError(t, err)
+
+	policy, err := svc.GetBucketPolicy(&s3.GetBucketPolicyInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Version":"2012-10-17","Statement":[]}`, aws.StringValue(policy.Policy))
+
+	_, err = svc.GetBucketEncryption(&s3.GetBucketEncryptionInput{Bucket: aws.String("some_missing_bucket")})
+
+	assert.Error(t, err)
+	assert.Equal(t, s3.ErrCodeNoSuchBucket, err.(awserr.Error).Code())
+
+}
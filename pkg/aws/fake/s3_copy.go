@@ -0,0 +1,337 @@
+package fake
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// copyObjectResult is the body of a CopyObject response
+type copyObjectResult struct {
+	XMLName      xml.Name `xml:"CopyObjectResult"`
+	ETag         string   `xml:"ETag"`
+	LastModified string   `xml:"LastModified"`
+}
+
+// copyPartResult is the body of an UploadPartCopy response
+type copyPartResult struct {
+	XMLName      xml.Name `xml:"CopyPartResult"`
+	ETag         string   `xml:"ETag"`
+	LastModified string   `xml:"LastModified"`
+}
+
+// tagging is the body of GetObjectTagging responses and PutObjectTagging requests
+type tagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	TagSet  []tag    `xml:"TagSet>Tag"`
+}
+
+// tag is a tag of an object
+type tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// Tags returns the tags of the object stored under bucketName and key
+func (srv *Server) Tags(bucketName, key string) (map[string]string, bool) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		return nil, false
+	}
+
+	o, ok := b.objects[key]
+	if !ok {
+		return nil, false
+	}
+
+	out := make(map[string]string, len(o.tags))
+	for k, v := range o.tags {
+		out[k] = v
+	}
+
+	return out, true
+
+}
+
+func (srv *Server) s3CopyObject(w http.ResponseWriter, r *http.Request, bucketName, key string) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	src, ok := srv.copySource(w, r)
+	if !ok {
+		return
+	}
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	tags, ok := parseTagging(r.Header.Get("X-Amz-Tagging"))
+	if !ok {
+		writeS3Error(w, http.StatusBadRequest, "InvalidTag", "The tag provided was not a valid tag.")
+		return
+	}
+
+	enc, sseErr := encryption(r)
+	if sseErr != nil {
+		sseErr.write(w)
+		return
+	}
+
+	o := &object{
+		body:         src.body,
+		header:       src.header,
+		tags:         src.tags,
+		etag:         src.etag,
+		lastModified: time.Now().UTC(),
+	}
+
+	if strings.Contains(src.etag, "-") {
+		sum := md5.Sum(src.body)
+		o.etag = strconv.Quote(hex.EncodeToString(sum[:]))
+	}
+	if r.Header.Get("X-Amz-Metadata-Directive") == "REPLACE" {
+		o.header = objectHeader(r.Header)
+	}
+	// the encryption of the source is never copied
+	o.header = withEncryption(o.header, enc)
+	if r.Header.Get("X-Amz-Tagging-Directive") == "REPLACE" {
+		o.tags = tags
+	}
+
+	b.put(key, o)
+
+	setVersionID(w, b, o)
+	setEncryption(w, o.header)
+	writeS3Response(w, copyObjectResult{
+		ETag:         o.etag,
+		LastModified: o.lastModified.Format(time.RFC3339),
+	})
+
+}
+
+func (srv *Server) s3UploadPartCopy(w http.ResponseWriter, r *http.Request, uploadID string) {
+
+	number, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil || number < 1 || number > 10000 {
+		writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "Part number must be an integer between 1 and 10000, inclusive.")
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	src, ok := srv.copySource(w, r)
+	if !ok {
+		return
+	}
+
+	u, ok := srv.uploads[uploadID]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchUpload", "The specified upload does not exist.")
+		return
+	}
+	if sseErr := checkCustomerKey(r, customerKeyPrefix, u.header); sseErr != nil {
+		sseErr.write(w)
+		return
+	}
+
+	body := src.body
+
+	if rng := r.Header.Get("X-Amz-Copy-Source-Range"); rng != "" {
+
+		start, end, ok := byteRange(rng, len(src.body))
+		if !ok || strings.HasPrefix(rng, "bytes=-") {
+			writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "The x-amz-copy-source-range value must be of the form bytes=first-last where first and last are the zero-based offsets of the first and last bytes to copy")
+			return
+		}
+
+		body = src.body[start : end+1]
+
+	}
+
+	p := &part{
+		body:         body,
+		sum:          md5.Sum(body),
+		lastModified: time.Now().UTC(),
+	}
+
+	u.parts[number] = p
+
+	writeS3Response(w, copyPartResult{
+		ETag:         strconv.Quote(hex.EncodeToString(p.sum[:])),
+		LastModified: p.lastModified.Format(time.RFC3339),
+	})
+
+}
+
+func (srv *Server) s3GetObjectTagging(w http.ResponseWriter, r *http.Request, bucketName, key string) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	o, ok := srv.taggedObject(w, bucketName, key)
+	if !ok {
+		return
+	}
+
+	keys := make([]string, 0, len(o.tags))
+	for k := range o.tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	out := tagging{TagSet: []tag{}}
+	for _, k := range keys {
+		out.TagSet = append(out.TagSet, tag{Key: k, Value: o.tags[k]})
+	}
+
+	writeS3Response(w, out)
+
+}
+
+func (srv *Server) s3PutObjectTagging(w http.ResponseWriter, r *http.Request, bucketName, key string) {
+
+	in := tagging{}
+	if err := xml.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeS3Error(w, http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema")
+		return
+	}
+	if len(in.TagSet) > 10 {
+		writeS3Error(w, http.StatusBadRequest, "BadRequest", "Object tags cannot be greater than 10")
+		return
+	}
+
+	tags := make(map[string]string, len(in.TagSet))
+	for _, t := range in.TagSet {
+		if _, ok := tags[t.Key]; ok || t.Key == "" {
+			writeS3Error(w, http.StatusBadRequest, "InvalidTag", "The tag provided was not a valid tag.")
+			return
+		}
+		tags[t.Key] = t.Value
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	o, ok := srv.taggedObject(w, bucketName, key)
+	if !ok {
+		return
+	}
+
+	// tag sets can be shared with copies, so they are replaced rather than modified
+	o.tags = tags
+
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(http.StatusOK)
+
+}
+
+func (srv *Server) s3DeleteObjectTagging(w http.ResponseWriter, r *http.Request, bucketName, key string) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	o, ok := srv.taggedObject(w, bucketName, key)
+	if !ok {
+		return
+	}
+
+	o.tags = nil
+
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(http.StatusNoContent)
+
+}
+
+// taggedObject returns the current version of the object whose tags are read or written,
+// writing the error response when not found. srv.mu must be held
+func (srv *Server) taggedObject(w http.ResponseWriter, bucketName, key string) (*object, bool) {
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return nil, false
+	}
+
+	o, ok := b.objects[key]
+	if !ok || o.deleteMarker {
+		writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+		return nil, false
+	}
+
+	return o, true
+
+}
+
+// copySource returns the object named by the X-Amz-Copy-Source header of r, checking
+// its X-Amz-Copy-Source-If-Match condition. It writes the error response when not found.
+// srv.mu must be held
+func (srv *Server) copySource(w http.ResponseWriter, r *http.Request) (*object, bool) {
+
+	source, err := url.PathUnescape(strings.TrimPrefix(r.Header.Get("X-Amz-Copy-Source"), "/"))
+	parts := strings.SplitN(source, "/", 2)
+	if err != nil || len(parts) != 2 || parts[1] == "" {
+		writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "Copy Source must mention the source bucket and key: sourcebucket/sourcekey")
+		return nil, false
+	}
+
+	b, ok := srv.buckets[parts[0]]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return nil, false
+	}
+
+	o, ok := b.objects[parts[1]]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+		return nil, false
+	}
+
+	if m := r.Header.Get("X-Amz-Copy-Source-If-Match"); m != "" && m != o.etag {
+		writeS3Error(w, http.StatusPreconditionFailed, "PreconditionFailed", "At least one of the pre-conditions you specified did not hold")
+		return nil, false
+	}
+	if sseErr := checkCustomerKey(r, copySourceCustomerKeyPrefix, o.header); sseErr != nil {
+		sseErr.write(w)
+		return nil, false
+	}
+
+	return o, true
+
+}
+
+// parseTagging parses the URL encoded tags of an X-Amz-Tagging header
+func parseTagging(header string) (map[string]string, bool) {
+
+	values, err := url.ParseQuery(header)
+	if err != nil {
+		return nil, false
+	}
+
+	tags := make(map[string]string, len(values))
+
+	for k, v := range values {
+		if k == "" || len(v) != 1 {
+			return nil, false
+		}
+		tags[k] = v[0]
+	}
+
+	return tags, true
+
+}
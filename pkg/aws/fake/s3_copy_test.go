@@ -0,0 +1,236 @@
+package fake
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_S3Copy(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+
+	svc := s3.New(srv.Session())
+
+	for _, bucketName := range []string{"some_bucket", "other_bucket"} {
+		_, err := svc.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String(bucketName)})
+		assert.NoError(t, err)
+	}
+
+	put, err := svc.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String("some_bucket"),
+		Key:         aws.String("some key"),
+		Body:        bytes.NewReader([]byte("some_body")),
+		ContentType: aws.String("text/plain"),
+		Metadata:    map[string]*string{"Some-Meta": aws.String("some_value")},
+		Tagging:     aws.String("some_tag=some_value"),
+	})
+
+	assert.NoError(t, err)
+
+	copied, err := svc.CopyObject(&s3.CopyObjectInput{
+		Bucket:            aws.String("other_bucket"),
+		Key:               aws.String("some/key"),
+		CopySource:        aws.String("some_bucket/some%20key"),
+		CopySourceIfMatch: put.ETag,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, *put.ETag, *copied.CopyObjectResult.ETag)
+
+	out, err := svc.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String("other_bucket"),
+		Key:    aws.String("some/key"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain", *out.ContentType)
+	assert.Equal(t, "some_value", *out.Metadata["Some-Meta"])
+
+	tags, err := svc.GetObjectTagging(&s3.GetObjectTaggingInput{
+		Bucket: aws.String("other_bucket"),
+		Key:    aws.String("some/key"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*s3.Tag{{Key: aws.String("some_tag"), Value: aws.String("some_value")}}, tags.TagSet)
+
+	_, err = svc.CopyObject(&s3.CopyObjectInput{
+		Bucket:            aws.String("other_bucket"),
+		Key:               aws.String("some/key"),
+		CopySource:        aws.String("some_bucket/some%20key"),
+		MetadataDirective: aws.String(s3.MetadataDirectiveReplace),
+		Metadata:          map[string]*string{"Other-Meta": aws.String("other_value")},
+		TaggingDirective:  aws.String(s3.TaggingDirectiveReplace),
+		Tagging:           aws.String("other_tag=other_value"),
+	})
+
+	assert.NoError(t, err)
+
+	out, err = svc.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String("other_bucket"),
+		Key:    aws.String("some/key"),
+	})
+
+	assert.NoError(t, err)
+	assert.Nil(t, out.Metadata["Some-Meta"])
+	assert.Equal(t, "other_value", *out.Metadata["Other-Meta"])
+
+	stored, ok := srv.Tags("other_bucket", "some/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"other_tag": "other_value"}, stored)
+
+	_, err = svc.CopyObject(&s3.CopyObjectInput{
+		Bucket:            aws.String("other_bucket"),
+		Key:               aws.String("some/key"),
+		CopySource:        aws.String("some_bucket/some%20key"),
+		CopySourceIfMatch: aws.String(`"some_etag"`),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "PreconditionFailed", err.(awserr.Error).Code())
+
+	upload, err := svc.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket: aws.String("other_bucket"),
+		Key:    aws.String("other/key"),
+	})
+
+	assert.NoError(t, err)
+
+	part, err := svc.UploadPartCopy(&s3.UploadPartCopyInput{
+		Bucket:          aws.String("other_bucket"),
+		Key:             aws.String("other/key"),
+		UploadId:        upload.UploadId,
+		PartNumber:      aws.Int64(1),
+		CopySource:      aws.String("some_bucket/some%20key"),
+		CopySourceRange: aws.String("bytes=5-8"),
+	})
+
+	assert.NoError(t, err)
+
+	_, err = svc.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String("other_bucket"),
+		Key:      aws.String("other/key"),
+		UploadId: upload.UploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: []*s3.CompletedPart{
+			{PartNumber: aws.Int64(1), ETag: part.CopyPartResult.ETag},
+		}},
+	})
+
+	assert.NoError(t, err)
+
+	body, ok := srv.Object("other_bucket", "other/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, "body", string(body))
+
+	_, err = svc.CopyObject(&s3.CopyObjectInput{
+		Bucket:     aws.String("other_bucket"),
+		Key:        aws.String("some/key"),
+		CopySource: aws.String("some_bucket/some_missing_key"),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, s3.ErrCodeNoSuchKey, err.(awserr.Error).Code())
+
+}
+
+func TestServer_S3Tagging(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+
+	svc := s3.New(srv.Session())
+
+	_, err := svc.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+
+	_, err = svc.PutObject(&s3.PutObjectInput{
+		Bucket:  aws.String("some_bucket"),
+		Key:     aws.String("some/key"),
+		Body:    bytes.NewReader([]byte("some_body")),
+		Tagging: aws.String("some_tag=some_value"),
+	})
+
+	assert.NoError(t, err)
+
+	_, err = svc.CopyObject(&s3.CopyObjectInput{
+		Bucket:     aws.String("some_bucket"),
+		Key:        aws.String("other/key"),
+		CopySource: aws.String("some_bucket/some/key"),
+	})
+
+	assert.NoError(t, err)
+
+	_, err = svc.PutObjectTagging(&s3.PutObjectTaggingInput{
+		Bucket: aws.String("some_bucket"),
+		Key:    aws.String("some/key"),
+		Tagging: &s3.Tagging{TagSet: []*s3.Tag{
+			{Key: aws.String("other_tag"), Value: aws.String("other_value")},
+			{Key: aws.String("third_tag"), Value: aws.String("")},
+		}},
+	})
+
+	assert.NoError(t, err)
+
+	tags, _ := srv.Tags("some_bucket", "some/key")
+
+	assert.Equal(t, map[string]string{"other_tag": "other_value", "third_tag": ""}, tags)
+
+	// the copy shared the tag set of its source, which must not have been modified
+	tags, _ = srv.Tags("some_bucket", "other/key")
+
+	assert.Equal(t, map[string]string{"some_tag": "some_value"}, tags)
+
+	tagSet := make([]*s3.Tag, 11)
+	for i := range tagSet {
+		tagSet[i] = &s3.Tag{Key: aws.String(string(rune('a' + i))), Value: aws.String("some_value")}
+	}
+
+	for code, set := range map[string][]*s3.Tag{
+		"BadRequest": tagSet,
+		"InvalidTag": {tagSet[0], tagSet[0]},
+	} {
+
+		_, err = svc.PutObjectTagging(&s3.PutObjectTaggingInput{
+			Bucket:  aws.String("some_bucket"),
+			Key:     aws.String("some/key"),
+			Tagging: &s3.Tagging{TagSet: set},
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, code, err.(awserr.Error).Code())
+
+	}
+
+	_, err = svc.DeleteObjectTagging(&s3.DeleteObjectTaggingInput{
+		Bucket: aws.String("some_bucket"),
+		Key:    aws.String("some/key"),
+	})
+
+	assert.NoError(t, err)
+
+	out, err := svc.GetObjectTagging(&s3.GetObjectTaggingInput{
+		Bucket: aws.String("some_bucket"),
+		Key:    aws.String("some/key"),
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, out.TagSet)
+
+	_, err = svc.DeleteObjectTagging(&s3.DeleteObjectTaggingInput{
+		Bucket: aws.String("some_bucket"),
+		Key:    aws.String("some_missing_key"),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "NoSuchKey", err.(awserr.Error).Code())
+
+}
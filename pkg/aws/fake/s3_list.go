@@ -0,0 +1,155 @@
+package fake
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultMaxKeys is the maximum number of entries of a listing page when not set by the request
+const defaultMaxKeys = 1000
+
+// listBucketResult is the body of a ListObjectsV2 response
+type listBucketResult struct {
+	XMLName               xml.Name       `xml:"ListBucketResult"`
+	Name                  string         `xml:"Name"`
+	Prefix                string         `xml:"Prefix"`
+	Delimiter             string         `xml:"Delimiter,omitempty"`
+	StartAfter            string         `xml:"StartAfter,omitempty"`
+	MaxKeys               int            `xml:"MaxKeys"`
+	KeyCount              int            `xml:"KeyCount"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	ContinuationToken     string         `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+	Contents              []listedObject `xml:"Contents"`
+	CommonPrefixes        []listedPrefix `xml:"CommonPrefixes"`
+}
+
+// listedObject is an object listed in a ListObjectsV2 response
+type listedObject struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int    `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+// listedPrefix is a common prefix listed in a ListObjectsV2 response
+type listedPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+func (srv *Server) s3ListObjectsV2(w http.ResponseWriter, r *http.Request, bucketName string) {
+
+	query := r.URL.Query()
+
+	out := listBucketResult{
+		Name:              bucketName,
+		Prefix:            query.Get("prefix"),
+		Delimiter:         query.Get("delimiter"),
+		StartAfter:        query.Get("start-after"),
+		MaxKeys:           defaultMaxKeys,
+		ContinuationToken: query.Get("continuation-token"),
+	}
+
+	if v := query.Get("max-keys"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "Provided max-keys not an integer or within integer range")
+			return
+		}
+		if n < defaultMaxKeys {
+			out.MaxKeys = n
+		}
+	}
+
+	marker := ""
+	if out.ContinuationToken != "" {
+		b, err := base64.StdEncoding.DecodeString(out.ContinuationToken)
+		if err != nil {
+			writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "The continuation token provided is incorrect")
+			return
+		}
+		marker = string(b)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	keys := make([]string, 0, len(b.objects))
+	for key := range b.objects {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	last := ""
+
+	for _, key := range keys {
+
+		if !strings.HasPrefix(key, out.Prefix) || key <= out.StartAfter {
+			continue
+		}
+
+		name := key
+		isPrefix := false
+
+		if out.Delimiter != "" {
+			if i := strings.Index(key[len(out.Prefix):], out.Delimiter); i >= 0 {
+				name = key[:len(out.Prefix)+i+len(out.Delimiter)]
+				isPrefix = true
+			}
+		}
+
+		if name <= marker || name == last {
+			continue
+		}
+
+		if out.KeyCount == out.MaxKeys {
+			out.IsTruncated = true
+			out.NextContinuationToken = base64.StdEncoding.EncodeToString([]byte(last))
+			break
+		}
+
+		if isPrefix {
+			out.CommonPrefixes = append(out.CommonPrefixes, listedPrefix{Prefix: name})
+		} else {
+			o := b.objects[key]
+			out.Contents = append(out.Contents, listedObject{
+				Key:          key,
+				LastModified: o.lastModified.Format(time.RFC3339),
+				ETag:         o.etag,
+				Size:         len(o.body),
+				StorageClass: storageClass(o),
+			})
+		}
+
+		out.KeyCount++
+		last = name
+
+	}
+
+	writeS3Response(w, out)
+
+}
+
+// storageClass returns the storage class an object has been written with
+func storageClass(o *object) string {
+
+	if class := o.header.Get("X-Amz-Storage-Class"); class != "" {
+		return class
+	}
+
+	return "STANDARD"
+
+}
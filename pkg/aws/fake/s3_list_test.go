@@ -0,0 +1,67 @@
+package fake
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_S3ListObjectsV2(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+
+	svc := s3.New(srv.Session())
+
+	_, err := svc.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+
+	for _, key := range []string{"a/1", "a/2", "b", "c/1", "d"} {
+		_, err = svc.PutObject(&s3.PutObjectInput{
+			Bucket:       aws.String("some_bucket"),
+			Key:          aws.String(key),
+			Body:         bytes.NewReader([]byte(key)),
+			StorageClass: aws.String(s3.StorageClassStandardIa),
+		})
+		assert.NoError(t, err)
+	}
+
+	out, err := svc.ListObjectsV2(&s3.ListObjectsV2Input{
+		Bucket:    aws.String("some_bucket"),
+		Delimiter: aws.String("/"),
+		MaxKeys:   aws.Int64(2),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, *out.IsTruncated)
+	assert.Equal(t, "a/", *out.CommonPrefixes[0].Prefix)
+	assert.Equal(t, "b", *out.Contents[0].Key)
+	assert.Equal(t, int64(1), *out.Contents[0].Size)
+	assert.Equal(t, s3.StorageClassStandardIa, *out.Contents[0].StorageClass)
+
+	out, err = svc.ListObjectsV2(&s3.ListObjectsV2Input{
+		Bucket:            aws.String("some_bucket"),
+		Delimiter:         aws.String("/"),
+		ContinuationToken: out.NextContinuationToken,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, *out.IsTruncated)
+	assert.Equal(t, "c/", *out.CommonPrefixes[0].Prefix)
+	assert.Equal(t, "d", *out.Contents[0].Key)
+
+	out, err = svc.ListObjectsV2(&s3.ListObjectsV2Input{
+		Bucket:     aws.String("some_bucket"),
+		Prefix:     aws.String("a/"),
+		StartAfter: aws.String("a/1"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), *out.KeyCount)
+	assert.Equal(t, "a/2", *out.Contents[0].Key)
+
+}
@@ -0,0 +1,356 @@
+package fake
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// minPartSize is the minimum size of every part of a multipart upload but the last one
+const minPartSize = 5 * 1024 * 1024
+
+// upload is an in-progress S3 multipart upload
+type upload struct {
+	bucket string
+	key    string
+	header http.Header
+	tags   map[string]string
+	parts  map[int]*part
+}
+
+// part is a part of an S3 multipart upload
+type part struct {
+	body         []byte
+	sum          [md5.Size]byte
+	lastModified time.Time
+}
+
+// initiateMultipartUploadResult is the body of a CreateMultipartUpload response
+type initiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	UploadID string   `xml:"UploadId"`
+}
+
+// completeMultipartUpload is the body of a CompleteMultipartUpload request
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+// completedPart is a part listed in a CompleteMultipartUpload request
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// completeMultipartUploadResult is the body of a CompleteMultipartUpload response
+type completeMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"CompleteMultipartUploadResult"`
+	Location string   `xml:"Location"`
+	Bucket   string   `xml:"Bucket"`
+	Key      string   `xml:"Key"`
+	ETag     string   `xml:"ETag"`
+}
+
+// listPartsResult is the body of a ListParts response
+type listPartsResult struct {
+	XMLName              xml.Name     `xml:"ListPartsResult"`
+	Bucket               string       `xml:"Bucket"`
+	Key                  string       `xml:"Key"`
+	UploadID             string       `xml:"UploadId"`
+	PartNumberMarker     int          `xml:"PartNumberMarker"`
+	NextPartNumberMarker int          `xml:"NextPartNumberMarker"`
+	MaxParts             int          `xml:"MaxParts"`
+	IsTruncated          bool         `xml:"IsTruncated"`
+	Parts                []listedPart `xml:"Part"`
+}
+
+// listedPart is a part listed in a ListParts response
+type listedPart struct {
+	PartNumber   int    `xml:"PartNumber"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int    `xml:"Size"`
+}
+
+// Uploads returns the ids of the multipart uploads of bucketName neither completed nor aborted
+func (srv *Server) Uploads(bucketName string) []string {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	var out []string
+
+	for id, u := range srv.uploads {
+		if u.bucket == bucketName {
+			out = append(out, id)
+		}
+	}
+
+	sort.Strings(out)
+
+	return out
+
+}
+
+func (srv *Server) s3CreateMultipartUpload(w http.ResponseWriter, r *http.Request, bucketName, key string) {
+
+	tags, ok := parseTagging(r.Header.Get("X-Amz-Tagging"))
+	if !ok {
+		writeS3Error(w, http.StatusBadRequest, "InvalidTag", "The tag provided was not a valid tag.")
+		return
+	}
+
+	enc, sseErr := encryption(r)
+	if sseErr != nil {
+		sseErr.write(w)
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	if _, ok := srv.buckets[bucketName]; !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	id := newRequestID()
+
+	srv.uploads[id] = &upload{
+		bucket: bucketName,
+		key:    key,
+		header: withEncryption(objectHeader(r.Header), enc),
+		tags:   tags,
+		parts:  make(map[int]*part),
+	}
+
+	setEncryption(w, enc)
+
+	writeS3Response(w, initiateMultipartUploadResult{
+		Bucket:   bucketName,
+		Key:      key,
+		UploadID: id,
+	})
+
+}
+
+func (srv *Server) s3UploadPart(w http.ResponseWriter, r *http.Request, uploadID string) {
+
+	number, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil || number < 1 || number > 10000 {
+		writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "Part number must be an integer between 1 and 10000, inclusive.")
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeS3Error(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	u, ok := srv.uploads[uploadID]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchUpload", "The specified upload does not exist.")
+		return
+	}
+	if sseErr := checkCustomerKey(r, customerKeyPrefix, u.header); sseErr != nil {
+		sseErr.write(w)
+		return
+	}
+
+	p := &part{
+		body:         body,
+		sum:          md5.Sum(body),
+		lastModified: time.Now().UTC(),
+	}
+
+	u.parts[number] = p
+
+	w.Header().Set("ETag", strconv.Quote(hex.EncodeToString(p.sum[:])))
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(http.StatusOK)
+
+}
+
+func (srv *Server) s3CompleteMultipartUpload(w http.ResponseWriter, r *http.Request, bucketName, key, uploadID string) {
+
+	in := &completeMultipartUpload{}
+	if err := xml.NewDecoder(r.Body).Decode(in); err != nil {
+		writeS3Error(w, http.StatusBadRequest, "MalformedXML", err.Error())
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	u, ok := srv.uploads[uploadID]
+	if !ok || u.bucket != bucketName || u.key != key {
+		writeS3Error(w, http.StatusNotFound, "NoSuchUpload", "The specified upload does not exist.")
+		return
+	}
+
+	if len(in.Parts) == 0 {
+		writeS3Error(w, http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed.")
+		return
+	}
+
+	var (
+		body []byte
+		sums []byte
+	)
+
+	for i, cp := range in.Parts {
+
+		if i > 0 && cp.PartNumber <= in.Parts[i-1].PartNumber {
+			writeS3Error(w, http.StatusBadRequest, "InvalidPartOrder", "The list of parts was not in ascending order.")
+			return
+		}
+
+		p, ok := u.parts[cp.PartNumber]
+		if !ok || cp.ETag != strconv.Quote(hex.EncodeToString(p.sum[:])) {
+			writeS3Error(w, http.StatusBadRequest, "InvalidPart", "One or more of the specified parts could not be found.")
+			return
+		}
+
+		if i < len(in.Parts)-1 && len(p.body) < minPartSize {
+			writeS3Error(w, http.StatusBadRequest, "EntityTooSmall", "Your proposed upload is smaller than the minimum allowed object size.")
+			return
+		}
+
+		body = append(body, p.body...)
+		sums = append(sums, p.sum[:]...)
+
+	}
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	sum := md5.Sum(sums)
+
+	o := &object{
+		body:         body,
+		header:       u.header,
+		tags:         u.tags,
+		etag:         strconv.Quote(fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:]), len(in.Parts))),
+		lastModified: time.Now().UTC(),
+	}
+
+	b.put(key, o)
+	delete(srv.uploads, uploadID)
+
+	setVersionID(w, b, o)
+
+	writeS3Response(w, completeMultipartUploadResult{
+		Location: "/" + bucketName + "/" + key,
+		Bucket:   bucketName,
+		Key:      key,
+		ETag:     o.etag,
+	})
+
+}
+
+func (srv *Server) s3AbortMultipartUpload(w http.ResponseWriter, r *http.Request, uploadID string) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	if _, ok := srv.uploads[uploadID]; !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchUpload", "The specified upload does not exist.")
+		return
+	}
+
+	delete(srv.uploads, uploadID)
+
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(http.StatusNoContent)
+
+}
+
+func (srv *Server) s3ListParts(w http.ResponseWriter, r *http.Request, bucketName, key, uploadID string) {
+
+	query := r.URL.Query()
+
+	maxParts := 1000
+	if v := query.Get("max-parts"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n < maxParts {
+			maxParts = n
+		}
+	}
+
+	marker, _ := strconv.Atoi(query.Get("part-number-marker"))
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	u, ok := srv.uploads[uploadID]
+	if !ok || u.bucket != bucketName || u.key != key {
+		writeS3Error(w, http.StatusNotFound, "NoSuchUpload", "The specified upload does not exist.")
+		return
+	}
+
+	var numbers []int
+
+	for number := range u.parts {
+		if number > marker {
+			numbers = append(numbers, number)
+		}
+	}
+
+	sort.Ints(numbers)
+
+	out := listPartsResult{
+		Bucket:           bucketName,
+		Key:              key,
+		UploadID:         uploadID,
+		PartNumberMarker: marker,
+		MaxParts:         maxParts,
+	}
+
+	if len(numbers) > maxParts {
+		numbers = numbers[:maxParts]
+		out.IsTruncated = true
+	}
+
+	for _, number := range numbers {
+
+		p := u.parts[number]
+
+		out.Parts = append(out.Parts, listedPart{
+			PartNumber:   number,
+			LastModified: p.lastModified.Format(time.RFC3339),
+			ETag:         strconv.Quote(hex.EncodeToString(p.sum[:])),
+			Size:         len(p.body),
+		})
+		out.NextPartNumberMarker = number
+
+	}
+
+	writeS3Response(w, out)
+
+}
+
+// writeS3Response writes a successful S3 response with an xml body
+func writeS3Response(w http.ResponseWriter, body interface{}) {
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(http.StatusOK)
+
+	xml.NewEncoder(w).Encode(body)
+
+}
@@ -0,0 +1,141 @@
+package fake
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_S3Multipart(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+
+	svc := s3.New(srv.Session())
+
+	_, err := svc.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+
+	created, err := svc.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket:      aws.String("some_bucket"),
+		Key:         aws.String("some/key"),
+		ContentType: aws.String("text/plain"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{*created.UploadId}, srv.Uploads("some_bucket"))
+
+	first := bytes.Repeat([]byte("a"), minPartSize)
+	second := []byte("some_body")
+
+	var parts []*s3.CompletedPart
+
+	for i, body := range [][]byte{first, second} {
+
+		out, err := svc.UploadPart(&s3.UploadPartInput{
+			Bucket:     aws.String("some_bucket"),
+			Key:        aws.String("some/key"),
+			UploadId:   created.UploadId,
+			PartNumber: aws.Int64(int64(i + 1)),
+			Body:       bytes.NewReader(body),
+		})
+
+		assert.NoError(t, err)
+
+		parts = append(parts, &s3.CompletedPart{ETag: out.ETag, PartNumber: aws.Int64(int64(i + 1))})
+
+	}
+
+	completed, err := svc.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String("some_bucket"),
+		Key:             aws.String("some/key"),
+		UploadId:        created.UploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(*completed.ETag, `-2"`))
+	assert.Empty(t, srv.Uploads("some_bucket"))
+
+	stored, ok := srv.Object("some_bucket", "some/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, append(first, second...), stored)
+
+	created, err = svc.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket: aws.String("some_bucket"),
+		Key:    aws.String("some/other_key"),
+	})
+
+	assert.NoError(t, err)
+
+	for i := 1; i <= 2; i++ {
+		_, err = svc.UploadPart(&s3.UploadPartInput{
+			Bucket:     aws.String("some_bucket"),
+			Key:        aws.String("some/other_key"),
+			UploadId:   created.UploadId,
+			PartNumber: aws.Int64(int64(i)),
+			Body:       bytes.NewReader(second),
+		})
+
+		assert.NoError(t, err)
+	}
+
+	listed, err := svc.ListParts(&s3.ListPartsInput{
+		Bucket:   aws.String("some_bucket"),
+		Key:      aws.String("some/other_key"),
+		UploadId: created.UploadId,
+		MaxParts: aws.Int64(1),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, *listed.IsTruncated)
+	assert.Len(t, listed.Parts, 1)
+	assert.Equal(t, int64(len(second)), *listed.Parts[0].Size)
+
+	listed, err = svc.ListParts(&s3.ListPartsInput{
+		Bucket:           aws.String("some_bucket"),
+		Key:              aws.String("some/other_key"),
+		UploadId:         created.UploadId,
+		PartNumberMarker: listed.NextPartNumberMarker,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, *listed.IsTruncated)
+	assert.Equal(t, int64(2), *listed.Parts[0].PartNumber)
+
+	_, err = svc.CompleteMultipartUpload(&s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String("some_bucket"),
+		Key:             aws.String("some/other_key"),
+		UploadId:        created.UploadId,
+		MultipartUpload: &s3.CompletedMultipartUpload{Parts: parts},
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "InvalidPart", err.(awserr.Error).Code())
+
+	_, err = svc.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+		Bucket:   aws.String("some_bucket"),
+		Key:      aws.String("some/other_key"),
+		UploadId: created.UploadId,
+	})
+
+	assert.NoError(t, err)
+	assert.Empty(t, srv.Uploads("some_bucket"))
+
+	_, err = svc.AbortMultipartUpload(&s3.AbortMultipartUploadInput{
+		Bucket:   aws.String("some_bucket"),
+		Key:      aws.String("some/other_key"),
+		UploadId: created.UploadId,
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, s3.ErrCodeNoSuchUpload, err.(awserr.Error).Code())
+
+}
@@ -0,0 +1,199 @@
+package fake
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPostSize is the maximum size of the form of a POST upload kept in memory
+const maxPostSize = 32 * 1024 * 1024
+
+// postPolicy is the decoded policy of a POST upload
+type postPolicy struct {
+	Expiration string            `json:"expiration"`
+	Conditions []json.RawMessage `json:"conditions"`
+}
+
+// s3PostObject handles a browser form upload, checking the fields of the form against its policy.
+// The policy signature is not verified
+func (srv *Server) s3PostObject(w http.ResponseWriter, r *http.Request, bucketName string) {
+
+	if err := r.ParseMultipartForm(maxPostSize); err != nil {
+		writeS3Error(w, http.StatusBadRequest, "MalformedPOSTRequest", "The body of your POST request is not well-formed multipart/form-data.")
+		return
+	}
+
+	file, fileHeader, err := r.FormFile("file")
+	if err != nil {
+		writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "POST requires exactly one file upload per request.")
+		return
+	}
+	defer file.Close()
+
+	body, err := ioutil.ReadAll(file)
+	if err != nil {
+		writeS3Error(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+
+	fields := make(map[string]string, len(r.MultipartForm.Value))
+	for k, v := range r.MultipartForm.Value {
+		fields[strings.ToLower(k)] = v[0]
+	}
+
+	fields["bucket"] = bucketName
+	fields["key"] = strings.Replace(fields["key"], "${filename}", fileHeader.Filename, -1)
+
+	if fields["key"] == "" {
+		writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "Bucket POST must contain a field named 'key'.")
+		return
+	}
+
+	if code, message := checkPostPolicy(fields, len(body)); code != "" {
+		status := http.StatusForbidden
+		if code != "AccessDenied" {
+			status = http.StatusBadRequest
+		}
+		writeS3Error(w, status, code, message)
+		return
+	}
+
+	header := make(http.Header)
+	for k, v := range fields {
+		header.Set(k, v)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	sum := md5.Sum(body)
+
+	o := &object{
+		body:         body,
+		header:       objectHeader(header),
+		etag:         strconv.Quote(hex.EncodeToString(sum[:])),
+		lastModified: time.Now().UTC(),
+	}
+
+	b.put(fields["key"], o)
+
+	status := http.StatusNoContent
+	if s, err := strconv.Atoi(fields["success_action_status"]); err == nil && (s == http.StatusOK || s == http.StatusCreated) {
+		status = s
+	}
+
+	setVersionID(w, b, o)
+	w.Header().Set("ETag", o.etag)
+	w.Header().Set("Location", "/"+bucketName+"/"+fields["key"])
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(status)
+
+}
+
+// checkPostPolicy checks fields and the size of the uploaded file against the policy field,
+// returning the code and message of the error to respond with if any
+func checkPostPolicy(fields map[string]string, size int) (string, string) {
+
+	denied := func(reason string) (string, string) {
+		return "AccessDenied", "Invalid according to Policy: " + reason
+	}
+
+	if fields["policy"] == "" {
+		return "", ""
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(fields["policy"])
+	if err != nil {
+		return "InvalidPolicyDocument", "Invalid Policy: Invalid 'Base64' encoding."
+	}
+
+	policy := postPolicy{}
+	if err := json.Unmarshal(raw, &policy); err != nil {
+		return "InvalidPolicyDocument", "Invalid Policy: Invalid JSON."
+	}
+
+	expiration, err := time.Parse(time.RFC3339, policy.Expiration)
+	if err != nil {
+		return "InvalidPolicyDocument", "Invalid Policy: Invalid 'expiration' value."
+	}
+	if time.Now().After(expiration) {
+		return denied("Policy expired.")
+	}
+
+	// every field but the ones below has to be covered by a condition
+	covered := map[string]bool{"policy": true, "x-amz-signature": true, "file": true}
+
+	for _, c := range policy.Conditions {
+
+		exact := map[string]string{}
+		if json.Unmarshal(c, &exact) == nil {
+			for k, v := range exact {
+				k = strings.ToLower(k)
+				covered[k] = true
+				if fields[k] != v {
+					return denied(fmt.Sprintf("Policy Condition failed: [\"eq\", \"$%s\", \"%s\"]", k, v))
+				}
+			}
+			continue
+		}
+
+		var op []interface{}
+		if err := json.Unmarshal(c, &op); err != nil || len(op) != 3 {
+			return "InvalidPolicyDocument", "Invalid Policy: Invalid Condition."
+		}
+
+		if op[0] == "content-length-range" {
+			min, _ := op[1].(float64)
+			max, _ := op[2].(float64)
+			if size < int(min) {
+				return "EntityTooSmall", "Your proposed upload is smaller than the minimum allowed size"
+			}
+			if size > int(max) {
+				return "EntityTooLarge", "Your proposed upload exceeds the maximum allowed size"
+			}
+			continue
+		}
+
+		name, _ := op[1].(string)
+		value, _ := op[2].(string)
+		k := strings.ToLower(strings.TrimPrefix(name, "$"))
+		covered[k] = true
+
+		switch op[0] {
+		case "eq":
+			if fields[k] != value {
+				return denied(fmt.Sprintf("Policy Condition failed: [\"eq\", \"%s\", \"%s\"]", name, value))
+			}
+		case "starts-with":
+			if !strings.HasPrefix(fields[k], value) {
+				return denied(fmt.Sprintf("Policy Condition failed: [\"starts-with\", \"%s\", \"%s\"]", name, value))
+			}
+		default:
+			return "InvalidPolicyDocument", "Invalid Policy: Invalid Condition."
+		}
+
+	}
+
+	for k := range fields {
+		if !covered[k] && k != "bucket" && !strings.HasPrefix(k, "x-ignore-") {
+			return denied(fmt.Sprintf("Extra input fields: %s", k))
+		}
+	}
+
+	return "", ""
+
+}
@@ -0,0 +1,122 @@
+package fake
+
+import (
+	"bytes"
+	"encoding/base64"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_S3PostObject(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+
+	svc := s3.New(srv.Session())
+
+	_, err := svc.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+
+	policy := func(expiration time.Time) string {
+		return base64.StdEncoding.EncodeToString([]byte(`{"expiration": "` + expiration.UTC().Format(time.RFC3339) + `", "conditions": [` +
+			`{"bucket": "some_bucket"}, ["starts-with", "$key", "some/"], ["eq", "$Content-Type", "text/plain"], ` +
+			`["content-length-range", 1, 9], {"success_action_status": "201"}]}`))
+	}
+
+	fields := map[string]string{
+		"key":                   "some/${filename}",
+		"Content-Type":          "text/plain",
+		"success_action_status": "201",
+		"policy":                policy(time.Now().Add(time.Minute)),
+	}
+
+	resp := postObject(t, srv.URL+"/some_bucket", fields, "some_body")
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("ETag"))
+
+	body, ok := srv.Object("some_bucket", "some/some_file.txt")
+
+	assert.True(t, ok)
+	assert.Equal(t, "some_body", string(body))
+
+	req, _ := svc.GetObjectRequest(&s3.GetObjectInput{
+		Bucket:                     aws.String("some_bucket"),
+		Key:                        aws.String("some/some_file.txt"),
+		ResponseContentDisposition: aws.String("attachment"),
+	})
+
+	url, err := req.Presign(time.Minute)
+
+	assert.NoError(t, err)
+
+	get, err := http.Get(url)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain", get.Header.Get("Content-Type"))
+	assert.Equal(t, "attachment", get.Header.Get("Content-Disposition"))
+
+	get.Body.Close()
+
+	for status, override := range map[int]map[string]string{
+		http.StatusForbidden:  {"policy": policy(time.Now().Add(-time.Minute))},
+		http.StatusBadRequest: {"policy": "some_policy"},
+	} {
+
+		in := map[string]string{}
+		for k, v := range fields {
+			in[k] = v
+		}
+		for k, v := range override {
+			in[k] = v
+		}
+
+		assert.Equal(t, status, postObject(t, srv.URL+"/some_bucket", in, "some_body").StatusCode)
+
+	}
+
+	fields["x-amz-meta-some-meta"] = "some_value"
+
+	assert.Equal(t, http.StatusForbidden, postObject(t, srv.URL+"/some_bucket", fields, "some_body").StatusCode)
+
+	delete(fields, "x-amz-meta-some-meta")
+
+	assert.Equal(t, http.StatusBadRequest, postObject(t, srv.URL+"/some_bucket", fields, "some_too_long_body").StatusCode)
+	assert.Equal(t, http.StatusNotFound, postObject(t, srv.URL+"/some_missing_bucket", map[string]string{"key": "some_key"}, "some_body").StatusCode)
+
+}
+
+// postObject posts body in a form with fields, the way browsers upload to S3
+func postObject(t *testing.T, url string, fields map[string]string, body string) *http.Response {
+
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+
+	for k, v := range fields {
+		assert.NoError(t, w.WriteField(k, v))
+	}
+
+	file, err := w.CreateFormFile("file", "some_file.txt")
+
+	assert.NoError(t, err)
+
+	file.Write([]byte(body))
+
+	assert.NoError(t, w.Close())
+
+	resp, err := http.Post(url, w.FormDataContentType(), buf)
+
+	assert.NoError(t, err)
+
+	resp.Body.Close()
+
+	return resp
+
+}
@@ -0,0 +1,216 @@
+package fake
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+)
+
+const (
+
+	// defaultKMSKeyID is the key SSE-KMS uses when the request names none, the aws/s3 managed key
+	defaultKMSKeyID = "arn:aws:kms:" + Region + ":" + AccountID + ":alias/aws/s3"
+
+	// customerKeyPrefix and copySourceCustomerKeyPrefix start the headers carrying the SSE-C key
+	// of the object written or read, and of the source of a copy
+	customerKeyPrefix           = "X-Amz-Server-Side-Encryption-Customer-"
+	copySourceCustomerKeyPrefix = "X-Amz-Copy-Source-Server-Side-Encryption-Customer-"
+)
+
+// encryptionHeaders are the headers describing the server-side encryption of an object.
+// SSE-C objects only keep the MD5 of their key
+var encryptionHeaders = []string{
+	"X-Amz-Server-Side-Encryption",
+	"X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id",
+	"X-Amz-Server-Side-Encryption-Context",
+	"X-Amz-Server-Side-Encryption-Bucket-Key-Enabled",
+	customerKeyPrefix + "Algorithm",
+	customerKeyPrefix + "Key-Md5",
+}
+
+// sseError is the error response to a request with invalid server-side encryption parameters
+type sseError struct {
+	status  int
+	code    string
+	message string
+}
+
+// write writes e as an S3 error response
+func (e *sseError) write(w http.ResponseWriter) {
+	writeS3Error(w, e.status, e.code, e.message)
+}
+
+// Encryption returns the headers describing the server-side encryption of the object stored
+// under bucketName and key. It is empty when the object is not encrypted
+func (srv *Server) Encryption(bucketName, key string) (http.Header, bool) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		return nil, false
+	}
+
+	o, ok := b.objects[key]
+	if !ok {
+		return nil, false
+	}
+
+	out := make(http.Header)
+	for _, h := range encryptionHeaders {
+		if v := o.header.Get(h); v != "" {
+			out.Set(h, v)
+		}
+	}
+
+	return out, true
+
+}
+
+// encryption validates the server-side encryption parameters of the write request r
+// and returns the headers to keep with the written object
+func encryption(r *http.Request) (http.Header, *sseError) {
+
+	out := make(http.Header)
+
+	switch sse := r.Header.Get("X-Amz-Server-Side-Encryption"); sse {
+	case "":
+	case "AES256":
+		out.Set("X-Amz-Server-Side-Encryption", sse)
+	case "aws:kms":
+		out.Set("X-Amz-Server-Side-Encryption", sse)
+		out.Set("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id", defaultKMSKeyID)
+		for _, h := range encryptionHeaders[1:4] {
+			if v := r.Header.Get(h); v != "" {
+				out.Set(h, v)
+			}
+		}
+	default:
+		return nil, &sseError{http.StatusBadRequest, "InvalidArgument", "The encryption method specified is not supported"}
+	}
+
+	if out.Get("X-Amz-Server-Side-Encryption") != "aws:kms" {
+		for _, h := range encryptionHeaders[1:4] {
+			if r.Header.Get(h) != "" {
+				return nil, &sseError{http.StatusBadRequest, "InvalidArgument", "Server Side Encryption with AWS KMS managed key requires HTTP header x-amz-server-side-encryption : aws:kms"}
+			}
+		}
+	}
+
+	sum, err := customerKeyMD5(r.Header, customerKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if sum != "" {
+		if out.Get("X-Amz-Server-Side-Encryption") != "" {
+			return nil, &sseError{http.StatusBadRequest, "InvalidArgument", "Server Side Encryption with Customer provided key is incompatible with the encryption method specified"}
+		}
+		out.Set(customerKeyPrefix+"Algorithm", "AES256")
+		out.Set(customerKeyPrefix+"Key-Md5", sum)
+	}
+
+	return out, nil
+
+}
+
+// customerKeyMD5 returns the base64 encoded MD5 of the SSE-C key sent in the headers of h
+// starting with prefix, once checked against the MD5 sent along. It is empty when h has no key
+func customerKeyMD5(h http.Header, prefix string) (string, *sseError) {
+
+	algorithm := h.Get(prefix + "Algorithm")
+	encoded := h.Get(prefix + "Key")
+
+	if algorithm == "" && encoded == "" {
+		return "", nil
+	}
+	if algorithm != "AES256" {
+		return "", &sseError{http.StatusBadRequest, "InvalidEncryptionAlgorithmError", "The encryption request you specified is not valid. The valid value is AES256."}
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		return "", &sseError{http.StatusBadRequest, "InvalidArgument", "The secret key was invalid for the specified algorithm."}
+	}
+
+	sum := md5.Sum(key)
+	out := base64.StdEncoding.EncodeToString(sum[:])
+
+	if m := h.Get(prefix + "Key-Md5"); m != "" && m != out {
+		return "", &sseError{http.StatusBadRequest, "InvalidArgument", "The calculated MD5 hash of the key did not match the hash that was provided."}
+	}
+
+	return out, nil
+
+}
+
+// checkCustomerKey checks that the SSE-C key sent in the headers of r starting with prefix
+// matches the one stored in header, if any
+func checkCustomerKey(r *http.Request, prefix string, header http.Header) *sseError {
+
+	sum, err := customerKeyMD5(r.Header, prefix)
+	if err != nil {
+		return err
+	}
+
+	stored := header.Get(customerKeyPrefix + "Key-Md5")
+
+	switch {
+	case stored == "" && sum != "":
+		return &sseError{http.StatusBadRequest, "InvalidRequest", "The encryption parameters are not applicable to this object."}
+	case stored != "" && sum == "":
+		return &sseError{http.StatusBadRequest, "InvalidRequest", "The object was stored using a form of Server Side Encryption. The correct parameters must be provided to retrieve the object."}
+	case stored != sum:
+		return &sseError{http.StatusForbidden, "AccessDenied", "Access Denied"}
+	}
+
+	return nil
+
+}
+
+// withEncryption returns a copy of header with its encryption headers replaced by enc
+func withEncryption(header, enc http.Header) http.Header {
+
+	out := make(http.Header, len(header)+len(enc))
+	for k, v := range header {
+		out[k] = v
+	}
+
+	for _, h := range encryptionHeaders {
+		out.Del(h)
+	}
+	for k, v := range enc {
+		out[k] = v
+	}
+
+	return out
+
+}
+
+// setEncryption sets the encryption headers of header on the response w
+func setEncryption(w http.ResponseWriter, header http.Header) {
+
+	for _, h := range encryptionHeaders {
+		if v := header.Get(h); v != "" {
+			w.Header().Set(h, v)
+		}
+	}
+
+}
+
+// objectETag returns the ETag of an object put in a single request with the encryption headers enc:
+// the MD5 of body, unless it is encrypted with SSE-KMS or SSE-C, whose ETags are not an MD5 on S3 either
+func objectETag(body []byte, enc http.Header) string {
+
+	sum := md5.Sum(body)
+
+	if enc.Get("X-Amz-Server-Side-Encryption") == "aws:kms" || enc.Get(customerKeyPrefix+"Algorithm") != "" {
+		sum = md5.Sum(append([]byte("encrypted:"), sum[:]...))
+	}
+
+	return strconv.Quote(hex.EncodeToString(sum[:]))
+
+}
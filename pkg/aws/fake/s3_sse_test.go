@@ -0,0 +1,138 @@
+package fake
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_S3Encryption(t *testing.T) {
+
+	srv := NewTLS()
+	defer srv.Close()
+
+	assert.True(t, strings.HasPrefix(srv.URL, "https://"))
+
+	svc := s3.New(srv.Session())
+
+	_, err := svc.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+
+	put, err := svc.PutObject(&s3.PutObjectInput{
+		Bucket:               aws.String("some_bucket"),
+		Key:                  aws.String("some/kms"),
+		Body:                 strings.NewReader("some_body"),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAwsKms),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, s3.ServerSideEncryptionAwsKms, *put.ServerSideEncryption)
+	assert.Equal(t, defaultKMSKeyID, *put.SSEKMSKeyId)
+	assert.NotEqual(t, `"29306d08936b46edebd9b8d94610651b"`, *put.ETag, "SSE-KMS ETags are not the MD5 of the body")
+
+	_, err = svc.PutObject(&s3.PutObjectInput{
+		Bucket:               aws.String("some_bucket"),
+		Key:                  aws.String("some/kms"),
+		Body:                 strings.NewReader("some_body"),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAes256),
+		SSEKMSKeyId:          aws.String("some_key"),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "InvalidArgument", err.(awserr.Error).Code())
+
+	key := string(bytes.Repeat([]byte("k"), 32))
+
+	_, err = svc.PutObject(&s3.PutObjectInput{
+		Bucket:               aws.String("some_bucket"),
+		Key:                  aws.String("some/sse-c"),
+		Body:                 strings.NewReader("some_body"),
+		SSECustomerAlgorithm: aws.String("AES256"),
+		SSECustomerKey:       aws.String(key),
+	})
+
+	assert.NoError(t, err)
+
+	enc, _ := srv.Encryption("some_bucket", "some/sse-c")
+
+	assert.Equal(t, "AES256", enc.Get(customerKeyPrefix+"Algorithm"))
+	assert.NotEmpty(t, enc.Get(customerKeyPrefix+"Key-Md5"))
+
+	for code, in := range map[string]*s3.HeadObjectInput{
+		"BadRequest": {},
+		"Forbidden":  {SSECustomerAlgorithm: aws.String("AES256"), SSECustomerKey: aws.String(strings.Repeat("o", 32))},
+	} {
+
+		in = in.SetBucket("some_bucket").SetKey("some/sse-c")
+
+		_, err = svc.HeadObject(in)
+
+		assert.Error(t, err)
+		assert.Equal(t, code, err.(awserr.Error).Code())
+
+	}
+
+	out, err := svc.GetObject(&s3.GetObjectInput{
+		Bucket:               aws.String("some_bucket"),
+		Key:                  aws.String("some/sse-c"),
+		SSECustomerAlgorithm: aws.String("AES256"),
+		SSECustomerKey:       aws.String(key),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "AES256", *out.SSECustomerAlgorithm)
+
+	out.Body.Close()
+
+	_, err = svc.GetObject(&s3.GetObjectInput{
+		Bucket:               aws.String("some_bucket"),
+		Key:                  aws.String("some/kms"),
+		SSECustomerAlgorithm: aws.String("AES256"),
+		SSECustomerKey:       aws.String(key),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "InvalidRequest", err.(awserr.Error).Code())
+
+	// copies never keep the encryption of their source
+	_, err = svc.CopyObject(&s3.CopyObjectInput{
+		Bucket:                         aws.String("some_bucket"),
+		Key:                            aws.String("other/key"),
+		CopySource:                     aws.String("some_bucket/some/sse-c"),
+		CopySourceSSECustomerAlgorithm: aws.String("AES256"),
+		CopySourceSSECustomerKey:       aws.String(key),
+	})
+
+	assert.NoError(t, err)
+
+	enc, _ = srv.Encryption("some_bucket", "other/key")
+
+	assert.Empty(t, enc)
+
+	upload, err := svc.CreateMultipartUpload(&s3.CreateMultipartUploadInput{
+		Bucket:               aws.String("some_bucket"),
+		Key:                  aws.String("other/sse-c"),
+		SSECustomerAlgorithm: aws.String("AES256"),
+		SSECustomerKey:       aws.String(key),
+	})
+
+	assert.NoError(t, err)
+
+	_, err = svc.UploadPart(&s3.UploadPartInput{
+		Bucket:     aws.String("some_bucket"),
+		Key:        aws.String("other/sse-c"),
+		UploadId:   upload.UploadId,
+		PartNumber: aws.Int64(1),
+		Body:       strings.NewReader("some_body"),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "InvalidRequest", err.(awserr.Error).Code())
+
+}
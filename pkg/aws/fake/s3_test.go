@@ -0,0 +1,127 @@
+package fake
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_S3(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+
+	svc := s3.New(srv.Session())
+
+	_, err := svc.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String("some_bucket"),
+		Key:    aws.String("some/key"),
+		Body:   bytes.NewReader([]byte("some_body")),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "NoSuchBucket", err.(awserr.Error).Code())
+
+	_, err = svc.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+
+	_, err = svc.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String("some_bucket")})
+
+	assert.Error(t, err)
+	assert.Equal(t, s3.ErrCodeBucketAlreadyOwnedByYou, err.(awserr.Error).Code())
+
+	_, err = svc.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String("some_bucket"),
+		Key:         aws.String("some/key"),
+		Body:        bytes.NewReader([]byte("some_body")),
+		ContentType: aws.String("text/plain"),
+		Metadata:    map[string]*string{"Some-Meta": aws.String("some_value")},
+	})
+
+	assert.NoError(t, err)
+
+	out, err := svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String("some_bucket"),
+		Key:    aws.String("some/key"),
+	})
+
+	assert.NoError(t, err)
+
+	body, err := ioutil.ReadAll(out.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_body", string(body))
+	assert.Equal(t, "text/plain", *out.ContentType)
+	assert.Equal(t, "some_value", *out.Metadata["Some-Meta"])
+	assert.Equal(t, int64(len(body)), *out.ContentLength)
+
+	stored, ok := srv.Object("some_bucket", "some/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, body, stored)
+
+	ranged, err := svc.GetObject(&s3.GetObjectInput{
+		Bucket:  aws.String("some_bucket"),
+		Key:     aws.String("some/key"),
+		Range:   aws.String("bytes=5-"),
+		IfMatch: out.ETag,
+	})
+
+	assert.NoError(t, err)
+
+	body, err = ioutil.ReadAll(ranged.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "body", string(body))
+	assert.Equal(t, "bytes 5-8/9", *ranged.ContentRange)
+
+	for rng, want := range map[string]string{"bytes=0-3": "some", "bytes=-4": "body", "bytes=5-100": "body"} {
+
+		ranged, err = svc.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String("some_bucket"),
+			Key:    aws.String("some/key"),
+			Range:  aws.String(rng),
+		})
+
+		assert.NoError(t, err)
+
+		body, err = ioutil.ReadAll(ranged.Body)
+
+		assert.NoError(t, err)
+		assert.Equal(t, want, string(body))
+
+	}
+
+	_, err = svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String("some_bucket"),
+		Key:    aws.String("some/key"),
+		Range:  aws.String("bytes=9-"),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "InvalidRange", err.(awserr.Error).Code())
+
+	_, err = svc.GetObject(&s3.GetObjectInput{
+		Bucket:  aws.String("some_bucket"),
+		Key:     aws.String("some/key"),
+		IfMatch: aws.String(`"some_etag"`),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "PreconditionFailed", err.(awserr.Error).Code())
+
+	_, err = svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String("some_bucket"),
+		Key:    aws.String("some_missing_key"),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, s3.ErrCodeNoSuchKey, err.(awserr.Error).Code())
+
+}
@@ -0,0 +1,454 @@
+package fake
+
+import (
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+
+	// nullVersionID is the version ID of the objects written while versioning is not enabled
+	nullVersionID = "null"
+
+	// versioningEnabled is the status of a bucket keeping every version of its objects
+	versioningEnabled = "Enabled"
+)
+
+// versioningConfiguration is the body of PutBucketVersioning requests and GetBucketVersioning responses
+type versioningConfiguration struct {
+	XMLName xml.Name `xml:"VersioningConfiguration"`
+	Status  string   `xml:"Status,omitempty"`
+}
+
+// listVersionsResult is the body of a ListObjectVersions response
+type listVersionsResult struct {
+	XMLName             xml.Name        `xml:"ListVersionsResult"`
+	Name                string          `xml:"Name"`
+	Prefix              string          `xml:"Prefix"`
+	KeyMarker           string          `xml:"KeyMarker"`
+	VersionIDMarker     string          `xml:"VersionIdMarker"`
+	MaxKeys             int             `xml:"MaxKeys"`
+	IsTruncated         bool            `xml:"IsTruncated"`
+	NextKeyMarker       string          `xml:"NextKeyMarker,omitempty"`
+	NextVersionIDMarker string          `xml:"NextVersionIdMarker,omitempty"`
+	Versions            []listedVersion `xml:"Version"`
+	DeleteMarkers       []listedMarker  `xml:"DeleteMarker"`
+}
+
+// listedVersion is an object version listed in a ListObjectVersions response
+type listedVersion struct {
+	Key          string `xml:"Key"`
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool   `xml:"IsLatest"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int    `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+// listedMarker is a delete marker listed in a ListObjectVersions response
+type listedMarker struct {
+	Key          string `xml:"Key"`
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool   `xml:"IsLatest"`
+	LastModified string `xml:"LastModified"`
+}
+
+// deleteRequest is the body of a DeleteObjects request
+type deleteRequest struct {
+	Quiet   bool `xml:"Quiet"`
+	Objects []struct {
+		Key       string `xml:"Key"`
+		VersionID string `xml:"VersionId"`
+	} `xml:"Object"`
+}
+
+// deleteResult is the body of a DeleteObjects response
+type deleteResult struct {
+	XMLName xml.Name        `xml:"DeleteResult"`
+	Deleted []deletedObject `xml:"Deleted"`
+	Errors  []deleteError   `xml:"Error"`
+}
+
+// deletedObject is a successfully deleted entry of a DeleteObjects response
+type deletedObject struct {
+	Key                   string `xml:"Key"`
+	VersionID             string `xml:"VersionId,omitempty"`
+	DeleteMarker          bool   `xml:"DeleteMarker,omitempty"`
+	DeleteMarkerVersionID string `xml:"DeleteMarkerVersionId,omitempty"`
+}
+
+// deleteError is a failed entry of a DeleteObjects response
+type deleteError struct {
+	Key       string `xml:"Key"`
+	VersionID string `xml:"VersionId,omitempty"`
+	Code      string `xml:"Code"`
+	Message   string `xml:"Message"`
+}
+
+// put stores o as the current version of key
+func (b *bucket) put(key string, o *object) {
+
+	b.seq++
+	o.seq = b.seq
+
+	o.versionID = nullVersionID
+	if b.versioning == versioningEnabled {
+		o.versionID = newVersionID(o.seq)
+	} else {
+		b.remove(key, nullVersionID)
+	}
+
+	b.versions[key] = append(b.versions[key], o)
+
+	if o.deleteMarker {
+		delete(b.objects, key)
+	} else {
+		b.objects[key] = o
+	}
+
+}
+
+// version returns the version of key identified by versionID
+func (b *bucket) version(key, versionID string) (*object, bool) {
+
+	for _, o := range b.versions[key] {
+		if o.versionID == versionID {
+			return o, true
+		}
+	}
+
+	return nil, false
+
+}
+
+// remove permanently deletes the version of key identified by versionID, if any,
+// and makes the previous version the current one
+func (b *bucket) remove(key, versionID string) (*object, bool) {
+
+	versions := b.versions[key]
+
+	for i, o := range versions {
+
+		if o.versionID != versionID {
+			continue
+		}
+
+		versions = append(versions[:i:i], versions[i+1:]...)
+
+		delete(b.objects, key)
+		if n := len(versions); n > 0 && !versions[n-1].deleteMarker {
+			b.objects[key] = versions[n-1]
+		}
+
+		if len(versions) == 0 {
+			delete(b.versions, key)
+		} else {
+			b.versions[key] = versions
+		}
+
+		return o, true
+
+	}
+
+	return nil, false
+
+}
+
+// deleteObject deletes versionID of key, or the current version of key when versionID is empty.
+// The current version is replaced by a delete marker once versioning has been enabled
+func (b *bucket) deleteObject(key, versionID string) (deletedObject, *deleteError) {
+
+	out := deletedObject{Key: key, VersionID: versionID}
+
+	if versionID != "" {
+
+		if !validVersionID(versionID) {
+			return out, &deleteError{Key: key, VersionID: versionID, Code: "InvalidArgument", Message: "Invalid version id specified"}
+		}
+
+		if o, ok := b.remove(key, versionID); ok && o.deleteMarker {
+			out.DeleteMarker = true
+			out.DeleteMarkerVersionID = versionID
+		}
+
+		return out, nil
+
+	}
+
+	if b.versioning == "" {
+		b.remove(key, nullVersionID)
+		return out, nil
+	}
+
+	marker := &object{
+		header:       make(http.Header),
+		lastModified: time.Now().UTC(),
+		deleteMarker: true,
+	}
+
+	b.put(key, marker)
+
+	out.DeleteMarker = true
+	out.DeleteMarkerVersionID = marker.versionID
+
+	return out, nil
+
+}
+
+func (srv *Server) s3GetBucketVersioning(w http.ResponseWriter, r *http.Request, bucketName string) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	writeS3Response(w, versioningConfiguration{Status: b.versioning})
+
+}
+
+func (srv *Server) s3PutBucketVersioning(w http.ResponseWriter, r *http.Request, bucketName string) {
+
+	in := versioningConfiguration{}
+	if err := xml.NewDecoder(r.Body).Decode(&in); err != nil || (in.Status != versioningEnabled && in.Status != "Suspended") {
+		writeS3Error(w, http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema")
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	b.versioning = in.Status
+
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(http.StatusOK)
+
+}
+
+func (srv *Server) s3ListObjectVersions(w http.ResponseWriter, r *http.Request, bucketName string) {
+
+	query := r.URL.Query()
+
+	out := listVersionsResult{
+		Name:            bucketName,
+		Prefix:          query.Get("prefix"),
+		KeyMarker:       query.Get("key-marker"),
+		VersionIDMarker: query.Get("version-id-marker"),
+		MaxKeys:         defaultMaxKeys,
+	}
+
+	if v := query.Get("max-keys"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeS3Error(w, http.StatusBadRequest, "InvalidArgument", "Provided max-keys not an integer or within integer range")
+			return
+		}
+		if n < defaultMaxKeys {
+			out.MaxKeys = n
+		}
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	keys := make([]string, 0, len(b.versions))
+	for key := range b.versions {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	count := 0
+	lastKey, lastVersionID := "", ""
+
+	for _, key := range keys {
+
+		if !strings.HasPrefix(key, out.Prefix) || key < out.KeyMarker {
+			continue
+		}
+
+		versions := b.versions[key]
+
+		// versions are listed from the newest one, after the version-id-marker of the key-marker
+		marker := uint64(0)
+		if key == out.KeyMarker {
+			marker = b.versionSeq(key, out.VersionIDMarker)
+		}
+
+		for i := len(versions) - 1; i >= 0; i-- {
+
+			o := versions[i]
+
+			if key == out.KeyMarker && o.seq >= marker {
+				continue
+			}
+
+			if count == out.MaxKeys {
+				out.IsTruncated = true
+				out.NextKeyMarker = lastKey
+				out.NextVersionIDMarker = lastVersionID
+				writeS3Response(w, out)
+				return
+			}
+
+			if o.deleteMarker {
+				out.DeleteMarkers = append(out.DeleteMarkers, listedMarker{
+					Key:          key,
+					VersionID:    o.versionID,
+					IsLatest:     i == len(versions)-1,
+					LastModified: o.lastModified.Format(time.RFC3339),
+				})
+			} else {
+				out.Versions = append(out.Versions, listedVersion{
+					Key:          key,
+					VersionID:    o.versionID,
+					IsLatest:     i == len(versions)-1,
+					LastModified: o.lastModified.Format(time.RFC3339),
+					ETag:         o.etag,
+					Size:         len(o.body),
+					StorageClass: storageClass(o),
+				})
+			}
+
+			count++
+			lastKey, lastVersionID = key, o.versionID
+
+		}
+
+	}
+
+	writeS3Response(w, out)
+
+}
+
+func (srv *Server) s3DeleteObject(w http.ResponseWriter, r *http.Request, bucketName, key string) {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	out, derr := b.deleteObject(key, r.URL.Query().Get("versionId"))
+	if derr != nil {
+		writeS3Error(w, http.StatusBadRequest, derr.Code, derr.Message)
+		return
+	}
+
+	if out.DeleteMarker {
+		w.Header().Set("X-Amz-Delete-Marker", "true")
+		w.Header().Set("X-Amz-Version-Id", out.DeleteMarkerVersionID)
+	} else if out.VersionID != "" {
+		w.Header().Set("X-Amz-Version-Id", out.VersionID)
+	}
+
+	w.Header().Set("X-Amz-Request-Id", newRequestID())
+	w.WriteHeader(http.StatusNoContent)
+
+}
+
+func (srv *Server) s3DeleteObjects(w http.ResponseWriter, r *http.Request, bucketName string) {
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeS3Error(w, http.StatusBadRequest, "IncompleteBody", err.Error())
+		return
+	}
+
+	in := deleteRequest{}
+	if err := xml.Unmarshal(body, &in); err != nil || len(in.Objects) == 0 || len(in.Objects) > defaultMaxKeys {
+		writeS3Error(w, http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema")
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	b, ok := srv.buckets[bucketName]
+	if !ok {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist")
+		return
+	}
+
+	out := deleteResult{}
+
+	for _, o := range in.Objects {
+
+		deleted, derr := b.deleteObject(o.Key, o.VersionID)
+		if derr != nil {
+			out.Errors = append(out.Errors, *derr)
+			continue
+		}
+
+		if !in.Quiet {
+			out.Deleted = append(out.Deleted, deleted)
+		}
+
+	}
+
+	writeS3Response(w, out)
+
+}
+
+// versionSeq returns the sequence number of the version of key identified by versionID.
+// It is also found for the versions that have been deleted since versionID was returned,
+// as long as versioning was enabled when they were written
+func (b *bucket) versionSeq(key, versionID string) uint64 {
+
+	if o, ok := b.version(key, versionID); ok {
+		return o.seq
+	}
+
+	if versionID != nullVersionID && validVersionID(versionID) {
+		seq, _ := strconv.ParseUint(versionID[:16], 16, 64)
+		return seq
+	}
+
+	return 0
+
+}
+
+// newVersionID returns a new version ID, made of the sequence number of the version
+// followed by random bytes, so that listings can resume after deleted versions
+func newVersionID(seq uint64) string {
+	return fmt.Sprintf("%016x", seq) + newRequestID()[:16]
+}
+
+// validVersionID reports whether versionID could have been returned by newVersionID
+func validVersionID(versionID string) bool {
+
+	if versionID == nullVersionID {
+		return true
+	}
+
+	_, err := hex.DecodeString(versionID)
+
+	return err == nil && len(versionID) == 32
+
+}
@@ -0,0 +1,143 @@
+package fake
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_S3Versions(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+
+	svc := s3.New(srv.Session())
+
+	_, err := svc.CreateBucket(&s3.CreateBucketInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+
+	put := func(key, body string) *s3.PutObjectOutput {
+		out, err := svc.PutObject(&s3.PutObjectInput{
+			Bucket: aws.String("some_bucket"),
+			Key:    aws.String(key),
+			Body:   bytes.NewReader([]byte(body)),
+		})
+		assert.NoError(t, err)
+		return out
+	}
+
+	assert.Nil(t, put("some/key", "some_body").VersionId)
+
+	_, err = svc.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket:                  aws.String("some_bucket"),
+		VersioningConfiguration: &s3.VersioningConfiguration{Status: aws.String(s3.BucketVersioningStatusEnabled)},
+	})
+
+	assert.NoError(t, err)
+
+	versioning, err := svc.GetBucketVersioning(&s3.GetBucketVersioningInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, s3.BucketVersioningStatusEnabled, *versioning.Status)
+
+	second := put("some/key", "some_other_body")
+
+	assert.NotEqual(t, "null", *second.VersionId)
+
+	deleted, err := svc.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String("some_bucket"),
+		Key:    aws.String("some/key"),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, *deleted.DeleteMarker)
+
+	_, ok := srv.Object("some_bucket", "some/key")
+
+	assert.False(t, ok)
+
+	out, err := svc.GetObject(&s3.GetObjectInput{
+		Bucket:    aws.String("some_bucket"),
+		Key:       aws.String("some/key"),
+		VersionId: aws.String("null"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "null", *out.VersionId)
+
+	versions, err := svc.ListObjectVersions(&s3.ListObjectVersionsInput{
+		Bucket:  aws.String("some_bucket"),
+		MaxKeys: aws.Int64(2),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, *versions.IsTruncated)
+	assert.Len(t, versions.DeleteMarkers, 1)
+	assert.True(t, *versions.DeleteMarkers[0].IsLatest)
+	assert.Len(t, versions.Versions, 1)
+	assert.Equal(t, *second.VersionId, *versions.Versions[0].VersionId)
+
+	versions, err = svc.ListObjectVersions(&s3.ListObjectVersionsInput{
+		Bucket:          aws.String("some_bucket"),
+		KeyMarker:       versions.NextKeyMarker,
+		VersionIdMarker: versions.NextVersionIdMarker,
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, *versions.IsTruncated)
+	assert.Len(t, versions.Versions, 1)
+	assert.Equal(t, "null", *versions.Versions[0].VersionId)
+
+	// removing the delete marker makes the previous version current again
+	_, err = svc.DeleteObject(&s3.DeleteObjectInput{
+		Bucket:    aws.String("some_bucket"),
+		Key:       aws.String("some/key"),
+		VersionId: deleted.VersionId,
+	})
+
+	assert.NoError(t, err)
+
+	body, ok := srv.Object("some_bucket", "some/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, "some_other_body", string(body))
+
+	put("other/key", "some_body")
+
+	batch, err := svc.DeleteObjects(&s3.DeleteObjectsInput{
+		Bucket: aws.String("some_bucket"),
+		Delete: &s3.Delete{Objects: []*s3.ObjectIdentifier{
+			{Key: aws.String("some/key"), VersionId: second.VersionId},
+			{Key: aws.String("some/key"), VersionId: aws.String("null")},
+			{Key: aws.String("other/key"), VersionId: aws.String("some_version")},
+			{Key: aws.String("other/key")},
+		}},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, batch.Deleted, 3)
+	assert.Len(t, batch.Errors, 1)
+	assert.Equal(t, "InvalidArgument", *batch.Errors[0].Code)
+
+	versions, err = svc.ListObjectVersions(&s3.ListObjectVersionsInput{Bucket: aws.String("some_bucket")})
+
+	assert.NoError(t, err)
+	assert.Empty(t, versions.Versions[1:])
+	assert.Equal(t, "other/key", *versions.Versions[0].Key)
+	assert.Len(t, versions.DeleteMarkers, 1)
+
+	_, err = svc.GetObject(&s3.GetObjectInput{
+		Bucket:    aws.String("some_bucket"),
+		Key:       aws.String("other/key"),
+		VersionId: versions.DeleteMarkers[0].VersionId,
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, "MethodNotAllowed", err.(awserr.Error).Code())
+
+}
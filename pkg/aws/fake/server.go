@@ -0,0 +1,155 @@
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+)
+
+const (
+
+	// Region is the region used by Server.Session
+	Region = "eu-west-1"
+
+	// AccessKeyID is the access key used by Server.Session
+	AccessKeyID = "fake"
+
+	// SecretAccessKey is the secret key used by Server.Session
+	SecretAccessKey = "fake"
+
+	// AccountID is the account id used to build queue urls and arns
+	AccountID = "000000000000"
+
+	serviceS3       = "s3"
+	serviceDynamoDB = "dynamodb"
+	serviceSQS      = "sqs"
+	serviceSNS      = "sns"
+)
+
+// credentialScope extracts the signing service from an Authorization header
+var credentialScope = regexp.MustCompile(`Credential=[^/]+/[^/]+/[^/]+/([^/]+)/aws4_request`)
+
+// Server is an in-memory aws backend listening on a local address
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	buckets  map[string]*bucket
+	tables   map[string]*table
+	queues   map[string]*queue
+	uploads  map[string]*upload
+	messages []Publication
+}
+
+// New starts and returns a new *Server. Call Close when done
+func New() *Server {
+
+	srv := newServer()
+	srv.Server = httptest.NewServer(http.HandlerFunc(srv.serveHTTP))
+
+	return srv
+
+}
+
+// NewTLS is the same as New, serving HTTPS with a self-signed certificate trusted by the
+// *http.Client returned by Client. The SDK refuses to send SSE-C keys over plain HTTP
+func NewTLS() *Server {
+
+	srv := newServer()
+	srv.Server = httptest.NewTLSServer(http.HandlerFunc(srv.serveHTTP))
+
+	return srv
+
+}
+
+// newServer returns a new *Server with empty stores, not listening yet
+func newServer() *Server {
+
+	return &Server{
+		buckets: make(map[string]*bucket),
+		tables:  make(map[string]*table),
+		queues:  make(map[string]*queue),
+		uploads: make(map[string]*upload),
+	}
+
+}
+
+// Session returns a new *pkgAws.Session pointing every client to srv,
+// with static credentials and path style addressing for S3
+func (srv *Server) Session() *pkgAws.Session {
+
+	cfg := &aws.Config{
+		Region:           aws.String(Region),
+		Endpoint:         aws.String(srv.URL),
+		Credentials:      credentials.NewStaticCredentials(AccessKeyID, SecretAccessKey, ""),
+		S3ForcePathStyle: aws.Bool(true),
+	}
+
+	sess := session.Must(session.NewSession(cfg))
+
+	// set once the session is created, so that AWS_CA_BUNDLE does not replace the roots trusting NewTLS
+	sess.Config.HTTPClient = srv.Client()
+
+	return &pkgAws.Session{
+		Session: sess,
+	}
+
+}
+
+// serveHTTP routes a request to the handler of the service it was signed for
+func (srv *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+
+	switch serviceName(r) {
+	case serviceDynamoDB:
+		srv.serveDynamoDB(w, r)
+	case serviceSQS:
+		srv.serveSQS(w, r)
+	case serviceSNS:
+		srv.serveSNS(w, r)
+	default:
+		srv.serveS3(w, r)
+	}
+
+}
+
+// serviceName returns the service a request is meant for, looking at its signature
+// first and at its protocol when the request is not signed
+func serviceName(r *http.Request) string {
+
+	if m := credentialScope.FindStringSubmatch(r.Header.Get("Authorization")); m != nil {
+		return m[1]
+	}
+
+	if r.Header.Get("X-Amz-Target") != "" {
+		return serviceDynamoDB
+	}
+
+	if r.Method == http.MethodPost && r.FormValue("Action") != "" {
+		if r.FormValue("Action") == "Publish" {
+			return serviceSNS
+		}
+		return serviceSQS
+	}
+
+	return serviceS3
+
+}
+
+// newRequestID returns a random request id
+func newRequestID() string {
+
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	return hex.EncodeToString(b)
+
+}
@@ -0,0 +1,70 @@
+package fake
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+
+	assert.NotEmpty(t, srv.URL)
+
+	awsSvc := srv.Session()
+
+	assert.Equal(t, srv.URL, *awsSvc.Config.Endpoint)
+	assert.Equal(t, Region, *awsSvc.Config.Region)
+	assert.True(t, *awsSvc.Config.S3ForcePathStyle)
+
+}
+
+func TestServiceName(t *testing.T) {
+
+	cases := []struct {
+		header map[string]string
+		body   string
+		want   string
+	}{
+		{
+			header: map[string]string{"Authorization": "AWS4-HMAC-SHA256 Credential=fake/20190101/eu-west-1/sqs/aws4_request, SignedHeaders=host, Signature=0"},
+			want:   serviceSQS,
+		},
+		{
+			header: map[string]string{"X-Amz-Target": "DynamoDB_20120810.Scan"},
+			want:   serviceDynamoDB,
+		},
+		{
+			header: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+			body:   "Action=Publish",
+			want:   serviceSNS,
+		},
+		{
+			header: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+			body:   "Action=CreateQueue",
+			want:   serviceSQS,
+		},
+		{
+			want: serviceS3,
+		},
+	}
+
+	for _, c := range cases {
+
+		r, err := http.NewRequest(http.MethodPost, "http://localhost/", strings.NewReader(c.body))
+
+		assert.NoError(t, err)
+
+		for k, v := range c.header {
+			r.Header.Set(k, v)
+		}
+
+		assert.Equal(t, c.want, serviceName(r))
+
+	}
+
+}
@@ -0,0 +1,85 @@
+package fake
+
+import (
+	"net/http"
+)
+
+// Publication is a message published on a fake SNS target
+type Publication struct {
+	MessageID         string
+	TargetArn         string
+	Message           string
+	MessageStructure  string
+	MessageAttributes map[string]MessageAttribute
+}
+
+// Publications returns the messages published so far
+func (srv *Server) Publications() []Publication {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	out := make([]Publication, len(srv.messages))
+	copy(out, srv.messages)
+
+	return out
+
+}
+
+// serveSNS handles an SNS query request
+func (srv *Server) serveSNS(w http.ResponseWriter, r *http.Request) {
+
+	if err := r.ParseForm(); err != nil {
+		writeQueryError(w, http.StatusBadRequest, "MalformedQueryString", err.Error())
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	switch action := r.Form.Get("Action"); action {
+	case "Publish":
+		srv.snsPublish(w, r)
+	default:
+		writeQueryError(w, http.StatusBadRequest, "InvalidAction", "The action "+action+" is not valid for this endpoint.")
+	}
+
+}
+
+func (srv *Server) snsPublish(w http.ResponseWriter, r *http.Request) {
+
+	target := r.Form.Get("TargetArn")
+	if target == "" {
+		target = r.Form.Get("TopicArn")
+	}
+	if target == "" {
+		target = r.Form.Get("PhoneNumber")
+	}
+	if target == "" {
+		writeQueryError(w, http.StatusBadRequest, "InvalidParameter", "Invalid parameter: TopicArn or TargetArn Reason: no value for required parameter")
+		return
+	}
+
+	message := r.Form.Get("Message")
+	if message == "" {
+		writeQueryError(w, http.StatusBadRequest, "InvalidParameter", "Invalid parameter: Message Reason: Empty message")
+		return
+	}
+
+	p := Publication{
+		MessageID:         newRequestID(),
+		TargetArn:         target,
+		Message:           message,
+		MessageStructure:  r.Form.Get("MessageStructure"),
+		MessageAttributes: formMessageAttributes(r.Form, "MessageAttributes.entry"),
+	}
+
+	srv.messages = append(srv.messages, p)
+
+	writeQueryResponse(w, "Publish", struct {
+		MessageId string `xml:"MessageId"`
+	}{
+		MessageId: p.MessageID,
+	})
+
+}
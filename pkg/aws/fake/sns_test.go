@@ -0,0 +1,50 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_SNS(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+
+	svc := sns.New(srv.Session())
+
+	out, err := svc.Publish(&sns.PublishInput{
+		TargetArn:        aws.String("some_target_arn"),
+		Message:          aws.String(`{"default":"some_message"}`),
+		MessageStructure: aws.String("json"),
+		MessageAttributes: map[string]*sns.MessageAttributeValue{
+			"some_attribute": {
+				DataType:    aws.String("String"),
+				StringValue: aws.String("some_value"),
+			},
+		},
+	})
+
+	assert.NoError(t, err)
+
+	publications := srv.Publications()
+
+	assert.Len(t, publications, 1)
+	assert.Equal(t, *out.MessageId, publications[0].MessageID)
+	assert.Equal(t, "some_target_arn", publications[0].TargetArn)
+	assert.Equal(t, `{"default":"some_message"}`, publications[0].Message)
+	assert.Equal(t, "json", publications[0].MessageStructure)
+	assert.Equal(t, "some_value", publications[0].MessageAttributes["some_attribute"].StringValue)
+
+	_, err = svc.Publish(&sns.PublishInput{
+		TargetArn: aws.String("some_target_arn"),
+		Message:   aws.String(""),
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, sns.ErrCodeInvalidParameterException, err.(awserr.Error).Code())
+
+}
@@ -0,0 +1,427 @@
+package fake
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const defaultVisibilityTimeout = "30"
+
+// queue is an in-memory SQS queue
+type queue struct {
+	name       string
+	url        string
+	attributes map[string]string
+	created    time.Time
+	messages   []Message
+	received   int
+}
+
+// Message is a message sent to a fake SQS queue
+type Message struct {
+	MessageID         string
+	Body              string
+	MessageAttributes map[string]MessageAttribute
+}
+
+// MessageAttribute is a message attribute sent along with an SQS message or an SNS publication
+type MessageAttribute struct {
+	DataType    string
+	StringValue string
+	BinaryValue []byte
+}
+
+// queryError is the body of an SQS or SNS error response
+type queryError struct {
+	XMLName   xml.Name `xml:"ErrorResponse"`
+	Type      string   `xml:"Error>Type"`
+	Code      string   `xml:"Error>Code"`
+	Message   string   `xml:"Error>Message"`
+	RequestID string   `xml:"RequestId"`
+}
+
+type receivedMessage struct {
+	MessageId        string                     `xml:"MessageId"`
+	ReceiptHandle    string                     `xml:"ReceiptHandle"`
+	MD5OfBody        string                     `xml:"MD5OfBody"`
+	Body             string                     `xml:"Body"`
+	MessageAttribute []receivedMessageAttribute `xml:"MessageAttribute"`
+}
+
+type receivedMessageAttribute struct {
+	Name  string `xml:"Name"`
+	Value struct {
+		DataType    string `xml:"DataType"`
+		StringValue string `xml:"StringValue,omitempty"`
+		BinaryValue []byte `xml:"BinaryValue,omitempty"`
+	} `xml:"Value"`
+}
+
+type responseMetadata struct {
+	RequestID string `xml:"RequestId"`
+}
+
+type queueAttribute struct {
+	Name  string `xml:"Name"`
+	Value string `xml:"Value"`
+}
+
+// Messages returns the messages sent to the queue named queueName
+func (srv *Server) Messages(queueName string) []Message {
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	q, ok := srv.queues[queueName]
+	if !ok {
+		return nil
+	}
+
+	out := make([]Message, len(q.messages))
+	copy(out, q.messages)
+
+	return out
+
+}
+
+// serveSQS handles an SQS query request
+func (srv *Server) serveSQS(w http.ResponseWriter, r *http.Request) {
+
+	if err := r.ParseForm(); err != nil {
+		writeQueryError(w, http.StatusBadRequest, "MalformedQueryString", err.Error())
+		return
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	switch action := r.Form.Get("Action"); action {
+	case "CreateQueue":
+		srv.sqsCreateQueue(w, r)
+	case "GetQueueUrl":
+		srv.sqsGetQueueUrl(w, r)
+	case "GetQueueAttributes":
+		srv.sqsGetQueueAttributes(w, r)
+	case "SendMessage":
+		srv.sqsSendMessage(w, r)
+	case "ReceiveMessage":
+		srv.sqsReceiveMessage(w, r)
+	default:
+		writeQueryError(w, http.StatusBadRequest, "InvalidAction", "The action "+action+" is not valid for this endpoint.")
+	}
+
+}
+
+func (srv *Server) sqsCreateQueue(w http.ResponseWriter, r *http.Request) {
+
+	name := r.Form.Get("QueueName")
+	if name == "" {
+		writeQueryError(w, http.StatusBadRequest, "MissingParameter", "The request must contain the parameter QueueName.")
+		return
+	}
+
+	q, ok := srv.queues[name]
+	if !ok {
+
+		q = &queue{
+			name:       name,
+			url:        srv.queueURL(name),
+			attributes: formMap(r.Form, "Attribute", "Name", "Value"),
+			created:    time.Now(),
+		}
+
+		srv.queues[name] = q
+
+	}
+
+	writeQueryResponse(w, "CreateQueue", struct {
+		QueueUrl string `xml:"QueueUrl"`
+	}{
+		QueueUrl: q.url,
+	})
+
+}
+
+func (srv *Server) sqsGetQueueUrl(w http.ResponseWriter, r *http.Request) {
+
+	q, ok := srv.queues[r.Form.Get("QueueName")]
+	if !ok {
+		writeNonExistentQueue(w)
+		return
+	}
+
+	writeQueryResponse(w, "GetQueueUrl", struct {
+		QueueUrl string `xml:"QueueUrl"`
+	}{
+		QueueUrl: q.url,
+	})
+
+}
+
+func (srv *Server) sqsGetQueueAttributes(w http.ResponseWriter, r *http.Request) {
+
+	q, ok := srv.queueByURL(r.Form.Get("QueueUrl"))
+	if !ok {
+		writeNonExistentQueue(w)
+		return
+	}
+
+	attributes := map[string]string{
+		"QueueArn":                    "arn:aws:sqs:" + Region + ":" + AccountID + ":" + q.name,
+		"ApproximateNumberOfMessages": strconv.Itoa(len(q.messages)),
+		"CreatedTimestamp":            strconv.FormatInt(q.created.Unix(), 10),
+		"VisibilityTimeout":           defaultVisibilityTimeout,
+	}
+	for k, v := range q.attributes {
+		attributes[k] = v
+	}
+
+	names := formList(r.Form, "AttributeName")
+	all := len(names) == 0
+	wanted := make(map[string]bool)
+	for _, n := range names {
+		all = all || n == "All"
+		wanted[n] = true
+	}
+
+	out := make([]queueAttribute, 0, len(attributes))
+	for k, v := range attributes {
+		if all || wanted[k] {
+			out = append(out, queueAttribute{Name: k, Value: v})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+
+	writeQueryResponse(w, "GetQueueAttributes", struct {
+		Attribute []queueAttribute `xml:"Attribute"`
+	}{
+		Attribute: out,
+	})
+
+}
+
+func (srv *Server) sqsSendMessage(w http.ResponseWriter, r *http.Request) {
+
+	q, ok := srv.queueByURL(r.Form.Get("QueueUrl"))
+	if !ok {
+		writeNonExistentQueue(w)
+		return
+	}
+
+	body := r.Form.Get("MessageBody")
+	if body == "" {
+		writeQueryError(w, http.StatusBadRequest, "MissingParameter", "The request must contain the parameter MessageBody.")
+		return
+	}
+
+	msg := Message{
+		MessageID:         newRequestID(),
+		Body:              body,
+		MessageAttributes: formMessageAttributes(r.Form, "MessageAttribute"),
+	}
+
+	q.messages = append(q.messages, msg)
+
+	sum := md5.Sum([]byte(body))
+
+	writeQueryResponse(w, "SendMessage", struct {
+		MD5OfMessageBody string `xml:"MD5OfMessageBody"`
+		MessageId        string `xml:"MessageId"`
+	}{
+		MD5OfMessageBody: hex.EncodeToString(sum[:]),
+		MessageId:        msg.MessageID,
+	})
+
+}
+
+func (srv *Server) sqsReceiveMessage(w http.ResponseWriter, r *http.Request) {
+
+	q, ok := srv.queueByURL(r.Form.Get("QueueUrl"))
+	if !ok {
+		writeNonExistentQueue(w)
+		return
+	}
+
+	max := 1
+	if n, err := strconv.Atoi(r.Form.Get("MaxNumberOfMessages")); err == nil && n > 0 {
+		max = n
+	}
+
+	names := make(map[string]bool)
+	for _, n := range formList(r.Form, "MessageAttributeName") {
+		names[n] = true
+	}
+
+	out := make([]receivedMessage, 0, max)
+
+	for ; q.received < len(q.messages) && len(out) < max; q.received++ {
+
+		msg := q.messages[q.received]
+		sum := md5.Sum([]byte(msg.Body))
+
+		received := receivedMessage{
+			MessageId:     msg.MessageID,
+			ReceiptHandle: newRequestID(),
+			MD5OfBody:     hex.EncodeToString(sum[:]),
+			Body:          msg.Body,
+		}
+
+		for name, v := range msg.MessageAttributes {
+
+			if !names["All"] && !names[name] {
+				continue
+			}
+
+			a := receivedMessageAttribute{Name: name}
+			a.Value.DataType = v.DataType
+			a.Value.StringValue = v.StringValue
+			a.Value.BinaryValue = v.BinaryValue
+
+			received.MessageAttribute = append(received.MessageAttribute, a)
+
+		}
+
+		out = append(out, received)
+
+	}
+
+	writeQueryResponse(w, "ReceiveMessage", struct {
+		Message []receivedMessage `xml:"Message"`
+	}{
+		Message: out,
+	})
+
+}
+
+// queueURL returns the url of the queue named name
+func (srv *Server) queueURL(name string) string {
+	return srv.URL + "/" + AccountID + "/" + name
+}
+
+// queueByURL returns the queue whose url is queueURL
+func (srv *Server) queueByURL(queueURL string) (*queue, bool) {
+
+	for _, q := range srv.queues {
+		if q.url == queueURL {
+			return q, true
+		}
+	}
+
+	return nil, false
+
+}
+
+// formList returns the values of a query list like prefix.1, prefix.2, ...
+func formList(form url.Values, prefix string) []string {
+
+	out := make([]string, 0)
+
+	for i := 1; ; i++ {
+
+		v, ok := form[prefix+"."+strconv.Itoa(i)]
+		if !ok {
+			return out
+		}
+
+		out = append(out, v[0])
+
+	}
+
+}
+
+// formMap returns the entries of a query map like prefix.1.key=k, prefix.1.value=v, ...
+func formMap(form url.Values, prefix, key, value string) map[string]string {
+
+	out := make(map[string]string)
+
+	for i := 1; ; i++ {
+
+		entry := prefix + "." + strconv.Itoa(i) + "."
+
+		k := form.Get(entry + key)
+		if k == "" {
+			return out
+		}
+
+		out[k] = form.Get(entry + value)
+
+	}
+
+}
+
+// formMessageAttributes returns the message attributes serialized under prefix
+func formMessageAttributes(form url.Values, prefix string) map[string]MessageAttribute {
+
+	out := make(map[string]MessageAttribute)
+
+	for i := 1; ; i++ {
+
+		entry := prefix + "." + strconv.Itoa(i) + "."
+
+		name := form.Get(entry + "Name")
+		if name == "" {
+			return out
+		}
+
+		binary, _ := base64.StdEncoding.DecodeString(form.Get(entry + "Value.BinaryValue"))
+
+		out[name] = MessageAttribute{
+			DataType:    form.Get(entry + "Value.DataType"),
+			StringValue: form.Get(entry + "Value.StringValue"),
+			BinaryValue: binary,
+		}
+
+	}
+
+}
+
+// writeQueryResponse writes an SQS or SNS response wrapping result in
+// <action>Response><action>Result>
+func writeQueryResponse(w http.ResponseWriter, action string, result interface{}) {
+
+	requestID := newRequestID()
+
+	w.Header().Set("Content-Type", "text/xml")
+	w.Header().Set("X-Amzn-Requestid", requestID)
+	w.WriteHeader(http.StatusOK)
+
+	enc := xml.NewEncoder(w)
+
+	start := xml.StartElement{Name: xml.Name{Local: action + "Response"}}
+	enc.EncodeToken(start)
+	enc.EncodeElement(result, xml.StartElement{Name: xml.Name{Local: action + "Result"}})
+	enc.EncodeElement(responseMetadata{RequestID: requestID}, xml.StartElement{Name: xml.Name{Local: "ResponseMetadata"}})
+	enc.EncodeToken(start.End())
+	enc.Flush()
+
+}
+
+// writeNonExistentQueue writes the error returned by SQS when a queue cannot be found
+func writeNonExistentQueue(w http.ResponseWriter) {
+	writeQueryError(w, http.StatusBadRequest, "AWS.SimpleQueueService.NonExistentQueue", "The specified queue does not exist for this wsdl version.")
+}
+
+// writeQueryError writes an SQS or SNS error response
+func writeQueryError(w http.ResponseWriter, status int, code, message string) {
+
+	requestID := newRequestID()
+
+	w.Header().Set("Content-Type", "text/xml")
+	w.Header().Set("X-Amzn-Requestid", requestID)
+	w.WriteHeader(status)
+
+	xml.NewEncoder(w).Encode(queryError{
+		Type:      "Sender",
+		Code:      code,
+		Message:   message,
+		RequestID: requestID,
+	})
+
+}
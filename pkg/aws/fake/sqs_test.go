@@ -0,0 +1,86 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_SQS(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+
+	svc := sqs.New(srv.Session())
+
+	_, err := svc.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("some_queue")})
+
+	assert.Error(t, err)
+	assert.Equal(t, sqs.ErrCodeQueueDoesNotExist, err.(awserr.Error).Code())
+
+	created, err := svc.CreateQueue(&sqs.CreateQueueInput{
+		QueueName:  aws.String("some_queue"),
+		Attributes: map[string]*string{"DelaySeconds": aws.String("5")},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, srv.URL+"/"+AccountID+"/some_queue", *created.QueueUrl)
+
+	url, err := svc.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("some_queue")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, *created.QueueUrl, *url.QueueUrl)
+
+	attributes, err := svc.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		QueueUrl:       url.QueueUrl,
+		AttributeNames: []*string{aws.String("All")},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "5", *attributes.Attributes["DelaySeconds"])
+	assert.Equal(t, "0", *attributes.Attributes["ApproximateNumberOfMessages"])
+
+	sent, err := svc.SendMessage(&sqs.SendMessageInput{
+		QueueUrl:    url.QueueUrl,
+		MessageBody: aws.String("some_body"),
+		MessageAttributes: map[string]*sqs.MessageAttributeValue{
+			"some_attribute": {
+				DataType:    aws.String("String"),
+				StringValue: aws.String("some_value"),
+			},
+		},
+	})
+
+	assert.NoError(t, err)
+
+	messages := srv.Messages("some_queue")
+
+	assert.Len(t, messages, 1)
+	assert.Equal(t, *sent.MessageId, messages[0].MessageID)
+	assert.Equal(t, "some_body", messages[0].Body)
+	assert.Equal(t, "some_value", messages[0].MessageAttributes["some_attribute"].StringValue)
+
+	received, err := svc.ReceiveMessage(&sqs.ReceiveMessageInput{
+		QueueUrl:              url.QueueUrl,
+		MessageAttributeNames: []*string{aws.String("All")},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, received.Messages, 1)
+	assert.Equal(t, "some_body", *received.Messages[0].Body)
+	assert.Equal(t, "some_value", *received.Messages[0].MessageAttributes["some_attribute"].StringValue)
+
+	received, err = svc.ReceiveMessage(&sqs.ReceiveMessageInput{QueueUrl: url.QueueUrl})
+
+	assert.NoError(t, err)
+	assert.Empty(t, received.Messages)
+
+	_, err = svc.GetQueueAttributes(&sqs.GetQueueAttributesInput{QueueUrl: aws.String("badURL")})
+
+	assert.Error(t, err)
+	assert.Equal(t, sqs.ErrCodeQueueDoesNotExist, err.(awserr.Error).Code())
+
+}
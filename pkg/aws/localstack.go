@@ -0,0 +1,160 @@
+package aws
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+const (
+
+	// LocalStackEnv enables the local mode of every session built without WithLocalStack
+	// when set to a base url, like http://localhost:4566
+	LocalStackEnv = "AWSB_LOCALSTACK_URL"
+
+	// LocalStackPortsEnv optionally sets the per-service ports of the local mode enabled
+	// by LocalStackEnv, as a comma separated list like s3=4572,sqs=4576
+	LocalStackPortsEnv = "AWSB_LOCALSTACK_PORTS"
+
+	// LocalAccessKeyID is the access key used in local mode when no credentials are set
+	LocalAccessKeyID = "test"
+
+	// LocalSecretAccessKey is the secret key used in local mode when no credentials are set
+	LocalSecretAccessKey = "test"
+)
+
+// localStack resolves the endpoint of every service to a local emulator
+type localStack struct {
+	baseURL *url.URL
+	ports   map[string]int
+}
+
+// WithLocalStack makes every client built from the session talk to a local emulator,
+// like localstack, listening on baseURL. ports optionally maps service endpoint ids,
+// like s3.EndpointsID, to the port they listen on, on the host of baseURL.
+// Services missing from ports are reached on baseURL.
+// Local mode also forces path style S3 addressing, disables SSL and signs requests
+// with LocalAccessKeyID and LocalSecretAccessKey unless other credentials are set.
+// Endpoints passed to the service constructors still take precedence
+func WithLocalStack(baseURL string, ports map[string]int) SessionOption {
+	return func(in *SessionInput) error {
+
+		local, err := newLocalStack(baseURL, ports)
+		if err != nil {
+			return err
+		}
+
+		in.localStack = local
+
+		return nil
+
+	}
+}
+
+// newLocalStack validates baseURL and ports and returns a new *localStack
+func newLocalStack(baseURL string, ports map[string]int) (*localStack, error) {
+
+	if baseURL == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BaseURL)
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, BaseURL)
+	}
+
+	for _, port := range ports {
+		if port < 1 || port > 65535 {
+			return nil, intErr.NewValidationError(ErrInvalidParameter, Ports)
+		}
+	}
+
+	return &localStack{
+		baseURL: u,
+		ports:   ports,
+	}, nil
+
+}
+
+// localStackFromEnv returns the *localStack described by LocalStackEnv and
+// LocalStackPortsEnv, or nil if LocalStackEnv is not set
+func localStackFromEnv() (*localStack, error) {
+
+	baseURL := os.Getenv(LocalStackEnv)
+	if baseURL == "" {
+		return nil, nil
+	}
+
+	ports := make(map[string]int)
+
+	for _, pair := range strings.Split(os.Getenv(LocalStackPortsEnv), ",") {
+
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, intErr.NewValidationError(ErrInvalidParameter, LocalStackPortsEnv)
+		}
+
+		port, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, intErr.NewValidationError(ErrInvalidParameter, LocalStackPortsEnv)
+		}
+
+		ports[strings.TrimSpace(kv[0])] = port
+
+	}
+
+	return newLocalStack(baseURL, ports)
+
+}
+
+// EndpointFor implements endpoints.Resolver
+func (l *localStack) EndpointFor(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+
+	return endpoints.ResolvedEndpoint{
+		URL:                l.endpoint(service),
+		SigningRegion:      region,
+		SigningName:        service,
+		SigningNameDerived: true,
+	}, nil
+
+}
+
+// endpoint returns the url service is reached on
+func (l *localStack) endpoint(service string) string {
+
+	u := *l.baseURL
+
+	if port, ok := l.ports[service]; ok {
+		u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(port))
+	}
+
+	return u.String()
+
+}
+
+// apply points cfg to the local emulator. Dummy credentials are only set when
+// withCredentials is true, so that explicit credential sources are left untouched
+func (l *localStack) apply(cfg *aws.Config, withCredentials bool) {
+
+	cfg.EndpointResolver = l
+	cfg.S3ForcePathStyle = aws.Bool(true)
+	cfg.DisableSSL = aws.Bool(true)
+
+	if withCredentials {
+		cfg.Credentials = credentials.NewStaticCredentials(LocalAccessKeyID, LocalSecretAccessKey, "")
+	}
+
+}
@@ -0,0 +1,128 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLocalStack(t *testing.T) {
+
+	in, err := NewSessionInput("some_region", WithLocalStack("http://localhost:4566", map[string]int{"s3": 4572}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:4566", in.localStack.baseURL.Host)
+	assert.Equal(t, 4572, in.localStack.ports["s3"])
+
+	_, err = NewSessionInput("some_region", WithLocalStack("", nil))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrEmptyParameter)
+
+	_, err = NewSessionInput("some_region", WithLocalStack("localhost:4566", nil))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidParameter)
+
+	_, err = NewSessionInput("some_region", WithLocalStack("http://localhost", map[string]int{"s3": 0}))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidParameter)
+
+}
+
+func TestLocalStackFromEnv(t *testing.T) {
+
+	in, err := NewSessionInput("some_region")
+
+	assert.NoError(t, err)
+	assert.Nil(t, in.localStack)
+
+	t.Setenv(LocalStackEnv, "http://localhost:4566")
+	t.Setenv(LocalStackPortsEnv, "s3=4572, sqs=4576")
+
+	in, err = NewSessionInput("some_region")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, in.localStack)
+	assert.Equal(t, map[string]int{"s3": 4572, "sqs": 4576}, in.localStack.ports)
+
+	in, err = NewSessionInput("some_region", WithLocalStack("http://127.0.0.1:9000", nil))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9000", in.localStack.baseURL.Host)
+
+	t.Setenv(LocalStackPortsEnv, "s3")
+
+	_, err = NewSessionInput("some_region")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidParameter)
+
+}
+
+func TestLocalStack_EndpointFor(t *testing.T) {
+
+	local, err := newLocalStack("http://localhost:4566", map[string]int{"sqs": 4576})
+
+	assert.NoError(t, err)
+
+	resolved, err := local.EndpointFor("sqs", "some_region")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:4576", resolved.URL)
+	assert.Equal(t, "sqs", resolved.SigningName)
+	assert.Equal(t, "some_region", resolved.SigningRegion)
+
+	resolved, err = local.EndpointFor("dynamodb", "some_region")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost:4566", resolved.URL)
+
+}
+
+func TestNew_LocalStack(t *testing.T) {
+
+	in, err := NewSessionInput("some_region", WithLocalStack("http://localhost:4566", map[string]int{"s3": 4572}))
+
+	assert.NoError(t, err)
+
+	svc, err := New(in)
+
+	assert.NoError(t, err)
+	assert.True(t, aws.BoolValue(svc.Config.S3ForcePathStyle))
+	assert.True(t, aws.BoolValue(svc.Config.DisableSSL))
+
+	creds, err := svc.Config.Credentials.Get()
+
+	assert.NoError(t, err)
+	assert.Equal(t, LocalAccessKeyID, creds.AccessKeyID)
+	assert.Equal(t, LocalSecretAccessKey, creds.SecretAccessKey)
+
+	assert.Equal(t, "http://localhost:4572", s3.New(svc).Endpoint)
+	assert.Equal(t, "http://localhost:4566", sqs.New(svc).Endpoint)
+	assert.Equal(t, "http://localhost:4566", dynamodb.New(svc).Endpoint)
+	assert.Equal(t, "http://localhost:9000", sqs.New(svc.ServiceSession(WithEndpoint("http://localhost:9000"))).Endpoint)
+
+	in, err = NewSessionInput(
+		"some_region",
+		WithLocalStack("http://localhost:4566", nil),
+		WithStaticCredentials("some_key", "some_secret", ""),
+	)
+
+	assert.NoError(t, err)
+
+	svc, err = New(in)
+
+	assert.NoError(t, err)
+
+	creds, err = svc.Config.Credentials.Get()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_key", creds.AccessKeyID)
+
+}
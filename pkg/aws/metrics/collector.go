@@ -0,0 +1,173 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/prometheus/client_golang/prometheus"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+)
+
+const (
+	serviceLabel   = "service"
+	operationLabel = "operation"
+	helperLabel    = "helper"
+	codeLabel      = "code"
+
+	s3Service          = "s3"
+	sqsService         = "sqs"
+	snsService         = "sns"
+	dynamodbService    = "dynamodb"
+	rekognitionService = "rekognition"
+
+	// UnknownErrorCode is the code label of errors neither returned by aws nor by validation
+	UnknownErrorCode = "Unknown"
+)
+
+// Collector records calls, errors, retries and latencies of aws-sdk-go calls,
+// as well as calls, errors and latencies of the wrapped helpers,
+// and exposes them as a prometheus.Collector
+type Collector struct {
+	calls          *prometheus.CounterVec
+	errors         *prometheus.CounterVec
+	retries        *prometheus.CounterVec
+	duration       *prometheus.HistogramVec
+	helperCalls    *prometheus.CounterVec
+	helperErrors   *prometheus.CounterVec
+	helperDuration *prometheus.HistogramVec
+}
+
+// New returns a new *Collector whose metrics are prefixed by namespace.
+// buckets optionally overrides the latency histogram buckets, in seconds,
+// which default to prometheus.DefBuckets
+func New(namespace string, buckets ...float64) *Collector {
+
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+
+	labels := []string{serviceLabel, operationLabel}
+	helperLabels := []string{serviceLabel, helperLabel}
+
+	return &Collector{
+		calls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "calls_total",
+			Help:      "Number of aws calls, retries excluded.",
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "errors_total",
+			Help:      "Number of failed aws calls by aws error code.",
+		}, append(labels, codeLabel)),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retries_total",
+			Help:      "Number of retried aws call attempts.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "call_duration_seconds",
+			Help:      "Latency of aws calls, retries included.",
+			Buckets:   buckets,
+		}, labels),
+		helperCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "helper_calls_total",
+			Help:      "Number of helper calls.",
+		}, helperLabels),
+		helperErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "helper_errors_total",
+			Help:      "Number of failed helper calls by error code.",
+		}, append(helperLabels, codeLabel)),
+		helperDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "helper_duration_seconds",
+			Help:      "Latency of helper calls, all of their aws calls included.",
+			Buckets:   buckets,
+		}, helperLabels),
+	}
+
+}
+
+// Middleware returns the pkgAws.Middleware recording the calls of a session on c
+func (c *Collector) Middleware() pkgAws.Middleware {
+	return pkgAws.CompleteMiddleware(c.Observe)
+}
+
+// Observe records a completed call
+func (c *Collector) Observe(call pkgAws.Call) {
+
+	labels := prometheus.Labels{
+		serviceLabel:   call.Service,
+		operationLabel: call.Operation,
+	}
+
+	c.calls.With(labels).Inc()
+	c.retries.With(labels).Add(float64(call.Retries))
+	c.duration.With(labels).Observe(call.Duration.Seconds())
+
+	if call.Err != nil {
+		c.errors.WithLabelValues(call.Service, call.Operation, errorCode(call.Err)).Inc()
+	}
+
+}
+
+// observeHelper records a helper call of service started at start.
+// err is a pointer so that it can be deferred before the error is known
+func (c *Collector) observeHelper(service, helper string, start time.Time, err *error) {
+
+	c.helperCalls.WithLabelValues(service, helper).Inc()
+	c.helperDuration.WithLabelValues(service, helper).Observe(time.Since(start).Seconds())
+
+	if *err != nil {
+		c.helperErrors.WithLabelValues(service, helper, errorCode(*err)).Inc()
+	}
+
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+
+	c.calls.Describe(ch)
+	c.errors.Describe(ch)
+	c.retries.Describe(ch)
+	c.duration.Describe(ch)
+	c.helperCalls.Describe(ch)
+	c.helperErrors.Describe(ch)
+	c.helperDuration.Describe(ch)
+
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+
+	c.calls.Collect(ch)
+	c.errors.Collect(ch)
+	c.retries.Collect(ch)
+	c.duration.Collect(ch)
+	c.helperCalls.Collect(ch)
+	c.helperErrors.Collect(ch)
+	c.helperDuration.Collect(ch)
+
+}
+
+// errorCode returns the aws error code of err, or its code if it is an *intErr.ValidationError
+func errorCode(err error) string {
+
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code()
+	}
+
+	var validErr *intErr.ValidationError
+	if errors.As(err, &validErr) {
+		return validErr.Code
+	}
+
+	return UnknownErrorCode
+
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/sqs"
+)
+
+func TestCollector_Observe(t *testing.T) {
+
+	c := New("some_namespace")
+
+	c.Observe(pkgAws.Call{
+		Service:   "dynamodb",
+		Operation: "PutItem",
+		Retries:   2,
+		Duration:  time.Second,
+	})
+	c.Observe(pkgAws.Call{
+		Service:   "dynamodb",
+		Operation: "PutItem",
+		Duration:  time.Second,
+		Err:       awserr.New("ProvisionedThroughputExceededException", "some_message", nil),
+	})
+	c.Observe(pkgAws.Call{
+		Service:   "dynamodb",
+		Operation: "PutItem",
+		Err:       errors.New("some_error"),
+	})
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(c.calls.WithLabelValues("dynamodb", "PutItem")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(c.retries.WithLabelValues("dynamodb", "PutItem")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.errors.WithLabelValues("dynamodb", "PutItem", "ProvisionedThroughputExceededException")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.errors.WithLabelValues("dynamodb", "PutItem", UnknownErrorCode)))
+
+	reg := prometheus.NewPedanticRegistry()
+
+	assert.NoError(t, reg.Register(c))
+
+	families, err := reg.Gather()
+
+	assert.NoError(t, err)
+	assert.Len(t, families, 4)
+
+}
+
+func TestCollector_Middleware(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	c := New("some_namespace")
+
+	in, err := pkgAws.NewSessionInput(
+		fake.Region,
+		pkgAws.WithStaticCredentials(fake.AccessKeyID, fake.SecretAccessKey, ""),
+		pkgAws.WithMiddleware(c.Middleware()),
+	)
+
+	assert.NoError(t, err)
+
+	awsSvc, err := pkgAws.New(in)
+
+	assert.NoError(t, err)
+
+	sqsSvc, err := sqs.New(awsSvc, srv.URL)
+
+	assert.NoError(t, err)
+
+	err = sqsSvc.SQSSendMessage(map[string]string{"some_key": "some_value"}, "some_missing_queue", false)
+
+	assert.Error(t, err)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.calls.WithLabelValues("sqs", "GetQueueUrl")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.errors.WithLabelValues("sqs", "GetQueueUrl", "AWS.SimpleQueueService.NonExistentQueue")))
+	assert.Equal(t, 1, collectAndCount(c.duration))
+
+}
+
+// collectAndCount returns the number of metrics c collects
+func collectAndCount(c prometheus.Collector) int {
+
+	ch := make(chan prometheus.Metric)
+
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+
+	count := 0
+	for range ch {
+		count++
+	}
+
+	return count
+
+}
@@ -0,0 +1,21 @@
+// Package metrics records prometheus metrics for every call made through a *aws.Session
+// and for every call of the service helpers.
+//
+// A *Collector is registered on the session as a middleware, on a prometheus registry
+// as a prometheus.Collector, and wraps the service helpers:
+//
+//	collector := metrics.New("some_namespace")
+//	prometheus.MustRegister(collector)
+//
+//	in, err := aws.NewSessionInput(region, aws.WithMiddleware(collector.Middleware()))
+//	...
+//	sqsSvc, err := sqs.New(awsSvc, endpoint)
+//	...
+//	var svc sqs.SQSAPI = collector.SQS(sqsSvc)
+//
+// The calls made through the session are labelled by service and operation, as named by
+// aws-sdk-go, so that SQSSendMessage is recorded as the GetQueueUrl and SendMessage operations
+// of the sqs service. The helper calls are labelled by service and helper, so that the same
+// call is also recorded once as the SQSSendMessage helper, failed or not. Errors are labelled
+// by their aws error code, or by the code of the validation error returned before any call
+package metrics
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/dynamodb"
+)
+
+// DynamoDB wraps a dynamodb.DynamoDBAPI recording the metrics of every helper call
+type DynamoDB struct {
+	next      dynamodb.DynamoDBAPI
+	collector *Collector
+}
+
+var _ dynamodb.DynamoDBAPI = (*DynamoDB)(nil)
+
+// DynamoDB returns next wrapped in a *DynamoDB
+func (c *Collector) DynamoDB(next dynamodb.DynamoDBAPI) *DynamoDB {
+	return &DynamoDB{
+		next:      next,
+		collector: c,
+	}
+}
+
+// DynamoPutItem calls DynamoPutItem on the wrapped dynamodb.DynamoDBAPI and records its metrics
+func (svc *DynamoDB) DynamoPutItem(input interface{}, table string) error {
+	return svc.DynamoPutItemWithContext(context.Background(), input, table)
+}
+
+// DynamoPutItemWithContext calls DynamoPutItemWithContext on the wrapped dynamodb.DynamoDBAPI and records its metrics
+func (svc *DynamoDB) DynamoPutItemWithContext(ctx context.Context, input interface{}, table string) (err error) {
+
+	defer svc.collector.observeHelper(dynamodbService, "DynamoPutItem", time.Now(), &err)
+
+	return svc.next.DynamoPutItemWithContext(ctx, input, table)
+
+}
+
+// DynamoGetItem calls DynamoGetItem on the wrapped dynamodb.DynamoDBAPI and records its metrics
+func (svc *DynamoDB) DynamoGetItem(table, keyName, keyValue string) (*dynamodb.GetItemOutput, error) {
+	return svc.DynamoGetItemWithContext(context.Background(), table, keyName, keyValue)
+}
+
+// DynamoGetItemWithContext calls DynamoGetItemWithContext on the wrapped dynamodb.DynamoDBAPI and records its metrics
+func (svc *DynamoDB) DynamoGetItemWithContext(ctx context.Context, table, keyName, keyValue string) (out *dynamodb.GetItemOutput, err error) {
+
+	defer svc.collector.observeHelper(dynamodbService, "DynamoGetItem", time.Now(), &err)
+
+	return svc.next.DynamoGetItemWithContext(ctx, table, keyName, keyValue)
+
+}
+
+// DynamoScan calls DynamoScan on the wrapped dynamodb.DynamoDBAPI and records its metrics
+func (svc *DynamoDB) DynamoScan(table, keyName string, keyValue interface{}) (*dynamodb.ScanOutput, error) {
+	return svc.DynamoScanWithContext(context.Background(), table, keyName, keyValue)
+}
+
+// DynamoScanWithContext calls DynamoScanWithContext on the wrapped dynamodb.DynamoDBAPI and records its metrics
+func (svc *DynamoDB) DynamoScanWithContext(ctx context.Context, table, keyName string, keyValue interface{}) (out *dynamodb.ScanOutput, err error) {
+
+	defer svc.collector.observeHelper(dynamodbService, "DynamoScan", time.Now(), &err)
+
+	return svc.next.DynamoScanWithContext(ctx, table, keyName, keyValue)
+
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/dynamodb"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/mock"
+)
+
+func TestDynamoDB(t *testing.T) {
+
+	c := New("some_namespace")
+
+	m := &mock.DynamoDB{
+		DynamoPutItemFunc: func(ctx context.Context, input interface{}, table string) error {
+			return intErr.NewValidationError(dynamodb.ErrEmptyParameter, dynamodb.Table)
+		},
+	}
+
+	svc := c.DynamoDB(m)
+
+	assert.Error(t, svc.DynamoPutItem("some_input", ""))
+
+	_, err := svc.DynamoGetItem("some_table", "some_key", "some_value")
+
+	assert.NoError(t, err)
+
+	_, err = svc.DynamoScan("some_table", "some_key", "some_value")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(m.Calls()))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.helperCalls.WithLabelValues("dynamodb", "DynamoPutItem")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.helperErrors.WithLabelValues("dynamodb", "DynamoPutItem", dynamodb.ErrEmptyParameter)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.helperCalls.WithLabelValues("dynamodb", "DynamoGetItem")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.helperCalls.WithLabelValues("dynamodb", "DynamoScan")))
+
+}
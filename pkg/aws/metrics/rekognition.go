@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/rekognition"
+)
+
+// Rekognition wraps a rekognition.RekognitionAPI recording the metrics of every helper call
+type Rekognition struct {
+	next      rekognition.RekognitionAPI
+	collector *Collector
+}
+
+var _ rekognition.RekognitionAPI = (*Rekognition)(nil)
+
+// Rekognition returns next wrapped in a *Rekognition
+func (c *Collector) Rekognition(next rekognition.RekognitionAPI) *Rekognition {
+	return &Rekognition{
+		next:      next,
+		collector: c,
+	}
+}
+
+// RekognitionCompareFaces calls RekognitionCompareFaces on the wrapped rekognition.RekognitionAPI and records its metrics
+func (svc *Rekognition) RekognitionCompareFaces(sourceImage, targetImage []byte, similarity float64) (*rekognition.CompareFacesOutput, error) {
+	return svc.RekognitionCompareFacesWithContext(context.Background(), sourceImage, targetImage, similarity)
+}
+
+// RekognitionCompareFacesWithContext calls RekognitionCompareFacesWithContext on the wrapped rekognition.RekognitionAPI and records its metrics
+func (svc *Rekognition) RekognitionCompareFacesWithContext(ctx context.Context, sourceImage, targetImage []byte, similarity float64) (out *rekognition.CompareFacesOutput, err error) {
+
+	defer svc.collector.observeHelper(rekognitionService, "RekognitionCompareFaces", time.Now(), &err)
+
+	return svc.next.RekognitionCompareFacesWithContext(ctx, sourceImage, targetImage, similarity)
+
+}
+
+// RekognitionDetectFaces calls RekognitionDetectFaces on the wrapped rekognition.RekognitionAPI and records its metrics
+func (svc *Rekognition) RekognitionDetectFaces(sourceImage []byte) (*rekognition.DetectFacesOutput, error) {
+	return svc.RekognitionDetectFacesWithContext(context.Background(), sourceImage)
+}
+
+// RekognitionDetectFacesWithContext calls RekognitionDetectFacesWithContext on the wrapped rekognition.RekognitionAPI and records its metrics
+func (svc *Rekognition) RekognitionDetectFacesWithContext(ctx context.Context, sourceImage []byte) (out *rekognition.DetectFacesOutput, err error) {
+
+	defer svc.collector.observeHelper(rekognitionService, "RekognitionDetectFaces", time.Now(), &err)
+
+	return svc.next.RekognitionDetectFacesWithContext(ctx, sourceImage)
+
+}
+
+// RekognitionDetectText calls RekognitionDetectText on the wrapped rekognition.RekognitionAPI and records its metrics
+func (svc *Rekognition) RekognitionDetectText(sourceImage []byte) (*rekognition.DetectTextOutput, error) {
+	return svc.RekognitionDetectTextWithContext(context.Background(), sourceImage)
+}
+
+// RekognitionDetectTextWithContext calls RekognitionDetectTextWithContext on the wrapped rekognition.RekognitionAPI and records its metrics
+func (svc *Rekognition) RekognitionDetectTextWithContext(ctx context.Context, sourceImage []byte) (out *rekognition.DetectTextOutput, err error) {
+
+	defer svc.collector.observeHelper(rekognitionService, "RekognitionDetectText", time.Now(), &err)
+
+	return svc.next.RekognitionDetectTextWithContext(ctx, sourceImage)
+
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/mock"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/rekognition"
+)
+
+func TestRekognition(t *testing.T) {
+
+	c := New("some_namespace")
+
+	m := &mock.Rekognition{
+		RekognitionDetectFacesFunc: func(ctx context.Context, sourceImage []byte) (*rekognition.DetectFacesOutput, error) {
+			return nil, awserr.New("InvalidImageFormatException", "some_message", nil)
+		},
+	}
+
+	svc := c.Rekognition(m)
+
+	_, err := svc.RekognitionCompareFaces([]byte("some_source"), []byte("some_target"), 70)
+
+	assert.NoError(t, err)
+
+	_, err = svc.RekognitionDetectFaces([]byte("some_source"))
+
+	assert.Error(t, err)
+
+	_, err = svc.RekognitionDetectText([]byte("some_source"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(m.Calls()))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.helperCalls.WithLabelValues("rekognition", "RekognitionDetectFaces")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.helperErrors.WithLabelValues("rekognition", "RekognitionDetectFaces", "InvalidImageFormatException")))
+	assert.Equal(t, 3, collectAndCount(c.helperCalls))
+
+}
@@ -0,0 +1,532 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/s3"
+)
+
+// S3 wraps an s3.S3API recording the metrics of every helper call
+type S3 struct {
+	next      s3.S3API
+	collector *Collector
+}
+
+var _ s3.S3API = (*S3)(nil)
+
+// S3 returns next wrapped in a *S3
+func (c *Collector) S3(next s3.S3API) *S3 {
+	return &S3{
+		next:      next,
+		collector: c,
+	}
+}
+
+// S3CreateBucket calls S3CreateBucket on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3CreateBucket(bucketName string, opts ...s3.CreateBucketOption) error {
+	return svc.S3CreateBucketWithContext(context.Background(), bucketName, opts...)
+}
+
+// S3CreateBucketWithContext calls S3CreateBucketWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3CreateBucketWithContext(ctx context.Context, bucketName string, opts ...s3.CreateBucketOption) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3CreateBucket", time.Now(), &err)
+
+	return svc.next.S3CreateBucketWithContext(ctx, bucketName, opts...)
+
+}
+
+// S3GetObject calls S3GetObject on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetObject(bucketName, sourceImage string, opts ...s3.EncryptionOption) ([]byte, error) {
+	return svc.S3GetObjectWithContext(context.Background(), bucketName, sourceImage, opts...)
+}
+
+// S3GetObjectWithContext calls S3GetObjectWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetObjectWithContext(ctx context.Context, bucketName, sourceImage string, opts ...s3.EncryptionOption) (out []byte, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3GetObject", time.Now(), &err)
+
+	return svc.next.S3GetObjectWithContext(ctx, bucketName, sourceImage, opts...)
+
+}
+
+// S3PutObject calls S3PutObject on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutObject(bucketName, objectName, objectPath string, opts ...s3.PutOption) error {
+	return svc.S3PutObjectWithContext(context.Background(), bucketName, objectName, objectPath, opts...)
+}
+
+// S3PutObjectWithContext calls S3PutObjectWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutObjectWithContext(ctx context.Context, bucketName, objectName, objectPath string, opts ...s3.PutOption) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3PutObject", time.Now(), &err)
+
+	return svc.next.S3PutObjectWithContext(ctx, bucketName, objectName, objectPath, opts...)
+
+}
+
+// S3Upload calls S3Upload on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3Upload(bucketName, objectName string, body io.Reader, opts ...s3.UploadOption) error {
+	return svc.S3UploadWithContext(context.Background(), bucketName, objectName, body, opts...)
+}
+
+// S3UploadWithContext calls S3UploadWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3UploadWithContext(ctx context.Context, bucketName, objectName string, body io.Reader, opts ...s3.UploadOption) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3Upload", time.Now(), &err)
+
+	return svc.next.S3UploadWithContext(ctx, bucketName, objectName, body, opts...)
+
+}
+
+// S3UploadFile calls S3UploadFile on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3UploadFile(bucketName, objectName, path string, opts ...s3.UploadOption) error {
+	return svc.S3UploadFileWithContext(context.Background(), bucketName, objectName, path, opts...)
+}
+
+// S3UploadFileWithContext calls S3UploadFileWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3UploadFileWithContext(ctx context.Context, bucketName, objectName, path string, opts ...s3.UploadOption) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3UploadFile", time.Now(), &err)
+
+	return svc.next.S3UploadFileWithContext(ctx, bucketName, objectName, path, opts...)
+
+}
+
+// S3UploadReaderAt calls S3UploadReaderAt on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3UploadReaderAt(bucketName, objectName string, body io.ReaderAt, size int64, opts ...s3.UploadOption) error {
+	return svc.S3UploadReaderAtWithContext(context.Background(), bucketName, objectName, body, size, opts...)
+}
+
+// S3UploadReaderAtWithContext calls S3UploadReaderAtWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3UploadReaderAtWithContext(ctx context.Context, bucketName, objectName string, body io.ReaderAt, size int64, opts ...s3.UploadOption) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3UploadReaderAt", time.Now(), &err)
+
+	return svc.next.S3UploadReaderAtWithContext(ctx, bucketName, objectName, body, size, opts...)
+
+}
+
+// S3Download calls S3Download on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3Download(bucketName, objectName string, w io.Writer, opts ...s3.DownloadOption) (int64, error) {
+	return svc.S3DownloadWithContext(context.Background(), bucketName, objectName, w, opts...)
+}
+
+// S3DownloadWithContext calls S3DownloadWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3DownloadWithContext(ctx context.Context, bucketName, objectName string, w io.Writer, opts ...s3.DownloadOption) (n int64, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3Download", time.Now(), &err)
+
+	return svc.next.S3DownloadWithContext(ctx, bucketName, objectName, w, opts...)
+
+}
+
+// S3DownloadAt calls S3DownloadAt on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3DownloadAt(bucketName, objectName string, w io.WriterAt, opts ...s3.DownloadOption) (int64, error) {
+	return svc.S3DownloadAtWithContext(context.Background(), bucketName, objectName, w, opts...)
+}
+
+// S3DownloadAtWithContext calls S3DownloadAtWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3DownloadAtWithContext(ctx context.Context, bucketName, objectName string, w io.WriterAt, opts ...s3.DownloadOption) (n int64, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3DownloadAt", time.Now(), &err)
+
+	return svc.next.S3DownloadAtWithContext(ctx, bucketName, objectName, w, opts...)
+
+}
+
+// S3ListObjects calls S3ListObjects on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3ListObjects(bucketName string, opts ...s3.ListOption) *s3.ObjectIterator {
+	return svc.S3ListObjectsWithContext(context.Background(), bucketName, opts...)
+}
+
+// S3ListObjectsWithContext calls S3ListObjectsWithContext on the wrapped s3.S3API and records its metrics.
+// Only the creation of the iterator is recorded, the pages are fetched later with its context
+func (svc *S3) S3ListObjectsWithContext(ctx context.Context, bucketName string, opts ...s3.ListOption) (it *s3.ObjectIterator) {
+
+	defer func(start time.Time) {
+		err := it.Err()
+		svc.collector.observeHelper(s3Service, "S3ListObjects", start, &err)
+	}(time.Now())
+
+	return svc.next.S3ListObjectsWithContext(ctx, bucketName, opts...)
+
+}
+
+// S3DeleteObject calls S3DeleteObject on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3DeleteObject(bucketName, objectName string, opts ...s3.DeleteOption) error {
+	return svc.S3DeleteObjectWithContext(context.Background(), bucketName, objectName, opts...)
+}
+
+// S3DeleteObjectWithContext calls S3DeleteObjectWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3DeleteObjectWithContext(ctx context.Context, bucketName, objectName string, opts ...s3.DeleteOption) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3DeleteObject", time.Now(), &err)
+
+	return svc.next.S3DeleteObjectWithContext(ctx, bucketName, objectName, opts...)
+
+}
+
+// S3DeleteObjects calls S3DeleteObjects on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3DeleteObjects(bucketName string, objectNames []string, opts ...s3.DeleteOption) (*s3.DeleteResult, error) {
+	return svc.S3DeleteObjectsWithContext(context.Background(), bucketName, objectNames, opts...)
+}
+
+// S3DeleteObjectsWithContext calls S3DeleteObjectsWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3DeleteObjectsWithContext(ctx context.Context, bucketName string, objectNames []string, opts ...s3.DeleteOption) (result *s3.DeleteResult, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3DeleteObjects", time.Now(), &err)
+
+	return svc.next.S3DeleteObjectsWithContext(ctx, bucketName, objectNames, opts...)
+
+}
+
+// S3DeletePrefix calls S3DeletePrefix on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3DeletePrefix(bucketName, prefix string, opts ...s3.DeleteOption) (*s3.DeleteResult, error) {
+	return svc.S3DeletePrefixWithContext(context.Background(), bucketName, prefix, opts...)
+}
+
+// S3DeletePrefixWithContext calls S3DeletePrefixWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3DeletePrefixWithContext(ctx context.Context, bucketName, prefix string, opts ...s3.DeleteOption) (result *s3.DeleteResult, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3DeletePrefix", time.Now(), &err)
+
+	return svc.next.S3DeletePrefixWithContext(ctx, bucketName, prefix, opts...)
+
+}
+
+// S3Copy calls S3Copy on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3Copy(srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...s3.CopyOption) error {
+	return svc.S3CopyWithContext(context.Background(), srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts...)
+}
+
+// S3CopyWithContext calls S3CopyWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3CopyWithContext(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...s3.CopyOption) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3Copy", time.Now(), &err)
+
+	return svc.next.S3CopyWithContext(ctx, srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts...)
+
+}
+
+// S3Move calls S3Move on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3Move(srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...s3.CopyOption) error {
+	return svc.S3MoveWithContext(context.Background(), srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts...)
+}
+
+// S3MoveWithContext calls S3MoveWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3MoveWithContext(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...s3.CopyOption) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3Move", time.Now(), &err)
+
+	return svc.next.S3MoveWithContext(ctx, srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts...)
+
+}
+
+// S3ReplaceMetadata calls S3ReplaceMetadata on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3ReplaceMetadata(bucketName, objectName string, metadata map[string]string, opts ...s3.CopyOption) error {
+	return svc.S3ReplaceMetadataWithContext(context.Background(), bucketName, objectName, metadata, opts...)
+}
+
+// S3ReplaceMetadataWithContext calls S3ReplaceMetadataWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3ReplaceMetadataWithContext(ctx context.Context, bucketName, objectName string, metadata map[string]string, opts ...s3.CopyOption) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3ReplaceMetadata", time.Now(), &err)
+
+	return svc.next.S3ReplaceMetadataWithContext(ctx, bucketName, objectName, metadata, opts...)
+
+}
+
+// S3Exists calls S3Exists on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3Exists(bucketName, objectName string, opts ...s3.EncryptionOption) (bool, error) {
+	return svc.S3ExistsWithContext(context.Background(), bucketName, objectName, opts...)
+}
+
+// S3ExistsWithContext calls S3ExistsWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3ExistsWithContext(ctx context.Context, bucketName, objectName string, opts ...s3.EncryptionOption) (exists bool, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3Exists", time.Now(), &err)
+
+	return svc.next.S3ExistsWithContext(ctx, bucketName, objectName, opts...)
+
+}
+
+// S3Stat calls S3Stat on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3Stat(bucketName, objectName string, opts ...s3.EncryptionOption) (*s3.ObjectInfo, error) {
+	return svc.S3StatWithContext(context.Background(), bucketName, objectName, opts...)
+}
+
+// S3StatWithContext calls S3StatWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3StatWithContext(ctx context.Context, bucketName, objectName string, opts ...s3.EncryptionOption) (info *s3.ObjectInfo, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3Stat", time.Now(), &err)
+
+	return svc.next.S3StatWithContext(ctx, bucketName, objectName, opts...)
+
+}
+
+// S3GetObjectTags calls S3GetObjectTags on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetObjectTags(bucketName, objectName string) (map[string]string, error) {
+	return svc.S3GetObjectTagsWithContext(context.Background(), bucketName, objectName)
+}
+
+// S3GetObjectTagsWithContext calls S3GetObjectTagsWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetObjectTagsWithContext(ctx context.Context, bucketName, objectName string) (tags map[string]string, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3GetObjectTags", time.Now(), &err)
+
+	return svc.next.S3GetObjectTagsWithContext(ctx, bucketName, objectName)
+
+}
+
+// S3PutObjectTags calls S3PutObjectTags on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutObjectTags(bucketName, objectName string, tags map[string]string) error {
+	return svc.S3PutObjectTagsWithContext(context.Background(), bucketName, objectName, tags)
+}
+
+// S3PutObjectTagsWithContext calls S3PutObjectTagsWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutObjectTagsWithContext(ctx context.Context, bucketName, objectName string, tags map[string]string) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3PutObjectTags", time.Now(), &err)
+
+	return svc.next.S3PutObjectTagsWithContext(ctx, bucketName, objectName, tags)
+
+}
+
+// S3DeleteObjectTags calls S3DeleteObjectTags on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3DeleteObjectTags(bucketName, objectName string) error {
+	return svc.S3DeleteObjectTagsWithContext(context.Background(), bucketName, objectName)
+}
+
+// S3DeleteObjectTagsWithContext calls S3DeleteObjectTagsWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3DeleteObjectTagsWithContext(ctx context.Context, bucketName, objectName string) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3DeleteObjectTags", time.Now(), &err)
+
+	return svc.next.S3DeleteObjectTagsWithContext(ctx, bucketName, objectName)
+
+}
+
+// S3PresignGet calls S3PresignGet on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PresignGet(bucketName, objectName string, expiry time.Duration, opts ...s3.PresignOption) (url string, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3PresignGet", time.Now(), &err)
+
+	return svc.next.S3PresignGet(bucketName, objectName, expiry, opts...)
+
+}
+
+// S3PresignPut calls S3PresignPut on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PresignPut(bucketName, objectName string, expiry time.Duration, opts ...s3.PresignOption) (req *s3.PresignedRequest, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3PresignPut", time.Now(), &err)
+
+	return svc.next.S3PresignPut(bucketName, objectName, expiry, opts...)
+
+}
+
+// S3PresignPost calls S3PresignPost on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PresignPost(bucketName, objectName string, expiry time.Duration, opts ...s3.PresignOption) (post *s3.PresignedPost, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3PresignPost", time.Now(), &err)
+
+	return svc.next.S3PresignPost(bucketName, objectName, expiry, opts...)
+
+}
+
+// S3SyncUp calls S3SyncUp on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3SyncUp(localDir, bucketName, prefix string, opts ...s3.SyncOption) (*s3.SyncReport, error) {
+	return svc.S3SyncUpWithContext(context.Background(), localDir, bucketName, prefix, opts...)
+}
+
+// S3SyncUpWithContext calls S3SyncUpWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3SyncUpWithContext(ctx context.Context, localDir, bucketName, prefix string, opts ...s3.SyncOption) (report *s3.SyncReport, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3SyncUp", time.Now(), &err)
+
+	return svc.next.S3SyncUpWithContext(ctx, localDir, bucketName, prefix, opts...)
+
+}
+
+// S3SyncDown calls S3SyncDown on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3SyncDown(bucketName, prefix, localDir string, opts ...s3.SyncOption) (*s3.SyncReport, error) {
+	return svc.S3SyncDownWithContext(context.Background(), bucketName, prefix, localDir, opts...)
+}
+
+// S3SyncDownWithContext calls S3SyncDownWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3SyncDownWithContext(ctx context.Context, bucketName, prefix, localDir string, opts ...s3.SyncOption) (report *s3.SyncReport, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3SyncDown", time.Now(), &err)
+
+	return svc.next.S3SyncDownWithContext(ctx, bucketName, prefix, localDir, opts...)
+
+}
+
+// S3GetBucketVersioning calls S3GetBucketVersioning on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetBucketVersioning(bucketName string) (s3.VersioningStatus, error) {
+	return svc.S3GetBucketVersioningWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketVersioningWithContext calls S3GetBucketVersioningWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetBucketVersioningWithContext(ctx context.Context, bucketName string) (status s3.VersioningStatus, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3GetBucketVersioning", time.Now(), &err)
+
+	return svc.next.S3GetBucketVersioningWithContext(ctx, bucketName)
+
+}
+
+// S3PutBucketVersioning calls S3PutBucketVersioning on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutBucketVersioning(bucketName string, status s3.VersioningStatus) error {
+	return svc.S3PutBucketVersioningWithContext(context.Background(), bucketName, status)
+}
+
+// S3PutBucketVersioningWithContext calls S3PutBucketVersioningWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutBucketVersioningWithContext(ctx context.Context, bucketName string, status s3.VersioningStatus) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3PutBucketVersioning", time.Now(), &err)
+
+	return svc.next.S3PutBucketVersioningWithContext(ctx, bucketName, status)
+
+}
+
+// S3GetBucketLifecycle calls S3GetBucketLifecycle on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetBucketLifecycle(bucketName string) ([]s3.LifecycleRule, error) {
+	return svc.S3GetBucketLifecycleWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketLifecycleWithContext calls S3GetBucketLifecycleWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetBucketLifecycleWithContext(ctx context.Context, bucketName string) (rules []s3.LifecycleRule, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3GetBucketLifecycle", time.Now(), &err)
+
+	return svc.next.S3GetBucketLifecycleWithContext(ctx, bucketName)
+
+}
+
+// S3PutBucketLifecycle calls S3PutBucketLifecycle on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutBucketLifecycle(bucketName string, rules []s3.LifecycleRule) error {
+	return svc.S3PutBucketLifecycleWithContext(context.Background(), bucketName, rules)
+}
+
+// S3PutBucketLifecycleWithContext calls S3PutBucketLifecycleWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutBucketLifecycleWithContext(ctx context.Context, bucketName string, rules []s3.LifecycleRule) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3PutBucketLifecycle", time.Now(), &err)
+
+	return svc.next.S3PutBucketLifecycleWithContext(ctx, bucketName, rules)
+
+}
+
+// S3GetBucketCORS calls S3GetBucketCORS on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetBucketCORS(bucketName string) ([]s3.CORSRule, error) {
+	return svc.S3GetBucketCORSWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketCORSWithContext calls S3GetBucketCORSWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetBucketCORSWithContext(ctx context.Context, bucketName string) (rules []s3.CORSRule, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3GetBucketCORS", time.Now(), &err)
+
+	return svc.next.S3GetBucketCORSWithContext(ctx, bucketName)
+
+}
+
+// S3PutBucketCORS calls S3PutBucketCORS on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutBucketCORS(bucketName string, rules []s3.CORSRule) error {
+	return svc.S3PutBucketCORSWithContext(context.Background(), bucketName, rules)
+}
+
+// S3PutBucketCORSWithContext calls S3PutBucketCORSWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutBucketCORSWithContext(ctx context.Context, bucketName string, rules []s3.CORSRule) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3PutBucketCORS", time.Now(), &err)
+
+	return svc.next.S3PutBucketCORSWithContext(ctx, bucketName, rules)
+
+}
+
+// S3GetPublicAccessBlock calls S3GetPublicAccessBlock on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetPublicAccessBlock(bucketName string) (*s3.PublicAccessBlock, error) {
+	return svc.S3GetPublicAccessBlockWithContext(context.Background(), bucketName)
+}
+
+// S3GetPublicAccessBlockWithContext calls S3GetPublicAccessBlockWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetPublicAccessBlockWithContext(ctx context.Context, bucketName string) (block *s3.PublicAccessBlock, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3GetPublicAccessBlock", time.Now(), &err)
+
+	return svc.next.S3GetPublicAccessBlockWithContext(ctx, bucketName)
+
+}
+
+// S3PutPublicAccessBlock calls S3PutPublicAccessBlock on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutPublicAccessBlock(bucketName string, block *s3.PublicAccessBlock) error {
+	return svc.S3PutPublicAccessBlockWithContext(context.Background(), bucketName, block)
+}
+
+// S3PutPublicAccessBlockWithContext calls S3PutPublicAccessBlockWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutPublicAccessBlockWithContext(ctx context.Context, bucketName string, block *s3.PublicAccessBlock) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3PutPublicAccessBlock", time.Now(), &err)
+
+	return svc.next.S3PutPublicAccessBlockWithContext(ctx, bucketName, block)
+
+}
+
+// S3GetBucketPolicy calls S3GetBucketPolicy on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetBucketPolicy(bucketName string) (string, error) {
+	return svc.S3GetBucketPolicyWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketPolicyWithContext calls S3GetBucketPolicyWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetBucketPolicyWithContext(ctx context.Context, bucketName string) (policy string, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3GetBucketPolicy", time.Now(), &err)
+
+	return svc.next.S3GetBucketPolicyWithContext(ctx, bucketName)
+
+}
+
+// S3PutBucketPolicy calls S3PutBucketPolicy on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutBucketPolicy(bucketName, policy string) error {
+	return svc.S3PutBucketPolicyWithContext(context.Background(), bucketName, policy)
+}
+
+// S3PutBucketPolicyWithContext calls S3PutBucketPolicyWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutBucketPolicyWithContext(ctx context.Context, bucketName, policy string) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3PutBucketPolicy", time.Now(), &err)
+
+	return svc.next.S3PutBucketPolicyWithContext(ctx, bucketName, policy)
+
+}
+
+// S3GetBucketEncryption calls S3GetBucketEncryption on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetBucketEncryption(bucketName string) (*s3.BucketEncryption, error) {
+	return svc.S3GetBucketEncryptionWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketEncryptionWithContext calls S3GetBucketEncryptionWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3GetBucketEncryptionWithContext(ctx context.Context, bucketName string) (enc *s3.BucketEncryption, err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3GetBucketEncryption", time.Now(), &err)
+
+	return svc.next.S3GetBucketEncryptionWithContext(ctx, bucketName)
+
+}
+
+// S3PutBucketEncryption calls S3PutBucketEncryption on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutBucketEncryption(bucketName string, enc *s3.BucketEncryption) error {
+	return svc.S3PutBucketEncryptionWithContext(context.Background(), bucketName, enc)
+}
+
+// S3PutBucketEncryptionWithContext calls S3PutBucketEncryptionWithContext on the wrapped s3.S3API and records its metrics
+func (svc *S3) S3PutBucketEncryptionWithContext(ctx context.Context, bucketName string, enc *s3.BucketEncryption) (err error) {
+
+	defer svc.collector.observeHelper(s3Service, "S3PutBucketEncryption", time.Now(), &err)
+
+	return svc.next.S3PutBucketEncryptionWithContext(ctx, bucketName, enc)
+
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/mock"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/s3"
+)
+
+func TestS3(t *testing.T) {
+
+	c := New("some_namespace")
+
+	m := &mock.S3{
+		S3PutObjectFunc: func(ctx context.Context, bucketName, objectName, objectPath string, opts ...s3.PutOption) error {
+			return errors.New("some_error")
+		},
+	}
+
+	svc := c.S3(m)
+
+	assert.NoError(t, svc.S3CreateBucket("some_bucket"))
+	assert.Error(t, svc.S3PutObject("some_bucket", "some_key", "some_path"))
+	assert.NoError(t, svc.S3Upload("some_bucket", "some_key", strings.NewReader("some_body")))
+
+	it := svc.S3ListObjects("some_bucket")
+
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+
+	_, err := svc.S3PresignGet("some_bucket", "some_key", time.Minute)
+
+	assert.NoError(t, err)
+
+	_, err = svc.S3SyncUp("some_dir", "some_bucket", "some/")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, len(m.Calls()))
+
+	for _, helper := range []string{"S3CreateBucket", "S3PutObject", "S3Upload", "S3ListObjects", "S3PresignGet", "S3SyncUp"} {
+		assert.Equal(t, float64(1), testutil.ToFloat64(c.helperCalls.WithLabelValues("s3", helper)), helper)
+	}
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.helperErrors.WithLabelValues("s3", "S3PutObject", UnknownErrorCode)))
+	assert.Equal(t, 1, collectAndCount(c.helperErrors))
+
+}
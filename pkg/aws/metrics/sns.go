@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/sns"
+)
+
+// SNS wraps an sns.SNSAPI recording the metrics of every helper call
+type SNS struct {
+	next      sns.SNSAPI
+	collector *Collector
+}
+
+var _ sns.SNSAPI = (*SNS)(nil)
+
+// SNS returns next wrapped in a *SNS
+func (c *Collector) SNS(next sns.SNSAPI) *SNS {
+	return &SNS{
+		next:      next,
+		collector: c,
+	}
+}
+
+// SnsPublish calls SnsPublish on the wrapped sns.SNSAPI and records its metrics
+func (svc *SNS) SnsPublish(input interface{}, messageAttributes map[string]interface{}, targetArn string) error {
+	return svc.SnsPublishWithContext(context.Background(), input, messageAttributes, targetArn)
+}
+
+// SnsPublishWithContext calls SnsPublishWithContext on the wrapped sns.SNSAPI and records its metrics
+func (svc *SNS) SnsPublishWithContext(ctx context.Context, input interface{}, messageAttributes map[string]interface{}, targetArn string) (err error) {
+
+	defer svc.collector.observeHelper(snsService, "SnsPublish", time.Now(), &err)
+
+	return svc.next.SnsPublishWithContext(ctx, input, messageAttributes, targetArn)
+
+}
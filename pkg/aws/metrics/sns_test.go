@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/mock"
+)
+
+func TestSNS(t *testing.T) {
+
+	c := New("some_namespace")
+
+	m := &mock.SNS{}
+
+	svc := c.SNS(m)
+
+	assert.NoError(t, svc.SnsPublish("some_input", nil, "some_arn"))
+
+	m.SnsPublishFunc = func(ctx context.Context, input interface{}, messageAttributes map[string]interface{}, targetArn string) error {
+		return errors.New("some_error")
+	}
+
+	assert.Error(t, svc.SnsPublish("some_input", nil, "some_arn"))
+	assert.Equal(t, 2, len(m.Calls()))
+	assert.Equal(t, float64(2), testutil.ToFloat64(c.helperCalls.WithLabelValues("sns", "SnsPublish")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.helperErrors.WithLabelValues("sns", "SnsPublish", UnknownErrorCode)))
+
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	awsSqs "github.com/aws/aws-sdk-go/service/sqs"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/sqs"
+)
+
+// SQS wraps an sqs.SQSAPI recording the metrics of every helper call
+type SQS struct {
+	next      sqs.SQSAPI
+	collector *Collector
+}
+
+var _ sqs.SQSAPI = (*SQS)(nil)
+
+// SQS returns next wrapped in a *SQS
+func (c *Collector) SQS(next sqs.SQSAPI) *SQS {
+	return &SQS{
+		next:      next,
+		collector: c,
+	}
+}
+
+// SQSCreateQueue calls SQSCreateQueue on the wrapped sqs.SQSAPI and records its metrics
+func (svc *SQS) SQSCreateQueue(queue string) error {
+	return svc.SQSCreateQueueWithContext(context.Background(), queue)
+}
+
+// SQSCreateQueueWithContext calls SQSCreateQueueWithContext on the wrapped sqs.SQSAPI and records its metrics
+func (svc *SQS) SQSCreateQueueWithContext(ctx context.Context, queue string) (err error) {
+
+	defer svc.collector.observeHelper(sqsService, "SQSCreateQueue", time.Now(), &err)
+
+	return svc.next.SQSCreateQueueWithContext(ctx, queue)
+
+}
+
+// SQSGetQueueAttributes calls SQSGetQueueAttributes on the wrapped sqs.SQSAPI and records its metrics
+func (svc *SQS) SQSGetQueueAttributes(queueUrl string) (*awsSqs.GetQueueAttributesOutput, error) {
+	return svc.SQSGetQueueAttributesWithContext(context.Background(), queueUrl)
+}
+
+// SQSGetQueueAttributesWithContext calls SQSGetQueueAttributesWithContext on the wrapped sqs.SQSAPI and records its metrics
+func (svc *SQS) SQSGetQueueAttributesWithContext(ctx context.Context, queueUrl string) (out *awsSqs.GetQueueAttributesOutput, err error) {
+
+	defer svc.collector.observeHelper(sqsService, "SQSGetQueueAttributes", time.Now(), &err)
+
+	return svc.next.SQSGetQueueAttributesWithContext(ctx, queueUrl)
+
+}
+
+// SQSSendMessage calls SQSSendMessage on the wrapped sqs.SQSAPI and records its metrics
+func (svc *SQS) SQSSendMessage(input interface{}, queueName string, base64Encode bool) error {
+	return svc.SQSSendMessageWithContext(context.Background(), input, queueName, base64Encode)
+}
+
+// SQSSendMessageWithContext calls SQSSendMessageWithContext on the wrapped sqs.SQSAPI and records its metrics
+func (svc *SQS) SQSSendMessageWithContext(ctx context.Context, input interface{}, queueName string, base64Encode bool) (err error) {
+
+	defer svc.collector.observeHelper(sqsService, "SQSSendMessage", time.Now(), &err)
+
+	return svc.next.SQSSendMessageWithContext(ctx, input, queueName, base64Encode)
+
+}
+
+// SQSGetQueueUrl calls SQSGetQueueUrl on the wrapped sqs.SQSAPI and records its metrics
+func (svc *SQS) SQSGetQueueUrl(queueName string) (string, error) {
+	return svc.SQSGetQueueUrlWithContext(context.Background(), queueName)
+}
+
+// SQSGetQueueUrlWithContext calls SQSGetQueueUrlWithContext on the wrapped sqs.SQSAPI and records its metrics
+func (svc *SQS) SQSGetQueueUrlWithContext(ctx context.Context, queueName string) (out string, err error) {
+
+	defer svc.collector.observeHelper(sqsService, "SQSGetQueueUrl", time.Now(), &err)
+
+	return svc.next.SQSGetQueueUrlWithContext(ctx, queueName)
+
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/mock"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/sqs"
+)
+
+func TestSQS(t *testing.T) {
+
+	c := New("some_namespace")
+
+	m := &mock.SQS{}
+
+	svc := c.SQS(m)
+
+	assert.NoError(t, svc.SQSCreateQueue("some_queue"))
+
+	_, err := svc.SQSGetQueueAttributes("some_queue_url")
+
+	assert.NoError(t, err)
+	assert.NoError(t, svc.SQSSendMessage("some_input", "some_queue", false))
+
+	_, err = svc.SQSGetQueueUrl("some_queue")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(m.Calls()))
+
+	for _, helper := range []string{"SQSCreateQueue", "SQSGetQueueAttributes", "SQSSendMessage", "SQSGetQueueUrl"} {
+		assert.Equal(t, float64(1), testutil.ToFloat64(c.helperCalls.WithLabelValues("sqs", helper)), helper)
+	}
+
+	assert.Equal(t, 0, collectAndCount(c.helperErrors))
+	assert.Equal(t, 4, collectAndCount(c.helperDuration))
+
+}
+
+func TestSQS_SQSSendMessage(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	c := New("some_namespace")
+
+	in, err := pkgAws.NewSessionInput(
+		fake.Region,
+		pkgAws.WithStaticCredentials(fake.AccessKeyID, fake.SecretAccessKey, ""),
+		pkgAws.WithMiddleware(c.Middleware()),
+	)
+
+	assert.NoError(t, err)
+
+	awsSvc, err := pkgAws.New(in)
+
+	assert.NoError(t, err)
+
+	sqsSvc, err := sqs.New(awsSvc, srv.URL)
+
+	assert.NoError(t, err)
+
+	svc := c.SQS(sqsSvc)
+
+	assert.NoError(t, svc.SQSCreateQueue("some_queue"))
+	assert.NoError(t, svc.SQSSendMessage(map[string]string{"some_key": "some_value"}, "some_queue", false))
+	assert.Error(t, svc.SQSSendMessage(map[string]string{"some_key": "some_value"}, "some_missing_queue", false))
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(c.helperCalls.WithLabelValues("sqs", "SQSSendMessage")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.helperErrors.WithLabelValues("sqs", "SQSSendMessage", "AWS.SimpleQueueService.NonExistentQueue")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(c.calls.WithLabelValues("sqs", "GetQueueUrl")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.calls.WithLabelValues("sqs", "SendMessage")))
+
+}
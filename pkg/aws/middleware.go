@@ -0,0 +1,143 @@
+package aws
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+const (
+	headerMiddlewareName   = "awsb.HeaderMiddleware"
+	completeMiddlewareName = "awsb.CompleteMiddleware"
+)
+
+// Middleware registers cross-cutting behavior on the handlers run by every request
+// of the clients built from a *Session
+type Middleware func(*request.Handlers)
+
+// Call describes a completed aws-sdk-go call, retries included
+type Call struct {
+	Service    string
+	Operation  string
+	RequestID  string
+	StatusCode int
+	Retries    int
+	Duration   time.Duration
+	Err        error
+}
+
+// callLog is the json line written by LoggerMiddleware
+type callLog struct {
+	Time       time.Time `json:"time"`
+	Service    string    `json:"service"`
+	Operation  string    `json:"operation"`
+	RequestID  string    `json:"request_id,omitempty"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Retries    int       `json:"retries"`
+	DurationMs float64   `json:"duration_ms"`
+	ErrorCode  string    `json:"error_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Use registers m on svc. Middlewares only apply to the clients built after Use is called
+func (svc *Session) Use(m ...Middleware) {
+
+	for _, middleware := range m {
+		middleware(&svc.Handlers)
+	}
+
+}
+
+// CompleteMiddleware calls fn once every call completes, either successfully or not.
+// It can be used to capture request ids or to measure latencies
+func CompleteMiddleware(fn func(Call)) Middleware {
+	return func(h *request.Handlers) {
+		h.Complete.PushBackNamed(request.NamedHandler{
+			Name: completeMiddlewareName,
+			Fn: func(r *request.Request) {
+				fn(newCall(r))
+			},
+		})
+	}
+}
+
+// HeaderMiddleware sets the header key to value on every request before it is signed
+func HeaderMiddleware(key, value string) Middleware {
+	return func(h *request.Handlers) {
+		h.Build.PushBackNamed(request.NamedHandler{
+			Name: headerMiddlewareName,
+			Fn: func(r *request.Request) {
+				r.HTTPRequest.Header.Set(key, value)
+			},
+		})
+	}
+}
+
+// LoggerMiddleware writes a json line describing every completed call to w
+func LoggerMiddleware(w io.Writer) Middleware {
+
+	mu := &sync.Mutex{}
+	enc := json.NewEncoder(w)
+
+	return CompleteMiddleware(func(c Call) {
+
+		line := &callLog{
+			Time:       time.Now().UTC(),
+			Service:    c.Service,
+			Operation:  c.Operation,
+			RequestID:  c.RequestID,
+			StatusCode: c.StatusCode,
+			Retries:    c.Retries,
+			DurationMs: float64(c.Duration) / float64(time.Millisecond),
+		}
+
+		if c.Err != nil {
+			line.Error = c.Err.Error()
+			if awsErr, ok := c.Err.(awserr.Error); ok {
+				line.ErrorCode = awsErr.Code()
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		enc.Encode(line)
+
+	})
+
+}
+
+// SlowCallMiddleware calls report for every call taking longer than threshold
+func SlowCallMiddleware(threshold time.Duration, report func(Call)) Middleware {
+	return CompleteMiddleware(func(c Call) {
+		if c.Duration > threshold {
+			report(c)
+		}
+	})
+}
+
+// newCall returns the Call described by a completed request
+func newCall(r *request.Request) Call {
+
+	c := Call{
+		Service:   r.ClientInfo.ServiceName,
+		RequestID: r.RequestID,
+		Retries:   r.RetryCount,
+		Duration:  time.Since(r.Time),
+		Err:       r.Error,
+	}
+
+	if r.Operation != nil {
+		c.Operation = r.Operation.Name
+	}
+	if r.HTTPResponse != nil {
+		c.StatusCode = r.HTTPResponse.StatusCode
+	}
+
+	return c
+
+}
@@ -0,0 +1,138 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	someQueueUrlResponse = `<GetQueueUrlResponse><GetQueueUrlResult><QueueUrl>some_url</QueueUrl></GetQueueUrlResult><ResponseMetadata><RequestId>some_request_id</RequestId></ResponseMetadata></GetQueueUrlResponse>`
+	someErrorResponse    = `<ErrorResponse><Error><Type>Sender</Type><Code>AWS.SimpleQueueService.NonExistentQueue</Code><Message>some_message</Message></Error><RequestId>some_request_id</RequestId></ErrorResponse>`
+)
+
+func TestSession_Use(t *testing.T) {
+
+	var header string
+	status := http.StatusOK
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		header = r.Header.Get("X-Some-Header")
+
+		w.Header().Set("X-Amzn-Requestid", "some_request_id")
+		w.WriteHeader(status)
+		if status == http.StatusOK {
+			w.Write([]byte(someQueueUrlResponse))
+			return
+		}
+		w.Write([]byte(someErrorResponse))
+
+	}))
+	defer srv.Close()
+
+	var calls []Call
+	buf := &bytes.Buffer{}
+
+	in, err := NewSessionInput(
+		"some_region",
+		WithStaticCredentials("some_key", "some_secret", ""),
+		WithMiddleware(
+			HeaderMiddleware("X-Some-Header", "some_value"),
+			CompleteMiddleware(func(c Call) {
+				calls = append(calls, c)
+			}),
+		),
+	)
+
+	assert.NoError(t, err)
+
+	svc, err := New(in)
+
+	assert.NoError(t, err)
+
+	svc.Use(LoggerMiddleware(buf))
+
+	client := sqs.New(svc.ServiceSession(WithEndpoint(srv.URL)))
+
+	_, err = client.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("some_queue")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_value", header)
+	assert.Len(t, calls, 1)
+	assert.Equal(t, sqs.ServiceName, calls[0].Service)
+	assert.Equal(t, "GetQueueUrl", calls[0].Operation)
+	assert.Equal(t, "some_request_id", calls[0].RequestID)
+	assert.Equal(t, http.StatusOK, calls[0].StatusCode)
+	assert.NoError(t, calls[0].Err)
+
+	status = http.StatusBadRequest
+
+	_, err = client.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("some_queue")})
+
+	assert.Error(t, err)
+	assert.Len(t, calls, 2)
+	assert.Equal(t, http.StatusBadRequest, calls[1].StatusCode)
+	assert.Equal(t, "AWS.SimpleQueueService.NonExistentQueue", calls[1].Err.(awserr.Error).Code())
+
+	dec := json.NewDecoder(buf)
+
+	var line callLog
+
+	assert.NoError(t, dec.Decode(&line))
+	assert.Equal(t, "GetQueueUrl", line.Operation)
+	assert.Empty(t, line.ErrorCode)
+
+	assert.NoError(t, dec.Decode(&line))
+	assert.Equal(t, "AWS.SimpleQueueService.NonExistentQueue", line.ErrorCode)
+	assert.Equal(t, http.StatusBadRequest, line.StatusCode)
+
+}
+
+func TestSlowCallMiddleware(t *testing.T) {
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Write([]byte(someQueueUrlResponse))
+	}))
+	defer srv.Close()
+
+	var slow, fast []Call
+
+	in, err := NewSessionInput(
+		"some_region",
+		WithStaticCredentials("some_key", "some_secret", ""),
+		WithMiddleware(
+			SlowCallMiddleware(10*time.Millisecond, func(c Call) {
+				slow = append(slow, c)
+			}),
+			SlowCallMiddleware(time.Minute, func(c Call) {
+				fast = append(fast, c)
+			}),
+		),
+	)
+
+	assert.NoError(t, err)
+
+	svc, err := New(in)
+
+	assert.NoError(t, err)
+
+	client := sqs.New(svc.ServiceSession(WithEndpoint(srv.URL)))
+
+	_, err = client.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("some_queue")})
+
+	assert.NoError(t, err)
+	assert.Len(t, slow, 1)
+	assert.True(t, slow[0].Duration >= 50*time.Millisecond)
+	assert.Empty(t, fast)
+
+}
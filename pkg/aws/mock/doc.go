@@ -0,0 +1,5 @@
+// Package mock contains mock implementations of the helper interfaces exposed by
+// the service packages, so that code depending on them can be unit tested without
+// any endpoint. Every mock records its calls and delegates to an optional function
+// field, returning zero values when that field is nil
+package mock
@@ -0,0 +1,72 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/dynamodb"
+)
+
+// DynamoDB is a mock implementation of dynamodb.DynamoDBAPI
+type DynamoDB struct {
+	Recorder
+
+	DynamoPutItemFunc func(ctx context.Context, input interface{}, table string) error
+	DynamoGetItemFunc func(ctx context.Context, table, keyName, keyValue string) (*dynamodb.GetItemOutput, error)
+	DynamoScanFunc    func(ctx context.Context, table, keyName string, keyValue interface{}) (*dynamodb.ScanOutput, error)
+}
+
+var _ dynamodb.DynamoDBAPI = (*DynamoDB)(nil)
+
+// DynamoPutItem calls DynamoPutItemFunc
+func (m *DynamoDB) DynamoPutItem(input interface{}, table string) error {
+	return m.DynamoPutItemWithContext(context.Background(), input, table)
+}
+
+// DynamoPutItemWithContext calls DynamoPutItemFunc
+func (m *DynamoDB) DynamoPutItemWithContext(ctx context.Context, input interface{}, table string) error {
+
+	m.record("DynamoPutItem", input, table)
+
+	if m.DynamoPutItemFunc == nil {
+		return nil
+	}
+
+	return m.DynamoPutItemFunc(ctx, input, table)
+
+}
+
+// DynamoGetItem calls DynamoGetItemFunc
+func (m *DynamoDB) DynamoGetItem(table, keyName, keyValue string) (*dynamodb.GetItemOutput, error) {
+	return m.DynamoGetItemWithContext(context.Background(), table, keyName, keyValue)
+}
+
+// DynamoGetItemWithContext calls DynamoGetItemFunc
+func (m *DynamoDB) DynamoGetItemWithContext(ctx context.Context, table, keyName, keyValue string) (*dynamodb.GetItemOutput, error) {
+
+	m.record("DynamoGetItem", table, keyName, keyValue)
+
+	if m.DynamoGetItemFunc == nil {
+		return nil, nil
+	}
+
+	return m.DynamoGetItemFunc(ctx, table, keyName, keyValue)
+
+}
+
+// DynamoScan calls DynamoScanFunc
+func (m *DynamoDB) DynamoScan(table, keyName string, keyValue interface{}) (*dynamodb.ScanOutput, error) {
+	return m.DynamoScanWithContext(context.Background(), table, keyName, keyValue)
+}
+
+// DynamoScanWithContext calls DynamoScanFunc
+func (m *DynamoDB) DynamoScanWithContext(ctx context.Context, table, keyName string, keyValue interface{}) (*dynamodb.ScanOutput, error) {
+
+	m.record("DynamoScan", table, keyName, keyValue)
+
+	if m.DynamoScanFunc == nil {
+		return nil, nil
+	}
+
+	return m.DynamoScanFunc(ctx, table, keyName, keyValue)
+
+}
@@ -0,0 +1,40 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/dynamodb"
+)
+
+func TestDynamoDB(t *testing.T) {
+
+	m := &DynamoDB{}
+
+	assert.NoError(t, m.DynamoPutItem("some_item", "some_table"))
+
+	var item dynamodb.GetItemOutput = map[string]interface{}{
+		"some_key": "some_value",
+	}
+
+	m.DynamoGetItemFunc = func(ctx context.Context, table, keyName, keyValue string) (*dynamodb.GetItemOutput, error) {
+		return &item, nil
+	}
+
+	out, err := m.DynamoGetItem("some_table", "some_key", "some_value")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &item, out)
+
+	scanOut, err := m.DynamoScan("some_table", "some_key", "some_value")
+
+	assert.NoError(t, err)
+	assert.Nil(t, scanOut)
+
+	assert.Equal(t, 1, m.CallCount("DynamoPutItem"))
+	assert.Equal(t, 1, m.CallCount("DynamoGetItem"))
+	assert.Equal(t, 1, m.CallCount("DynamoScan"))
+
+}
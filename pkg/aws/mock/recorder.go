@@ -0,0 +1,73 @@
+package mock
+
+import (
+	"sync"
+)
+
+// Call describes a single call made to a mock
+type Call struct {
+	// Method is the name of the called method, without the WithContext suffix
+	Method string
+	// Args are the arguments passed to the method, context excluded
+	Args []interface{}
+}
+
+// Recorder records the calls made to a mock. It is safe for concurrent use
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Calls returns the calls recorded so far
+func (r *Recorder) Calls() []Call {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]Call, len(r.calls))
+	copy(out, r.calls)
+
+	return out
+
+}
+
+// CallCount returns how many times method has been called
+func (r *Recorder) CallCount(method string) int {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+
+	for _, c := range r.calls {
+		if c.Method == method {
+			n++
+		}
+	}
+
+	return n
+
+}
+
+// Reset deletes every recorded call
+func (r *Recorder) Reset() {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+
+}
+
+// record appends a new call
+func (r *Recorder) record(method string, args ...interface{}) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = append(r.calls, Call{
+		Method: method,
+		Args:   args,
+	})
+
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+
+	r := &Recorder{}
+
+	r.record("SomeMethod", "some_arg")
+	r.record("SomeMethod", "other_arg")
+	r.record("OtherMethod")
+
+	assert.Equal(t, 2, r.CallCount("SomeMethod"))
+	assert.Equal(t, 1, r.CallCount("OtherMethod"))
+	assert.Equal(t, 0, r.CallCount("MissingMethod"))
+	assert.Equal(t, Call{Method: "SomeMethod", Args: []interface{}{"other_arg"}}, r.Calls()[1])
+
+	r.Reset()
+
+	assert.Empty(t, r.Calls())
+
+}
@@ -0,0 +1,72 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/rekognition"
+)
+
+// Rekognition is a mock implementation of rekognition.RekognitionAPI
+type Rekognition struct {
+	Recorder
+
+	RekognitionCompareFacesFunc func(ctx context.Context, sourceImage, targetImage []byte, similarity float64) (*rekognition.CompareFacesOutput, error)
+	RekognitionDetectFacesFunc  func(ctx context.Context, sourceImage []byte) (*rekognition.DetectFacesOutput, error)
+	RekognitionDetectTextFunc   func(ctx context.Context, sourceImage []byte) (*rekognition.DetectTextOutput, error)
+}
+
+var _ rekognition.RekognitionAPI = (*Rekognition)(nil)
+
+// RekognitionCompareFaces calls RekognitionCompareFacesFunc
+func (m *Rekognition) RekognitionCompareFaces(sourceImage, targetImage []byte, similarity float64) (*rekognition.CompareFacesOutput, error) {
+	return m.RekognitionCompareFacesWithContext(context.Background(), sourceImage, targetImage, similarity)
+}
+
+// RekognitionCompareFacesWithContext calls RekognitionCompareFacesFunc
+func (m *Rekognition) RekognitionCompareFacesWithContext(ctx context.Context, sourceImage, targetImage []byte, similarity float64) (*rekognition.CompareFacesOutput, error) {
+
+	m.record("RekognitionCompareFaces", sourceImage, targetImage, similarity)
+
+	if m.RekognitionCompareFacesFunc == nil {
+		return nil, nil
+	}
+
+	return m.RekognitionCompareFacesFunc(ctx, sourceImage, targetImage, similarity)
+
+}
+
+// RekognitionDetectFaces calls RekognitionDetectFacesFunc
+func (m *Rekognition) RekognitionDetectFaces(sourceImage []byte) (*rekognition.DetectFacesOutput, error) {
+	return m.RekognitionDetectFacesWithContext(context.Background(), sourceImage)
+}
+
+// RekognitionDetectFacesWithContext calls RekognitionDetectFacesFunc
+func (m *Rekognition) RekognitionDetectFacesWithContext(ctx context.Context, sourceImage []byte) (*rekognition.DetectFacesOutput, error) {
+
+	m.record("RekognitionDetectFaces", sourceImage)
+
+	if m.RekognitionDetectFacesFunc == nil {
+		return nil, nil
+	}
+
+	return m.RekognitionDetectFacesFunc(ctx, sourceImage)
+
+}
+
+// RekognitionDetectText calls RekognitionDetectTextFunc
+func (m *Rekognition) RekognitionDetectText(sourceImage []byte) (*rekognition.DetectTextOutput, error) {
+	return m.RekognitionDetectTextWithContext(context.Background(), sourceImage)
+}
+
+// RekognitionDetectTextWithContext calls RekognitionDetectTextFunc
+func (m *Rekognition) RekognitionDetectTextWithContext(ctx context.Context, sourceImage []byte) (*rekognition.DetectTextOutput, error) {
+
+	m.record("RekognitionDetectText", sourceImage)
+
+	if m.RekognitionDetectTextFunc == nil {
+		return nil, nil
+	}
+
+	return m.RekognitionDetectTextFunc(ctx, sourceImage)
+
+}
@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/rekognition"
+)
+
+func TestRekognition(t *testing.T) {
+
+	m := &Rekognition{
+		RekognitionDetectTextFunc: func(ctx context.Context, sourceImage []byte) (*rekognition.DetectTextOutput, error) {
+			return &rekognition.DetectTextOutput{
+				TextDetections: []rekognition.TextDetection{
+					{DetectedText: "Hello"},
+				},
+			}, nil
+		},
+	}
+
+	out, err := m.RekognitionDetectText([]byte("some_image"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", out.TextDetections[0].DetectedText)
+
+	facesOut, err := m.RekognitionDetectFaces([]byte("some_image"))
+
+	assert.NoError(t, err)
+	assert.Nil(t, facesOut)
+
+	compareOut, err := m.RekognitionCompareFaces([]byte("some_source"), []byte("some_target"), 90)
+
+	assert.NoError(t, err)
+	assert.Nil(t, compareOut)
+
+	assert.Equal(t, 3, len(m.Calls()))
+
+}
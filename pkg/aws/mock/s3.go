@@ -0,0 +1,705 @@
+package mock
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/s3"
+)
+
+// S3 is a mock implementation of s3.S3API
+type S3 struct {
+	Recorder
+
+	S3CreateBucketFunc         func(ctx context.Context, bucketName string, opts ...s3.CreateBucketOption) error
+	S3GetObjectFunc            func(ctx context.Context, bucketName, sourceImage string, opts ...s3.EncryptionOption) ([]byte, error)
+	S3PutObjectFunc            func(ctx context.Context, bucketName, objectName, objectPath string, opts ...s3.PutOption) error
+	S3UploadFunc               func(ctx context.Context, bucketName, objectName string, body io.Reader, opts ...s3.UploadOption) error
+	S3UploadFileFunc           func(ctx context.Context, bucketName, objectName, path string, opts ...s3.UploadOption) error
+	S3UploadReaderAtFunc       func(ctx context.Context, bucketName, objectName string, body io.ReaderAt, size int64, opts ...s3.UploadOption) error
+	S3DownloadFunc             func(ctx context.Context, bucketName, objectName string, w io.Writer, opts ...s3.DownloadOption) (int64, error)
+	S3DownloadAtFunc           func(ctx context.Context, bucketName, objectName string, w io.WriterAt, opts ...s3.DownloadOption) (int64, error)
+	S3ListObjectsFunc          func(ctx context.Context, bucketName string, opts ...s3.ListOption) *s3.ObjectIterator
+	S3DeleteObjectFunc         func(ctx context.Context, bucketName, objectName string, opts ...s3.DeleteOption) error
+	S3DeleteObjectsFunc        func(ctx context.Context, bucketName string, objectNames []string, opts ...s3.DeleteOption) (*s3.DeleteResult, error)
+	S3DeletePrefixFunc         func(ctx context.Context, bucketName, prefix string, opts ...s3.DeleteOption) (*s3.DeleteResult, error)
+	S3CopyFunc                 func(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...s3.CopyOption) error
+	S3MoveFunc                 func(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...s3.CopyOption) error
+	S3ReplaceMetadataFunc      func(ctx context.Context, bucketName, objectName string, metadata map[string]string, opts ...s3.CopyOption) error
+	S3ExistsFunc               func(ctx context.Context, bucketName, objectName string, opts ...s3.EncryptionOption) (bool, error)
+	S3StatFunc                 func(ctx context.Context, bucketName, objectName string, opts ...s3.EncryptionOption) (*s3.ObjectInfo, error)
+	S3GetObjectTagsFunc        func(ctx context.Context, bucketName, objectName string) (map[string]string, error)
+	S3PutObjectTagsFunc        func(ctx context.Context, bucketName, objectName string, tags map[string]string) error
+	S3DeleteObjectTagsFunc     func(ctx context.Context, bucketName, objectName string) error
+	S3PresignGetFunc           func(bucketName, objectName string, expiry time.Duration, opts ...s3.PresignOption) (string, error)
+	S3PresignPutFunc           func(bucketName, objectName string, expiry time.Duration, opts ...s3.PresignOption) (*s3.PresignedRequest, error)
+	S3PresignPostFunc          func(bucketName, objectName string, expiry time.Duration, opts ...s3.PresignOption) (*s3.PresignedPost, error)
+	S3SyncUpFunc               func(ctx context.Context, localDir, bucketName, prefix string, opts ...s3.SyncOption) (*s3.SyncReport, error)
+	S3SyncDownFunc             func(ctx context.Context, bucketName, prefix, localDir string, opts ...s3.SyncOption) (*s3.SyncReport, error)
+	S3GetBucketVersioningFunc  func(ctx context.Context, bucketName string) (s3.VersioningStatus, error)
+	S3PutBucketVersioningFunc  func(ctx context.Context, bucketName string, status s3.VersioningStatus) error
+	S3GetBucketLifecycleFunc   func(ctx context.Context, bucketName string) ([]s3.LifecycleRule, error)
+	S3PutBucketLifecycleFunc   func(ctx context.Context, bucketName string, rules []s3.LifecycleRule) error
+	S3GetBucketCORSFunc        func(ctx context.Context, bucketName string) ([]s3.CORSRule, error)
+	S3PutBucketCORSFunc        func(ctx context.Context, bucketName string, rules []s3.CORSRule) error
+	S3GetPublicAccessBlockFunc func(ctx context.Context, bucketName string) (*s3.PublicAccessBlock, error)
+	S3PutPublicAccessBlockFunc func(ctx context.Context, bucketName string, block *s3.PublicAccessBlock) error
+	S3GetBucketPolicyFunc      func(ctx context.Context, bucketName string) (string, error)
+	S3PutBucketPolicyFunc      func(ctx context.Context, bucketName, policy string) error
+	S3GetBucketEncryptionFunc  func(ctx context.Context, bucketName string) (*s3.BucketEncryption, error)
+	S3PutBucketEncryptionFunc  func(ctx context.Context, bucketName string, enc *s3.BucketEncryption) error
+}
+
+var _ s3.S3API = (*S3)(nil)
+
+// S3CreateBucket calls S3CreateBucketFunc
+func (m *S3) S3CreateBucket(bucketName string, opts ...s3.CreateBucketOption) error {
+	return m.S3CreateBucketWithContext(context.Background(), bucketName, opts...)
+}
+
+// S3CreateBucketWithContext calls S3CreateBucketFunc
+func (m *S3) S3CreateBucketWithContext(ctx context.Context, bucketName string, opts ...s3.CreateBucketOption) error {
+
+	m.record("S3CreateBucket", bucketName)
+
+	if m.S3CreateBucketFunc == nil {
+		return nil
+	}
+
+	return m.S3CreateBucketFunc(ctx, bucketName, opts...)
+
+}
+
+// S3GetObject calls S3GetObjectFunc
+func (m *S3) S3GetObject(bucketName, sourceImage string, opts ...s3.EncryptionOption) ([]byte, error) {
+	return m.S3GetObjectWithContext(context.Background(), bucketName, sourceImage, opts...)
+}
+
+// S3GetObjectWithContext calls S3GetObjectFunc
+func (m *S3) S3GetObjectWithContext(ctx context.Context, bucketName, sourceImage string, opts ...s3.EncryptionOption) ([]byte, error) {
+
+	m.record("S3GetObject", bucketName, sourceImage)
+
+	if m.S3GetObjectFunc == nil {
+		return nil, nil
+	}
+
+	return m.S3GetObjectFunc(ctx, bucketName, sourceImage, opts...)
+
+}
+
+// S3PutObject calls S3PutObjectFunc
+func (m *S3) S3PutObject(bucketName, objectName, objectPath string, opts ...s3.PutOption) error {
+	return m.S3PutObjectWithContext(context.Background(), bucketName, objectName, objectPath, opts...)
+}
+
+// S3PutObjectWithContext calls S3PutObjectFunc
+func (m *S3) S3PutObjectWithContext(ctx context.Context, bucketName, objectName, objectPath string, opts ...s3.PutOption) error {
+
+	m.record("S3PutObject", bucketName, objectName, objectPath)
+
+	if m.S3PutObjectFunc == nil {
+		return nil
+	}
+
+	return m.S3PutObjectFunc(ctx, bucketName, objectName, objectPath, opts...)
+
+}
+
+// S3Upload calls S3UploadFunc
+func (m *S3) S3Upload(bucketName, objectName string, body io.Reader, opts ...s3.UploadOption) error {
+	return m.S3UploadWithContext(context.Background(), bucketName, objectName, body, opts...)
+}
+
+// S3UploadWithContext calls S3UploadFunc
+func (m *S3) S3UploadWithContext(ctx context.Context, bucketName, objectName string, body io.Reader, opts ...s3.UploadOption) error {
+
+	m.record("S3Upload", bucketName, objectName, body)
+
+	if m.S3UploadFunc == nil {
+		return nil
+	}
+
+	return m.S3UploadFunc(ctx, bucketName, objectName, body, opts...)
+
+}
+
+// S3UploadFile calls S3UploadFileFunc
+func (m *S3) S3UploadFile(bucketName, objectName, path string, opts ...s3.UploadOption) error {
+	return m.S3UploadFileWithContext(context.Background(), bucketName, objectName, path, opts...)
+}
+
+// S3UploadFileWithContext calls S3UploadFileFunc
+func (m *S3) S3UploadFileWithContext(ctx context.Context, bucketName, objectName, path string, opts ...s3.UploadOption) error {
+
+	m.record("S3UploadFile", bucketName, objectName, path)
+
+	if m.S3UploadFileFunc == nil {
+		return nil
+	}
+
+	return m.S3UploadFileFunc(ctx, bucketName, objectName, path, opts...)
+
+}
+
+// S3UploadReaderAt calls S3UploadReaderAtFunc
+func (m *S3) S3UploadReaderAt(bucketName, objectName string, body io.ReaderAt, size int64, opts ...s3.UploadOption) error {
+	return m.S3UploadReaderAtWithContext(context.Background(), bucketName, objectName, body, size, opts...)
+}
+
+// S3UploadReaderAtWithContext calls S3UploadReaderAtFunc
+func (m *S3) S3UploadReaderAtWithContext(ctx context.Context, bucketName, objectName string, body io.ReaderAt, size int64, opts ...s3.UploadOption) error {
+
+	m.record("S3UploadReaderAt", bucketName, objectName, body, size)
+
+	if m.S3UploadReaderAtFunc == nil {
+		return nil
+	}
+
+	return m.S3UploadReaderAtFunc(ctx, bucketName, objectName, body, size, opts...)
+
+}
+
+// S3Download calls S3DownloadFunc
+func (m *S3) S3Download(bucketName, objectName string, w io.Writer, opts ...s3.DownloadOption) (int64, error) {
+	return m.S3DownloadWithContext(context.Background(), bucketName, objectName, w, opts...)
+}
+
+// S3DownloadWithContext calls S3DownloadFunc
+func (m *S3) S3DownloadWithContext(ctx context.Context, bucketName, objectName string, w io.Writer, opts ...s3.DownloadOption) (int64, error) {
+
+	m.record("S3Download", bucketName, objectName, w)
+
+	if m.S3DownloadFunc == nil {
+		return 0, nil
+	}
+
+	return m.S3DownloadFunc(ctx, bucketName, objectName, w, opts...)
+
+}
+
+// S3DownloadAt calls S3DownloadAtFunc
+func (m *S3) S3DownloadAt(bucketName, objectName string, w io.WriterAt, opts ...s3.DownloadOption) (int64, error) {
+	return m.S3DownloadAtWithContext(context.Background(), bucketName, objectName, w, opts...)
+}
+
+// S3DownloadAtWithContext calls S3DownloadAtFunc
+func (m *S3) S3DownloadAtWithContext(ctx context.Context, bucketName, objectName string, w io.WriterAt, opts ...s3.DownloadOption) (int64, error) {
+
+	m.record("S3DownloadAt", bucketName, objectName, w)
+
+	if m.S3DownloadAtFunc == nil {
+		return 0, nil
+	}
+
+	return m.S3DownloadAtFunc(ctx, bucketName, objectName, w, opts...)
+
+}
+
+// S3ListObjects calls S3ListObjectsFunc
+func (m *S3) S3ListObjects(bucketName string, opts ...s3.ListOption) *s3.ObjectIterator {
+	return m.S3ListObjectsWithContext(context.Background(), bucketName, opts...)
+}
+
+// S3ListObjectsWithContext calls S3ListObjectsFunc, returning an empty iterator when not set
+func (m *S3) S3ListObjectsWithContext(ctx context.Context, bucketName string, opts ...s3.ListOption) *s3.ObjectIterator {
+
+	m.record("S3ListObjects", bucketName)
+
+	if m.S3ListObjectsFunc == nil {
+		return s3.NewObjectIterator(nil, nil)
+	}
+
+	return m.S3ListObjectsFunc(ctx, bucketName, opts...)
+
+}
+
+// S3DeleteObject calls S3DeleteObjectFunc
+func (m *S3) S3DeleteObject(bucketName, objectName string, opts ...s3.DeleteOption) error {
+	return m.S3DeleteObjectWithContext(context.Background(), bucketName, objectName, opts...)
+}
+
+// S3DeleteObjectWithContext calls S3DeleteObjectFunc
+func (m *S3) S3DeleteObjectWithContext(ctx context.Context, bucketName, objectName string, opts ...s3.DeleteOption) error {
+
+	m.record("S3DeleteObject", bucketName, objectName)
+
+	if m.S3DeleteObjectFunc == nil {
+		return nil
+	}
+
+	return m.S3DeleteObjectFunc(ctx, bucketName, objectName, opts...)
+
+}
+
+// S3DeleteObjects calls S3DeleteObjectsFunc
+func (m *S3) S3DeleteObjects(bucketName string, objectNames []string, opts ...s3.DeleteOption) (*s3.DeleteResult, error) {
+	return m.S3DeleteObjectsWithContext(context.Background(), bucketName, objectNames, opts...)
+}
+
+// S3DeleteObjectsWithContext calls S3DeleteObjectsFunc
+func (m *S3) S3DeleteObjectsWithContext(ctx context.Context, bucketName string, objectNames []string, opts ...s3.DeleteOption) (*s3.DeleteResult, error) {
+
+	m.record("S3DeleteObjects", bucketName, objectNames)
+
+	if m.S3DeleteObjectsFunc == nil {
+		return &s3.DeleteResult{}, nil
+	}
+
+	return m.S3DeleteObjectsFunc(ctx, bucketName, objectNames, opts...)
+
+}
+
+// S3DeletePrefix calls S3DeletePrefixFunc
+func (m *S3) S3DeletePrefix(bucketName, prefix string, opts ...s3.DeleteOption) (*s3.DeleteResult, error) {
+	return m.S3DeletePrefixWithContext(context.Background(), bucketName, prefix, opts...)
+}
+
+// S3DeletePrefixWithContext calls S3DeletePrefixFunc
+func (m *S3) S3DeletePrefixWithContext(ctx context.Context, bucketName, prefix string, opts ...s3.DeleteOption) (*s3.DeleteResult, error) {
+
+	m.record("S3DeletePrefix", bucketName, prefix)
+
+	if m.S3DeletePrefixFunc == nil {
+		return &s3.DeleteResult{}, nil
+	}
+
+	return m.S3DeletePrefixFunc(ctx, bucketName, prefix, opts...)
+
+}
+
+// S3Copy calls S3CopyFunc
+func (m *S3) S3Copy(srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...s3.CopyOption) error {
+	return m.S3CopyWithContext(context.Background(), srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts...)
+}
+
+// S3CopyWithContext calls S3CopyFunc
+func (m *S3) S3CopyWithContext(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...s3.CopyOption) error {
+
+	m.record("S3Copy", srcBucketName, srcObjectName, dstBucketName, dstObjectName)
+
+	if m.S3CopyFunc == nil {
+		return nil
+	}
+
+	return m.S3CopyFunc(ctx, srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts...)
+
+}
+
+// S3Move calls S3MoveFunc
+func (m *S3) S3Move(srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...s3.CopyOption) error {
+	return m.S3MoveWithContext(context.Background(), srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts...)
+}
+
+// S3MoveWithContext calls S3MoveFunc
+func (m *S3) S3MoveWithContext(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...s3.CopyOption) error {
+
+	m.record("S3Move", srcBucketName, srcObjectName, dstBucketName, dstObjectName)
+
+	if m.S3MoveFunc == nil {
+		return nil
+	}
+
+	return m.S3MoveFunc(ctx, srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts...)
+
+}
+
+// S3ReplaceMetadata calls S3ReplaceMetadataFunc
+func (m *S3) S3ReplaceMetadata(bucketName, objectName string, metadata map[string]string, opts ...s3.CopyOption) error {
+	return m.S3ReplaceMetadataWithContext(context.Background(), bucketName, objectName, metadata, opts...)
+}
+
+// S3ReplaceMetadataWithContext calls S3ReplaceMetadataFunc
+func (m *S3) S3ReplaceMetadataWithContext(ctx context.Context, bucketName, objectName string, metadata map[string]string, opts ...s3.CopyOption) error {
+
+	m.record("S3ReplaceMetadata", bucketName, objectName, metadata)
+
+	if m.S3ReplaceMetadataFunc == nil {
+		return nil
+	}
+
+	return m.S3ReplaceMetadataFunc(ctx, bucketName, objectName, metadata, opts...)
+
+}
+
+// S3Exists calls S3ExistsFunc
+func (m *S3) S3Exists(bucketName, objectName string, opts ...s3.EncryptionOption) (bool, error) {
+	return m.S3ExistsWithContext(context.Background(), bucketName, objectName, opts...)
+}
+
+// S3ExistsWithContext calls S3ExistsFunc
+func (m *S3) S3ExistsWithContext(ctx context.Context, bucketName, objectName string, opts ...s3.EncryptionOption) (bool, error) {
+
+	m.record("S3Exists", bucketName, objectName)
+
+	if m.S3ExistsFunc == nil {
+		return false, nil
+	}
+
+	return m.S3ExistsFunc(ctx, bucketName, objectName, opts...)
+
+}
+
+// S3Stat calls S3StatFunc
+func (m *S3) S3Stat(bucketName, objectName string, opts ...s3.EncryptionOption) (*s3.ObjectInfo, error) {
+	return m.S3StatWithContext(context.Background(), bucketName, objectName, opts...)
+}
+
+// S3StatWithContext calls S3StatFunc
+func (m *S3) S3StatWithContext(ctx context.Context, bucketName, objectName string, opts ...s3.EncryptionOption) (*s3.ObjectInfo, error) {
+
+	m.record("S3Stat", bucketName, objectName)
+
+	if m.S3StatFunc == nil {
+		return &s3.ObjectInfo{}, nil
+	}
+
+	return m.S3StatFunc(ctx, bucketName, objectName, opts...)
+
+}
+
+// S3GetObjectTags calls S3GetObjectTagsFunc
+func (m *S3) S3GetObjectTags(bucketName, objectName string) (map[string]string, error) {
+	return m.S3GetObjectTagsWithContext(context.Background(), bucketName, objectName)
+}
+
+// S3GetObjectTagsWithContext calls S3GetObjectTagsFunc
+func (m *S3) S3GetObjectTagsWithContext(ctx context.Context, bucketName, objectName string) (map[string]string, error) {
+
+	m.record("S3GetObjectTags", bucketName, objectName)
+
+	if m.S3GetObjectTagsFunc == nil {
+		return map[string]string{}, nil
+	}
+
+	return m.S3GetObjectTagsFunc(ctx, bucketName, objectName)
+
+}
+
+// S3PutObjectTags calls S3PutObjectTagsFunc
+func (m *S3) S3PutObjectTags(bucketName, objectName string, tags map[string]string) error {
+	return m.S3PutObjectTagsWithContext(context.Background(), bucketName, objectName, tags)
+}
+
+// S3PutObjectTagsWithContext calls S3PutObjectTagsFunc
+func (m *S3) S3PutObjectTagsWithContext(ctx context.Context, bucketName, objectName string, tags map[string]string) error {
+
+	m.record("S3PutObjectTags", bucketName, objectName, tags)
+
+	if m.S3PutObjectTagsFunc == nil {
+		return nil
+	}
+
+	return m.S3PutObjectTagsFunc(ctx, bucketName, objectName, tags)
+
+}
+
+// S3DeleteObjectTags calls S3DeleteObjectTagsFunc
+func (m *S3) S3DeleteObjectTags(bucketName, objectName string) error {
+	return m.S3DeleteObjectTagsWithContext(context.Background(), bucketName, objectName)
+}
+
+// S3DeleteObjectTagsWithContext calls S3DeleteObjectTagsFunc
+func (m *S3) S3DeleteObjectTagsWithContext(ctx context.Context, bucketName, objectName string) error {
+
+	m.record("S3DeleteObjectTags", bucketName, objectName)
+
+	if m.S3DeleteObjectTagsFunc == nil {
+		return nil
+	}
+
+	return m.S3DeleteObjectTagsFunc(ctx, bucketName, objectName)
+
+}
+
+// S3PresignGet calls S3PresignGetFunc
+func (m *S3) S3PresignGet(bucketName, objectName string, expiry time.Duration, opts ...s3.PresignOption) (string, error) {
+
+	m.record("S3PresignGet", bucketName, objectName, expiry)
+
+	if m.S3PresignGetFunc == nil {
+		return "", nil
+	}
+
+	return m.S3PresignGetFunc(bucketName, objectName, expiry, opts...)
+
+}
+
+// S3PresignPut calls S3PresignPutFunc
+func (m *S3) S3PresignPut(bucketName, objectName string, expiry time.Duration, opts ...s3.PresignOption) (*s3.PresignedRequest, error) {
+
+	m.record("S3PresignPut", bucketName, objectName, expiry)
+
+	if m.S3PresignPutFunc == nil {
+		return &s3.PresignedRequest{}, nil
+	}
+
+	return m.S3PresignPutFunc(bucketName, objectName, expiry, opts...)
+
+}
+
+// S3PresignPost calls S3PresignPostFunc
+func (m *S3) S3PresignPost(bucketName, objectName string, expiry time.Duration, opts ...s3.PresignOption) (*s3.PresignedPost, error) {
+
+	m.record("S3PresignPost", bucketName, objectName, expiry)
+
+	if m.S3PresignPostFunc == nil {
+		return &s3.PresignedPost{}, nil
+	}
+
+	return m.S3PresignPostFunc(bucketName, objectName, expiry, opts...)
+
+}
+
+// S3SyncUp calls S3SyncUpFunc
+func (m *S3) S3SyncUp(localDir, bucketName, prefix string, opts ...s3.SyncOption) (*s3.SyncReport, error) {
+	return m.S3SyncUpWithContext(context.Background(), localDir, bucketName, prefix, opts...)
+}
+
+// S3SyncUpWithContext calls S3SyncUpFunc
+func (m *S3) S3SyncUpWithContext(ctx context.Context, localDir, bucketName, prefix string, opts ...s3.SyncOption) (*s3.SyncReport, error) {
+
+	m.record("S3SyncUp", localDir, bucketName, prefix)
+
+	if m.S3SyncUpFunc == nil {
+		return &s3.SyncReport{}, nil
+	}
+
+	return m.S3SyncUpFunc(ctx, localDir, bucketName, prefix, opts...)
+
+}
+
+// S3SyncDown calls S3SyncDownFunc
+func (m *S3) S3SyncDown(bucketName, prefix, localDir string, opts ...s3.SyncOption) (*s3.SyncReport, error) {
+	return m.S3SyncDownWithContext(context.Background(), bucketName, prefix, localDir, opts...)
+}
+
+// S3SyncDownWithContext calls S3SyncDownFunc
+func (m *S3) S3SyncDownWithContext(ctx context.Context, bucketName, prefix, localDir string, opts ...s3.SyncOption) (*s3.SyncReport, error) {
+
+	m.record("S3SyncDown", bucketName, prefix, localDir)
+
+	if m.S3SyncDownFunc == nil {
+		return &s3.SyncReport{}, nil
+	}
+
+	return m.S3SyncDownFunc(ctx, bucketName, prefix, localDir, opts...)
+
+}
+
+// S3GetBucketVersioning calls S3GetBucketVersioningFunc
+func (m *S3) S3GetBucketVersioning(bucketName string) (s3.VersioningStatus, error) {
+	return m.S3GetBucketVersioningWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketVersioningWithContext calls S3GetBucketVersioningFunc
+func (m *S3) S3GetBucketVersioningWithContext(ctx context.Context, bucketName string) (s3.VersioningStatus, error) {
+
+	m.record("S3GetBucketVersioning", bucketName)
+
+	if m.S3GetBucketVersioningFunc == nil {
+		return "", nil
+	}
+
+	return m.S3GetBucketVersioningFunc(ctx, bucketName)
+
+}
+
+// S3PutBucketVersioning calls S3PutBucketVersioningFunc
+func (m *S3) S3PutBucketVersioning(bucketName string, status s3.VersioningStatus) error {
+	return m.S3PutBucketVersioningWithContext(context.Background(), bucketName, status)
+}
+
+// S3PutBucketVersioningWithContext calls S3PutBucketVersioningFunc
+func (m *S3) S3PutBucketVersioningWithContext(ctx context.Context, bucketName string, status s3.VersioningStatus) error {
+
+	m.record("S3PutBucketVersioning", bucketName, status)
+
+	if m.S3PutBucketVersioningFunc == nil {
+		return nil
+	}
+
+	return m.S3PutBucketVersioningFunc(ctx, bucketName, status)
+
+}
+
+// S3GetBucketLifecycle calls S3GetBucketLifecycleFunc
+func (m *S3) S3GetBucketLifecycle(bucketName string) ([]s3.LifecycleRule, error) {
+	return m.S3GetBucketLifecycleWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketLifecycleWithContext calls S3GetBucketLifecycleFunc
+func (m *S3) S3GetBucketLifecycleWithContext(ctx context.Context, bucketName string) ([]s3.LifecycleRule, error) {
+
+	m.record("S3GetBucketLifecycle", bucketName)
+
+	if m.S3GetBucketLifecycleFunc == nil {
+		return nil, nil
+	}
+
+	return m.S3GetBucketLifecycleFunc(ctx, bucketName)
+
+}
+
+// S3PutBucketLifecycle calls S3PutBucketLifecycleFunc
+func (m *S3) S3PutBucketLifecycle(bucketName string, rules []s3.LifecycleRule) error {
+	return m.S3PutBucketLifecycleWithContext(context.Background(), bucketName, rules)
+}
+
+// S3PutBucketLifecycleWithContext calls S3PutBucketLifecycleFunc
+func (m *S3) S3PutBucketLifecycleWithContext(ctx context.Context, bucketName string, rules []s3.LifecycleRule) error {
+
+	m.record("S3PutBucketLifecycle", bucketName, rules)
+
+	if m.S3PutBucketLifecycleFunc == nil {
+		return nil
+	}
+
+	return m.S3PutBucketLifecycleFunc(ctx, bucketName, rules)
+
+}
+
+// S3GetBucketCORS calls S3GetBucketCORSFunc
+func (m *S3) S3GetBucketCORS(bucketName string) ([]s3.CORSRule, error) {
+	return m.S3GetBucketCORSWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketCORSWithContext calls S3GetBucketCORSFunc
+func (m *S3) S3GetBucketCORSWithContext(ctx context.Context, bucketName string) ([]s3.CORSRule, error) {
+
+	m.record("S3GetBucketCORS", bucketName)
+
+	if m.S3GetBucketCORSFunc == nil {
+		return nil, nil
+	}
+
+	return m.S3GetBucketCORSFunc(ctx, bucketName)
+
+}
+
+// S3PutBucketCORS calls S3PutBucketCORSFunc
+func (m *S3) S3PutBucketCORS(bucketName string, rules []s3.CORSRule) error {
+	return m.S3PutBucketCORSWithContext(context.Background(), bucketName, rules)
+}
+
+// S3PutBucketCORSWithContext calls S3PutBucketCORSFunc
+func (m *S3) S3PutBucketCORSWithContext(ctx context.Context, bucketName string, rules []s3.CORSRule) error {
+
+	m.record("S3PutBucketCORS", bucketName, rules)
+
+	if m.S3PutBucketCORSFunc == nil {
+		return nil
+	}
+
+	return m.S3PutBucketCORSFunc(ctx, bucketName, rules)
+
+}
+
+// S3GetPublicAccessBlock calls S3GetPublicAccessBlockFunc
+func (m *S3) S3GetPublicAccessBlock(bucketName string) (*s3.PublicAccessBlock, error) {
+	return m.S3GetPublicAccessBlockWithContext(context.Background(), bucketName)
+}
+
+// S3GetPublicAccessBlockWithContext calls S3GetPublicAccessBlockFunc
+func (m *S3) S3GetPublicAccessBlockWithContext(ctx context.Context, bucketName string) (*s3.PublicAccessBlock, error) {
+
+	m.record("S3GetPublicAccessBlock", bucketName)
+
+	if m.S3GetPublicAccessBlockFunc == nil {
+		return nil, nil
+	}
+
+	return m.S3GetPublicAccessBlockFunc(ctx, bucketName)
+
+}
+
+// S3PutPublicAccessBlock calls S3PutPublicAccessBlockFunc
+func (m *S3) S3PutPublicAccessBlock(bucketName string, block *s3.PublicAccessBlock) error {
+	return m.S3PutPublicAccessBlockWithContext(context.Background(), bucketName, block)
+}
+
+// S3PutPublicAccessBlockWithContext calls S3PutPublicAccessBlockFunc
+func (m *S3) S3PutPublicAccessBlockWithContext(ctx context.Context, bucketName string, block *s3.PublicAccessBlock) error {
+
+	m.record("S3PutPublicAccessBlock", bucketName, block)
+
+	if m.S3PutPublicAccessBlockFunc == nil {
+		return nil
+	}
+
+	return m.S3PutPublicAccessBlockFunc(ctx, bucketName, block)
+
+}
+
+// S3GetBucketPolicy calls S3GetBucketPolicyFunc
+func (m *S3) S3GetBucketPolicy(bucketName string) (string, error) {
+	return m.S3GetBucketPolicyWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketPolicyWithContext calls S3GetBucketPolicyFunc
+func (m *S3) S3GetBucketPolicyWithContext(ctx context.Context, bucketName string) (string, error) {
+
+	m.record("S3GetBucketPolicy", bucketName)
+
+	if m.S3GetBucketPolicyFunc == nil {
+		return "", nil
+	}
+
+	return m.S3GetBucketPolicyFunc(ctx, bucketName)
+
+}
+
+// S3PutBucketPolicy calls S3PutBucketPolicyFunc
+func (m *S3) S3PutBucketPolicy(bucketName, policy string) error {
+	return m.S3PutBucketPolicyWithContext(context.Background(), bucketName, policy)
+}
+
+// S3PutBucketPolicyWithContext calls S3PutBucketPolicyFunc
+func (m *S3) S3PutBucketPolicyWithContext(ctx context.Context, bucketName, policy string) error {
+
+	m.record("S3PutBucketPolicy", bucketName, policy)
+
+	if m.S3PutBucketPolicyFunc == nil {
+		return nil
+	}
+
+	return m.S3PutBucketPolicyFunc(ctx, bucketName, policy)
+
+}
+
+// S3GetBucketEncryption calls S3GetBucketEncryptionFunc
+func (m *S3) S3GetBucketEncryption(bucketName string) (*s3.BucketEncryption, error) {
+	return m.S3GetBucketEncryptionWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketEncryptionWithContext calls S3GetBucketEncryptionFunc
+func (m *S3) S3GetBucketEncryptionWithContext(ctx context.Context, bucketName string) (*s3.BucketEncryption, error) {
+
+	m.record("S3GetBucketEncryption", bucketName)
+
+	if m.S3GetBucketEncryptionFunc == nil {
+		return nil, nil
+	}
+
+	return m.S3GetBucketEncryptionFunc(ctx, bucketName)
+
+}
+
+// S3PutBucketEncryption calls S3PutBucketEncryptionFunc
+func (m *S3) S3PutBucketEncryption(bucketName string, enc *s3.BucketEncryption) error {
+	return m.S3PutBucketEncryptionWithContext(context.Background(), bucketName, enc)
+}
+
+// S3PutBucketEncryptionWithContext calls S3PutBucketEncryptionFunc
+func (m *S3) S3PutBucketEncryptionWithContext(ctx context.Context, bucketName string, enc *s3.BucketEncryption) error {
+
+	m.record("S3PutBucketEncryption", bucketName, enc)
+
+	if m.S3PutBucketEncryptionFunc == nil {
+		return nil
+	}
+
+	return m.S3PutBucketEncryptionFunc(ctx, bucketName, enc)
+
+}
@@ -0,0 +1,196 @@
+package mock
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/s3"
+)
+
+func TestS3(t *testing.T) {
+
+	m := &S3{}
+
+	assert.NoError(t, m.S3PutObject("some_bucket", "some_object", "some_path"))
+
+	out, err := m.S3GetObject("some_bucket", "some_object")
+
+	assert.NoError(t, err)
+	assert.Nil(t, out)
+
+	m.S3GetObjectFunc = func(ctx context.Context, bucketName, sourceImage string, opts ...s3.EncryptionOption) ([]byte, error) {
+		return []byte(sourceImage), nil
+	}
+	m.S3CreateBucketFunc = func(ctx context.Context, bucketName string, opts ...s3.CreateBucketOption) error {
+		return errors.New("some_error")
+	}
+
+	out, err = m.S3GetObject("some_bucket", "some_object")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("some_object"), out)
+	assert.Error(t, m.S3CreateBucket("some_bucket"))
+
+	m.S3UploadFunc = func(ctx context.Context, bucketName, objectName string, body io.Reader, opts ...s3.UploadOption) error {
+		return errors.New("some_error")
+	}
+
+	assert.Error(t, m.S3Upload("some_bucket", "some_object", strings.NewReader("some_body"), s3.WithContentType("text/plain")))
+
+	assert.Equal(t, 2, m.CallCount("S3GetObject"))
+	assert.Equal(t, 1, m.CallCount("S3Upload"))
+
+	assert.NoError(t, m.S3UploadFile("some_bucket", "some_object", "some_path"))
+	assert.NoError(t, m.S3UploadReaderAt("some_bucket", "some_object", strings.NewReader("some_body"), 9))
+	assert.Equal(t, []interface{}{"some_bucket", "some_object", "some_path"}, m.Calls()[len(m.Calls())-2].Args)
+
+	m.S3DownloadFunc = func(ctx context.Context, bucketName, objectName string, w io.Writer, opts ...s3.DownloadOption) (int64, error) {
+		return io.Copy(w, strings.NewReader(objectName))
+	}
+
+	buf := &bytes.Buffer{}
+
+	n, err := m.S3Download("some_bucket", "some_object", buf, s3.WithRange(0, 4))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(11), n)
+	assert.Equal(t, "some_object", buf.String())
+
+	n, err = m.S3DownloadAt("some_bucket", "some_object", nil)
+
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Equal(t, 1, m.CallCount("S3DownloadAt"))
+
+	it := m.S3ListObjects("some_bucket")
+
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+
+	m.S3ListObjectsFunc = func(ctx context.Context, bucketName string, opts ...s3.ListOption) *s3.ObjectIterator {
+		return s3.NewObjectIterator([]s3.Entry{{Key: "some/key"}, {Key: "some/dir/", IsPrefix: true}}, nil)
+	}
+
+	it = m.S3ListObjects("some_bucket", s3.WithPrefix("some/"))
+
+	assert.True(t, it.Next())
+	assert.Equal(t, "some/key", it.Entry().Key)
+	assert.True(t, it.Next())
+	assert.True(t, it.Entry().IsPrefix)
+	assert.False(t, it.Next())
+	assert.Equal(t, 2, m.CallCount("S3ListObjects"))
+
+	assert.NoError(t, m.S3DeleteObject("some_bucket", "some_object", s3.WithAllVersions()))
+
+	result, err := m.S3DeletePrefix("some_bucket", "some/")
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Deleted)
+
+	m.S3DeleteObjectsFunc = func(ctx context.Context, bucketName string, objectNames []string, opts ...s3.DeleteOption) (*s3.DeleteResult, error) {
+		return &s3.DeleteResult{Deleted: []s3.DeletedObject{{Key: objectNames[0]}}}, nil
+	}
+
+	result, err = m.S3DeleteObjects("some_bucket", []string{"some_object"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_object", result.Deleted[0].Key)
+	assert.Equal(t, []interface{}{"some_bucket", []string{"some_object"}}, m.Calls()[len(m.Calls())-1].Args)
+
+	m.S3MoveFunc = func(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...s3.CopyOption) error {
+		return errors.New("some_error")
+	}
+
+	assert.NoError(t, m.S3Copy("some_bucket", "some_object", "other_bucket", "other_object", s3.WithReplaceTags(nil)))
+	assert.Error(t, m.S3Move("some_bucket", "some_object", "other_bucket", "other_object"))
+	assert.Equal(t, []interface{}{"some_bucket", "some_object", "other_bucket", "other_object"}, m.Calls()[len(m.Calls())-1].Args)
+
+	assert.NoError(t, m.S3ReplaceMetadata("some_bucket", "some_object", map[string]string{"some": "metadata"}))
+	assert.Equal(t, []interface{}{"some_bucket", "some_object", map[string]string{"some": "metadata"}}, m.Calls()[len(m.Calls())-1].Args)
+
+	exists, err := m.S3Exists("some_bucket", "some_object")
+
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	m.S3StatFunc = func(ctx context.Context, bucketName, objectName string, opts ...s3.EncryptionOption) (*s3.ObjectInfo, error) {
+		return &s3.ObjectInfo{Size: 9}, nil
+	}
+
+	info, err := m.S3Stat("some_bucket", "some_object")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), info.Size)
+
+	tags, err := m.S3GetObjectTags("some_bucket", "some_object")
+
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	m.S3DeleteObjectTagsFunc = func(ctx context.Context, bucketName, objectName string) error {
+		return errors.New("some_error")
+	}
+
+	assert.NoError(t, m.S3PutObjectTags("some_bucket", "some_object", map[string]string{"some": "tag"}))
+	assert.Error(t, m.S3DeleteObjectTags("some_bucket", "some_object"))
+	assert.Equal(t, 1, m.CallCount("S3PutObjectTags"))
+
+	m.S3PresignGetFunc = func(bucketName, objectName string, expiry time.Duration, opts ...s3.PresignOption) (string, error) {
+		return "some_url", nil
+	}
+
+	url, err := m.S3PresignGet("some_bucket", "some_object", time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_url", url)
+	assert.Equal(t, []interface{}{"some_bucket", "some_object", time.Minute}, m.Calls()[len(m.Calls())-1].Args)
+
+	post, err := m.S3PresignPost("some_bucket", "some_object", time.Minute)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, post)
+
+	report, err := m.S3SyncUp("some_dir", "some_bucket", "some/prefix")
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.Files)
+
+	m.S3SyncDownFunc = func(ctx context.Context, bucketName, prefix, localDir string, opts ...s3.SyncOption) (*s3.SyncReport, error) {
+		return nil, errors.New("some_error")
+	}
+
+	_, err = m.S3SyncDown("some_bucket", "some/prefix", "some_dir")
+
+	assert.Error(t, err)
+	assert.Equal(t, []interface{}{"some_bucket", "some/prefix", "some_dir"}, m.Calls()[len(m.Calls())-1].Args)
+
+	status, err := m.S3GetBucketVersioning("some_bucket")
+
+	assert.NoError(t, err)
+	assert.Equal(t, s3.VersioningUnversioned, status)
+
+	m.S3GetBucketEncryptionFunc = func(ctx context.Context, bucketName string) (*s3.BucketEncryption, error) {
+		return &s3.BucketEncryption{Algorithm: "AES256"}, nil
+	}
+
+	enc, err := m.S3GetBucketEncryption("some_bucket")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "AES256", enc.Algorithm)
+
+	rules := []s3.CORSRule{{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"GET"}}}
+
+	assert.NoError(t, m.S3PutBucketCORS("some_bucket", rules))
+	assert.Equal(t, []interface{}{"some_bucket", rules}, m.Calls()[len(m.Calls())-1].Args)
+	assert.NoError(t, m.S3PutBucketPolicy("some_bucket", ""))
+	assert.Equal(t, 1, m.CallCount("S3PutBucketPolicy"))
+	assert.Equal(t, []interface{}{"some_bucket", "some_object", "some_path"}, m.Calls()[0].Args)
+
+}
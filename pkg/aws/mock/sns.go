@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/sns"
+)
+
+// SNS is a mock implementation of sns.SNSAPI
+type SNS struct {
+	Recorder
+
+	SnsPublishFunc func(ctx context.Context, input interface{}, messageAttributes map[string]interface{}, targetArn string) error
+}
+
+var _ sns.SNSAPI = (*SNS)(nil)
+
+// SnsPublish calls SnsPublishFunc
+func (m *SNS) SnsPublish(input interface{}, messageAttributes map[string]interface{}, targetArn string) error {
+	return m.SnsPublishWithContext(context.Background(), input, messageAttributes, targetArn)
+}
+
+// SnsPublishWithContext calls SnsPublishFunc
+func (m *SNS) SnsPublishWithContext(ctx context.Context, input interface{}, messageAttributes map[string]interface{}, targetArn string) error {
+
+	m.record("SnsPublish", input, messageAttributes, targetArn)
+
+	if m.SnsPublishFunc == nil {
+		return nil
+	}
+
+	return m.SnsPublishFunc(ctx, input, messageAttributes, targetArn)
+
+}
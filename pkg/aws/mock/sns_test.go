@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+)
+
+func TestSNS(t *testing.T) {
+
+	m := &SNS{}
+
+	assert.NoError(t, m.SnsPublish("some_message", nil, "some_arn"))
+
+	m.SnsPublishFunc = func(ctx context.Context, input interface{}, messageAttributes map[string]interface{}, targetArn string) error {
+		return pkgAws.NewAWSError("NotFound", "some message", nil)
+	}
+
+	err := m.SnsPublish("some_message", nil, "some_arn")
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, m.CallCount("SnsPublish"))
+
+}
@@ -0,0 +1,93 @@
+package mock
+
+import (
+	"context"
+
+	awsSqs "github.com/aws/aws-sdk-go/service/sqs"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/sqs"
+)
+
+// SQS is a mock implementation of sqs.SQSAPI
+type SQS struct {
+	Recorder
+
+	SQSCreateQueueFunc        func(ctx context.Context, queue string) error
+	SQSGetQueueAttributesFunc func(ctx context.Context, queueUrl string) (*awsSqs.GetQueueAttributesOutput, error)
+	SQSSendMessageFunc        func(ctx context.Context, input interface{}, queueName string, base64Encode bool) error
+	SQSGetQueueUrlFunc        func(ctx context.Context, queueName string) (string, error)
+}
+
+var _ sqs.SQSAPI = (*SQS)(nil)
+
+// SQSCreateQueue calls SQSCreateQueueFunc
+func (m *SQS) SQSCreateQueue(queue string) error {
+	return m.SQSCreateQueueWithContext(context.Background(), queue)
+}
+
+// SQSCreateQueueWithContext calls SQSCreateQueueFunc
+func (m *SQS) SQSCreateQueueWithContext(ctx context.Context, queue string) error {
+
+	m.record("SQSCreateQueue", queue)
+
+	if m.SQSCreateQueueFunc == nil {
+		return nil
+	}
+
+	return m.SQSCreateQueueFunc(ctx, queue)
+
+}
+
+// SQSGetQueueAttributes calls SQSGetQueueAttributesFunc
+func (m *SQS) SQSGetQueueAttributes(queueUrl string) (*awsSqs.GetQueueAttributesOutput, error) {
+	return m.SQSGetQueueAttributesWithContext(context.Background(), queueUrl)
+}
+
+// SQSGetQueueAttributesWithContext calls SQSGetQueueAttributesFunc
+func (m *SQS) SQSGetQueueAttributesWithContext(ctx context.Context, queueUrl string) (*awsSqs.GetQueueAttributesOutput, error) {
+
+	m.record("SQSGetQueueAttributes", queueUrl)
+
+	if m.SQSGetQueueAttributesFunc == nil {
+		return nil, nil
+	}
+
+	return m.SQSGetQueueAttributesFunc(ctx, queueUrl)
+
+}
+
+// SQSSendMessage calls SQSSendMessageFunc
+func (m *SQS) SQSSendMessage(input interface{}, queueName string, base64Encode bool) error {
+	return m.SQSSendMessageWithContext(context.Background(), input, queueName, base64Encode)
+}
+
+// SQSSendMessageWithContext calls SQSSendMessageFunc
+func (m *SQS) SQSSendMessageWithContext(ctx context.Context, input interface{}, queueName string, base64Encode bool) error {
+
+	m.record("SQSSendMessage", input, queueName, base64Encode)
+
+	if m.SQSSendMessageFunc == nil {
+		return nil
+	}
+
+	return m.SQSSendMessageFunc(ctx, input, queueName, base64Encode)
+
+}
+
+// SQSGetQueueUrl calls SQSGetQueueUrlFunc
+func (m *SQS) SQSGetQueueUrl(queueName string) (string, error) {
+	return m.SQSGetQueueUrlWithContext(context.Background(), queueName)
+}
+
+// SQSGetQueueUrlWithContext calls SQSGetQueueUrlFunc
+func (m *SQS) SQSGetQueueUrlWithContext(ctx context.Context, queueName string) (string, error) {
+
+	m.record("SQSGetQueueUrl", queueName)
+
+	if m.SQSGetQueueUrlFunc == nil {
+		return "", nil
+	}
+
+	return m.SQSGetQueueUrlFunc(ctx, queueName)
+
+}
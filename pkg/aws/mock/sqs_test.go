@@ -0,0 +1,35 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQS(t *testing.T) {
+
+	m := &SQS{
+		SQSGetQueueUrlFunc: func(ctx context.Context, queueName string) (string, error) {
+			return "http://localhost/queue/" + queueName, nil
+		},
+	}
+
+	assert.NoError(t, m.SQSCreateQueue("some_queue"))
+
+	url, err := m.SQSGetQueueUrl("some_queue")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "http://localhost/queue/some_queue", url)
+
+	assert.NoError(t, m.SQSSendMessage("some_message", "some_queue", true))
+
+	out, err := m.SQSGetQueueAttributes(url)
+
+	assert.NoError(t, err)
+	assert.Nil(t, out)
+
+	assert.Equal(t, []interface{}{"some_message", "some_queue", true}, m.Calls()[2].Args)
+	assert.Equal(t, 1, m.CallCount("SQSGetQueueAttributes"))
+
+}
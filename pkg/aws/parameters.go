@@ -0,0 +1,31 @@
+package aws
+
+const (
+
+	// AccessKeyID represents the parameter named accessKeyID
+	AccessKeyID = "accessKeyID"
+	// SecretAccessKey represents the parameter named secretAccessKey
+	SecretAccessKey = "secretAccessKey"
+	// Profile represents the parameter named profile
+	Profile = "profile"
+	// RoleARN represents the parameter named roleARN
+	RoleARN = "roleARN"
+	// TokenFile represents the parameter named tokenFile
+	TokenFile = "tokenFile"
+	// MaxAttempts represents the parameter named maxAttempts
+	MaxAttempts = "maxAttempts"
+	// BaseDelay represents the parameter named baseDelay
+	BaseDelay = "baseDelay"
+	// MaxDelay represents the parameter named maxDelay
+	MaxDelay = "maxDelay"
+	// Budget represents the parameter named budget
+	Budget = "budget"
+	// OnRetry represents the parameter named onRetry
+	OnRetry = "onRetry"
+	// Policy represents the parameter named policy
+	Policy = "policy"
+	// BaseURL represents the parameter named baseURL
+	BaseURL = "baseURL"
+	// Ports represents the parameter named ports
+	Ports = "ports"
+)
@@ -0,0 +1,18 @@
+package rekognition
+
+import (
+	"context"
+)
+
+// RekognitionAPI contains the helper methods of *Rekognition.
+// Depend on it instead of *Rekognition to swap the real client with a mock in unit tests
+type RekognitionAPI interface {
+	RekognitionCompareFaces(sourceImage, targetImage []byte, similarity float64) (*CompareFacesOutput, error)
+	RekognitionCompareFacesWithContext(ctx context.Context, sourceImage, targetImage []byte, similarity float64) (*CompareFacesOutput, error)
+	RekognitionDetectFaces(sourceImage []byte) (*DetectFacesOutput, error)
+	RekognitionDetectFacesWithContext(ctx context.Context, sourceImage []byte) (*DetectFacesOutput, error)
+	RekognitionDetectText(sourceImage []byte) (*DetectTextOutput, error)
+	RekognitionDetectTextWithContext(ctx context.Context, sourceImage []byte) (*DetectTextOutput, error)
+}
+
+var _ RekognitionAPI = (*Rekognition)(nil)
@@ -13,4 +13,10 @@ const (
 
 	// ErrEmptyMap is used when structs.Map() returns an empty map
 	ErrEmptyMap = "EmptyMap"
+
+	// ErrInvalidParameter is used when an input is refused by the validation of aws-sdk-go
+	ErrInvalidParameter = "InvalidParameter"
+
+	// ErrUnsupportedImageFormat is used when an image is neither a JPEG nor a PNG
+	ErrUnsupportedImageFormat = "UnsupportedImageFormat"
 )
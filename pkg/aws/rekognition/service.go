@@ -1,7 +1,18 @@
 package rekognition
 
+import (
+	"context"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
 // RekognitionCompareFaces compares two faces returning their similarity
 func (svc *Rekognition) RekognitionCompareFaces(sourceImage, targetImage []byte, similarity float64) (*CompareFacesOutput, error) {
+	return svc.RekognitionCompareFacesWithContext(context.Background(), sourceImage, targetImage, similarity)
+}
+
+// RekognitionCompareFacesWithContext is the same as RekognitionCompareFaces with the addition of a context.Context
+func (svc *Rekognition) RekognitionCompareFacesWithContext(ctx context.Context, sourceImage, targetImage []byte, similarity float64) (*CompareFacesOutput, error) {
 
 	input, err := NewCompareFacesInput(
 		sourceImage,
@@ -14,12 +25,12 @@ func (svc *Rekognition) RekognitionCompareFaces(sourceImage, targetImage []byte,
 
 	valid := input.Validate()
 	if valid != nil {
-		return nil, valid
+		return nil, intErr.NewValidationError(ErrInvalidParameter, Input)
 	}
 
-	compareFacesOut, err := svc.CompareFaces(input)
+	compareFacesOut, err := svc.CompareFacesWithContext(ctx, input)
 	if err != nil {
-		return nil, err
+		return nil, intErr.Wrap(err)
 	}
 
 	var out CompareFacesOutput
@@ -35,6 +46,11 @@ func (svc *Rekognition) RekognitionCompareFaces(sourceImage, targetImage []byte,
 
 // RekognitionDetectFaces detects faces in an image
 func (svc *Rekognition) RekognitionDetectFaces(sourceImage []byte) (*DetectFacesOutput, error) {
+	return svc.RekognitionDetectFacesWithContext(context.Background(), sourceImage)
+}
+
+// RekognitionDetectFacesWithContext is the same as RekognitionDetectFaces with the addition of a context.Context
+func (svc *Rekognition) RekognitionDetectFacesWithContext(ctx context.Context, sourceImage []byte) (*DetectFacesOutput, error) {
 
 	input, err := NewDetectFacesInput(
 		sourceImage,
@@ -45,12 +61,12 @@ func (svc *Rekognition) RekognitionDetectFaces(sourceImage []byte) (*DetectFaces
 
 	valid := input.Validate()
 	if valid != nil {
-		return nil, valid
+		return nil, intErr.NewValidationError(ErrInvalidParameter, Input)
 	}
 
-	detectFacesOut, err := svc.DetectFaces(input)
+	detectFacesOut, err := svc.DetectFacesWithContext(ctx, input)
 	if err != nil {
-		return nil, err
+		return nil, intErr.Wrap(err)
 	}
 
 	var out DetectFacesOutput
@@ -66,6 +82,11 @@ func (svc *Rekognition) RekognitionDetectFaces(sourceImage []byte) (*DetectFaces
 
 // RekognitionDetectText extracts text from an image
 func (svc *Rekognition) RekognitionDetectText(sourceImage []byte) (*DetectTextOutput, error) {
+	return svc.RekognitionDetectTextWithContext(context.Background(), sourceImage)
+}
+
+// RekognitionDetectTextWithContext is the same as RekognitionDetectText with the addition of a context.Context
+func (svc *Rekognition) RekognitionDetectTextWithContext(ctx context.Context, sourceImage []byte) (*DetectTextOutput, error) {
 
 	input, err := NewDetectTextInput(
 		sourceImage,
@@ -76,12 +97,12 @@ func (svc *Rekognition) RekognitionDetectText(sourceImage []byte) (*DetectTextOu
 
 	valid := input.Validate()
 	if valid != nil {
-		return nil, valid
+		return nil, intErr.NewValidationError(ErrInvalidParameter, Input)
 	}
 
-	detectTextOut, err := svc.DetectText(input)
+	detectTextOut, err := svc.DetectTextWithContext(ctx, input)
 	if err != nil {
-		return nil, err
+		return nil, intErr.Wrap(err)
 	}
 
 	var out DetectTextOutput
@@ -1,16 +1,19 @@
 package rekognition
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/stretchr/testify/assert"
 
-	"github.com/easynetwork/aws-sdk-go-bindings/internal/configuration"
 	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
 	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/s3"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/config"
 	"github.com/easynetwork/aws-sdk-go-bindings/testdata"
 )
 
@@ -29,7 +32,7 @@ func TestRekognition_RekognitionMethods(t *testing.T) {
 
 	uploadImages(cfg, s3Svc, t)
 
-	funcs := []func(*configuration.Configuration, *s3.S3, *Rekognition, *testing.T){
+	funcs := []func(*config.Configuration, *s3.S3, *Rekognition, *testing.T){
 		testRekognitionRekognitionCompareFaces,
 		testRekognitionRekognitionDetectFaces,
 		testRekognitionRekognitionDetectText,
@@ -41,7 +44,33 @@ func TestRekognition_RekognitionMethods(t *testing.T) {
 
 }
 
-func uploadImages(cfg *configuration.Configuration, svc *s3.S3, t *testing.T) {
+func TestRekognition_RekognitionDetectTextWithContext(t *testing.T) {
+
+	cfg := testdata.MockConfiguration(t)
+
+	svcIn, err := aws.NewSessionInput(cfg.Region, aws.WithStaticCredentials("some_key", "some_secret", ""))
+
+	assert.NoError(t, err)
+
+	awsSvc, err := aws.New(svcIn)
+
+	assert.NoError(t, err)
+
+	rekSvc, err := New(awsSvc, cfg.Rekognition.Region)
+
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = rekSvc.RekognitionDetectTextWithContext(ctx, []byte("\xFF\xD8\xFFsome_image"))
+
+	assert.Error(t, err)
+	assert.Equal(t, request.CanceledErrorCode, err.(awserr.Error).Code())
+
+}
+
+func uploadImages(cfg *config.Configuration, svc *s3.S3, t *testing.T) {
 
 	t.Helper()
 
@@ -98,7 +127,7 @@ func readImage(path string, t *testing.T) ([]byte, string, int64) {
 
 }
 
-func testRekognitionRekognitionCompareFaces(cfg *configuration.Configuration, s3Svc *s3.S3, rekSvc *Rekognition, t *testing.T) {
+func testRekognitionRekognitionCompareFaces(cfg *config.Configuration, s3Svc *s3.S3, rekSvc *Rekognition, t *testing.T) {
 
 	t.Helper()
 
@@ -129,7 +158,7 @@ func testRekognitionRekognitionCompareFaces(cfg *configuration.Configuration, s3
 
 }
 
-func testRekognitionRekognitionDetectFaces(cfg *configuration.Configuration, s3Svc *s3.S3, rekSvc *Rekognition, t *testing.T) {
+func testRekognitionRekognitionDetectFaces(cfg *config.Configuration, s3Svc *s3.S3, rekSvc *Rekognition, t *testing.T) {
 
 	t.Helper()
 
@@ -148,7 +177,7 @@ func testRekognitionRekognitionDetectFaces(cfg *configuration.Configuration, s3S
 
 }
 
-func testRekognitionRekognitionDetectText(cfg *configuration.Configuration, s3Svc *s3.S3, rekSvc *Rekognition, t *testing.T) {
+func testRekognitionRekognitionDetectText(cfg *config.Configuration, s3Svc *s3.S3, rekSvc *Rekognition, t *testing.T) {
 
 	t.Helper()
 
@@ -167,7 +196,7 @@ func testRekognitionRekognitionDetectText(cfg *configuration.Configuration, s3Sv
 
 }
 
-func mockSessions(cfg *configuration.Configuration, t *testing.T) (*s3.S3, *Rekognition) {
+func mockSessions(cfg *config.Configuration, t *testing.T) (*s3.S3, *Rekognition) {
 
 	t.Helper()
 
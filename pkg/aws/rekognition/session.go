@@ -1,8 +1,6 @@
 package rekognition
 
 import (
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/rekognition"
 
 	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
@@ -13,17 +11,15 @@ type Rekognition struct {
 	*rekognition.Rekognition
 }
 
-// New returns a new *Rekognition embedding *rekognition.Rekognition
-func New(svc *pkgAws.Session, region string) (*Rekognition, error) {
+// New returns a new *Rekognition embedding *rekognition.Rekognition.
+// region and opts only apply to the returned client, svc is left untouched
+func New(svc *pkgAws.Session, region string, opts ...pkgAws.ClientOption) (*Rekognition, error) {
 
 	if region != "" {
-		svc.Config.Region = aws.String(region)
+		opts = append([]pkgAws.ClientOption{pkgAws.WithRegion(region)}, opts...)
 	}
 
-	newSvc, err := session.NewSession(svc.Config)
-	if err != nil {
-		return nil, err
-	}
+	newSvc := svc.ServiceSession(opts...)
 
 	rekognitionSvc := &Rekognition{
 		Rekognition: rekognition.New(newSvc),
@@ -22,9 +22,11 @@ func TestNew(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, awsSvc)
 
-	snsSvc, err := New(awsSvc, cfg.Region)
+	snsSvc, err := New(awsSvc, "some_region")
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, snsSvc)
+	assert.Equal(t, "some_region", *snsSvc.Config.Region)
+	assert.Equal(t, cfg.Region, *awsSvc.Config.Region)
 
 }
@@ -8,8 +8,15 @@ import (
 	"github.com/fatih/structs"
 
 	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/contenttype"
 )
 
+// supportedFormats are the content types of the images accepted by Rekognition
+var supportedFormats = map[string]bool{
+	contenttype.JPEG: true,
+	contenttype.PNG:  true,
+}
+
 // CompareFacesInput contains parameters to be sent to CompareFaces
 type CompareFacesInput struct {
 	SourceImage []byte
@@ -105,11 +112,11 @@ type Quality struct {
 func UnmarshalCompareFacesOutput(input *rekognition.CompareFacesOutput, output *CompareFacesOutput) error {
 
 	if reflect.DeepEqual(input, reflect.Zero(reflect.TypeOf(input)).Interface()) {
-		return intErr.Format(Input, ErrEmptyParameter)
+		return intErr.NewValidationError(ErrEmptyParameter, Input)
 	}
 
 	if reflect.ValueOf(output).Kind() != reflect.Ptr {
-		return intErr.Format(Output, ErrNoPointerParameter)
+		return intErr.NewValidationError(ErrNoPointerParameter, Output)
 	}
 
 	err := unmarshalRekognitionOut(input, output)
@@ -125,11 +132,11 @@ func UnmarshalCompareFacesOutput(input *rekognition.CompareFacesOutput, output *
 func UnmarshalDetectFacesOutput(input *rekognition.DetectFacesOutput, output *DetectFacesOutput) error {
 
 	if reflect.DeepEqual(input, reflect.Zero(reflect.TypeOf(input)).Interface()) {
-		return intErr.Format(Input, ErrEmptyParameter)
+		return intErr.NewValidationError(ErrEmptyParameter, Input)
 	}
 
 	if reflect.ValueOf(output).Kind() != reflect.Ptr {
-		return intErr.Format(Output, ErrNoPointerParameter)
+		return intErr.NewValidationError(ErrNoPointerParameter, Output)
 	}
 	err := unmarshalRekognitionOut(input, output)
 	if err != nil {
@@ -142,11 +149,11 @@ func UnmarshalDetectFacesOutput(input *rekognition.DetectFacesOutput, output *De
 func UnmarshalDetectTextOutput(input *rekognition.DetectTextOutput, output *DetectTextOutput) error {
 
 	if reflect.DeepEqual(input, reflect.Zero(reflect.TypeOf(input)).Interface()) {
-		return intErr.Format(Input, ErrEmptyParameter)
+		return intErr.NewValidationError(ErrEmptyParameter, Input)
 	}
 
 	if reflect.ValueOf(output).Kind() != reflect.Ptr {
-		return intErr.Format(Output, ErrNoPointerParameter)
+		return intErr.NewValidationError(ErrNoPointerParameter, Output)
 	}
 
 	err := unmarshalRekognitionOut(input, output)
@@ -163,17 +170,17 @@ func UnmarshalDetectTextOutput(input *rekognition.DetectTextOutput, output *Dete
 func unmarshalRekognitionOut(input, output interface{}) error {
 
 	if reflect.DeepEqual(input, reflect.Zero(reflect.TypeOf(input)).Interface()) {
-		return intErr.Format(Input, ErrEmptyParameter)
+		return intErr.NewValidationError(ErrEmptyParameter, Input)
 	}
 
 	if reflect.ValueOf(output).Kind() != reflect.Ptr {
-		return intErr.Format(Output, ErrNoPointerParameter)
+		return intErr.NewValidationError(ErrNoPointerParameter, Output)
 	}
 
 	m := structs.Map(input)
 
 	if len(m) == 0 {
-		return intErr.Format(Map, ErrEmptyMap)
+		return intErr.NewValidationError(ErrEmptyMap, Map)
 	}
 
 	bytes, err := json.Marshal(m)
@@ -194,15 +201,15 @@ func unmarshalRekognitionOut(input, output interface{}) error {
 func NewCompareFacesInput(source, target []byte, similarity float64) (*rekognition.CompareFacesInput, error) {
 
 	if len(source) == 0 {
-		return nil, intErr.Format(Source, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Source)
 	}
 
 	if len(target) == 0 {
-		return nil, intErr.Format(Target, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Target)
 	}
 
 	if similarity == 0 {
-		return nil, intErr.Format(Similarity, ErrBadSimilarityParameter)
+		return nil, intErr.NewValidationError(ErrBadSimilarityParameter, Similarity)
 	}
 
 	newSourceInputImg, err := newInputImage(source)
@@ -227,7 +234,7 @@ func NewCompareFacesInput(source, target []byte, similarity float64) (*rekogniti
 func NewDetectFacesInput(source []byte) (*rekognition.DetectFacesInput, error) {
 
 	if len(source) == 0 {
-		return nil, intErr.Format(Source, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Source)
 	}
 
 	newInputImg, err := newInputImage(source)
@@ -246,7 +253,7 @@ func NewDetectFacesInput(source []byte) (*rekognition.DetectFacesInput, error) {
 func NewDetectTextInput(source []byte) (*rekognition.DetectTextInput, error) {
 
 	if len(source) == 0 {
-		return nil, intErr.Format(Source, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Source)
 	}
 
 	newInputImg, err := newInputImage(source)
@@ -261,11 +268,16 @@ func NewDetectTextInput(source []byte) (*rekognition.DetectTextInput, error) {
 
 }
 
-// newInputImage returns a *rekognition.Image given an S3 image []byte encoded
+// newInputImage returns a *rekognition.Image given an S3 image []byte encoded.
+// The format of the image is detected from its signature, since Rekognition only accepts JPEG and PNG images
 func newInputImage(image []byte) (*rekognition.Image, error) {
 
 	if len(image) == 0 {
-		return nil, intErr.Format(Image, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Image)
+	}
+
+	if !supportedFormats[contenttype.Detect(image)] {
+		return nil, intErr.NewValidationError(ErrUnsupportedImageFormat, Image)
 	}
 
 	out := &rekognition.Image{
@@ -161,8 +161,8 @@ func TestUnmarshalDetectTextOutput(t *testing.T) {
 
 func TestNewCompareFacesInput(t *testing.T) {
 
-	target := []byte("someTarget")
-	source := []byte("someSource")
+	target := []byte("\x89PNG\r\n\x1A\nsomeTarget")
+	source := []byte("\xFF\xD8\xFF\xE0someSource")
 	similarity := 90.0
 
 	in, err := NewCompareFacesInput(source, target, similarity)
@@ -183,11 +183,15 @@ func TestNewCompareFacesInput(t *testing.T) {
 
 	assert.Contains(t, err.Error(), ErrBadSimilarityParameter)
 
+	_, err = NewCompareFacesInput(source, []byte("RIFF\x24\x00\x00\x00WEBPVP8 "), similarity)
+
+	assert.Contains(t, err.Error(), ErrUnsupportedImageFormat)
+
 }
 
 func TestNewDetectFacesInput(t *testing.T) {
 
-	source := []byte("someSource")
+	source := []byte("\xFF\xD8\xFF\xE0someSource")
 
 	in, err := NewDetectFacesInput(source)
 
@@ -196,11 +200,15 @@ func TestNewDetectFacesInput(t *testing.T) {
 	assert.NotEmpty(t, in)
 	assert.Equal(t, source, in.Image.Bytes)
 
+	_, err = NewDetectFacesInput([]byte("<svg></svg>"))
+
+	assert.Contains(t, err.Error(), ErrUnsupportedImageFormat)
+
 }
 
 func TestNewDetectTextInput(t *testing.T) {
 
-	source := []byte("someSource")
+	source := []byte("\x89PNG\r\n\x1A\nsomeSource")
 
 	in, err := NewDetectTextInput(source)
 
@@ -209,4 +217,8 @@ func TestNewDetectTextInput(t *testing.T) {
 	assert.NotEmpty(t, in)
 	assert.Equal(t, source, in.Image.Bytes)
 
+	_, err = NewDetectTextInput([]byte("someSource"))
+
+	assert.Contains(t, err.Error(), ErrUnsupportedImageFormat)
+
 }
@@ -0,0 +1,219 @@
+package aws
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+const (
+
+	// DefaultBaseDelay is the backoff delay of the first retry used by NewRetryPolicy
+	DefaultBaseDelay = 50 * time.Millisecond
+
+	// DefaultMaxDelay is the maximum backoff delay used by NewRetryPolicy
+	DefaultMaxDelay = 20 * time.Second
+)
+
+// RetryPolicy is a request.Retryer retrying failed requests with an exponential
+// backoff with full jitter, within an optional total time budget
+type RetryPolicy struct {
+	maxAttempts    int
+	baseDelay      time.Duration
+	maxDelay       time.Duration
+	retryableCodes map[string]bool
+	budget         time.Duration
+	onRetry        func(Retry)
+}
+
+// RetryOption sets an optional parameter on a *RetryPolicy
+type RetryOption func(*RetryPolicy) error
+
+// Retry describes a request about to be retried
+type Retry struct {
+	Service   string
+	Operation string
+	Attempt   int
+	Delay     time.Duration
+	Err       error
+}
+
+var _ request.Retryer = (*RetryPolicy)(nil)
+
+// NewRetryPolicy returns a new *RetryPolicy making at most maxAttempts attempts per request,
+// the first one included. Errors are retryable when aws-sdk-go considers them so, like
+// throttling errors, connection errors and 5xx responses, or when their code has been
+// added with WithRetryableCodes
+func NewRetryPolicy(maxAttempts int, opts ...RetryOption) (*RetryPolicy, error) {
+
+	if maxAttempts < 1 {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, MaxAttempts)
+	}
+
+	p := &RetryPolicy{
+		maxAttempts:    maxAttempts,
+		baseDelay:      DefaultBaseDelay,
+		maxDelay:       DefaultMaxDelay,
+		retryableCodes: make(map[string]bool),
+	}
+
+	for _, opt := range opts {
+		if err := opt(p); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+
+}
+
+// WithBackoff sets the delay of the first retry and the maximum delay between two attempts.
+// The n-th retry waits a random delay between 0 and min(maxDelay, baseDelay * 2^(n-1))
+func WithBackoff(baseDelay, maxDelay time.Duration) RetryOption {
+	return func(p *RetryPolicy) error {
+
+		if baseDelay <= 0 {
+			return intErr.NewValidationError(ErrInvalidParameter, BaseDelay)
+		}
+		if maxDelay < baseDelay {
+			return intErr.NewValidationError(ErrInvalidParameter, MaxDelay)
+		}
+
+		p.baseDelay = baseDelay
+		p.maxDelay = maxDelay
+
+		return nil
+
+	}
+}
+
+// WithRetryableCodes makes errors with the given aws error codes retryable,
+// in addition to the ones aws-sdk-go already retries
+func WithRetryableCodes(codes ...string) RetryOption {
+	return func(p *RetryPolicy) error {
+
+		for _, code := range codes {
+			p.retryableCodes[code] = true
+		}
+
+		return nil
+
+	}
+}
+
+// WithRetryBudget stops retrying a request once budget has elapsed since it was created.
+// Backoff delays are shortened so that they never exceed the remaining budget
+func WithRetryBudget(budget time.Duration) RetryOption {
+	return func(p *RetryPolicy) error {
+
+		if budget <= 0 {
+			return intErr.NewValidationError(ErrInvalidParameter, Budget)
+		}
+
+		p.budget = budget
+
+		return nil
+
+	}
+}
+
+// WithRetryCallback makes the policy call fn before every retry
+func WithRetryCallback(fn func(Retry)) RetryOption {
+	return func(p *RetryPolicy) error {
+
+		if fn == nil {
+			return intErr.NewValidationError(ErrEmptyParameter, OnRetry)
+		}
+
+		p.onRetry = fn
+
+		return nil
+
+	}
+}
+
+// MaxRetries implements request.Retryer
+func (p *RetryPolicy) MaxRetries() int {
+	return p.maxAttempts - 1
+}
+
+// ShouldRetry implements request.Retryer
+func (p *RetryPolicy) ShouldRetry(r *request.Request) bool {
+
+	if p.budget > 0 && time.Since(r.Time) >= p.budget {
+		return false
+	}
+
+	if awsErr, ok := r.Error.(awserr.Error); ok && p.retryableCodes[awsErr.Code()] {
+		return true
+	}
+
+	if r.Retryable != nil {
+		return *r.Retryable
+	}
+
+	return r.IsErrorRetryable() || r.IsErrorThrottle()
+
+}
+
+// RetryRules implements request.Retryer
+func (p *RetryPolicy) RetryRules(r *request.Request) time.Duration {
+
+	delay := p.delay(r.RetryCount)
+
+	if p.budget > 0 {
+		if remaining := p.budget - time.Since(r.Time); delay > remaining {
+			delay = remaining
+		}
+		if delay < 0 {
+			delay = 0
+		}
+	}
+
+	if p.onRetry != nil {
+
+		retry := Retry{
+			Service: r.ClientInfo.ServiceName,
+			Attempt: r.RetryCount + 1,
+			Delay:   delay,
+			Err:     r.Error,
+		}
+		if r.Operation != nil {
+			retry.Operation = r.Operation.Name
+		}
+
+		p.onRetry(retry)
+
+	}
+
+	return delay
+
+}
+
+// delay returns a random delay between 0 and the exponential backoff of retryCount
+func (p *RetryPolicy) delay(retryCount int) time.Duration {
+
+	ceiling := p.maxDelay
+	if retryCount < 62 {
+		if exp := p.baseDelay << uint(retryCount); exp > 0 && exp < ceiling {
+			ceiling = exp
+		}
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+
+}
+
+// apply sets p as the retryer of cfg. Every error goes through ShouldRetry,
+// even when aws-sdk-go has already decided whether it is retryable
+func (p *RetryPolicy) apply(cfg *aws.Config) {
+
+	request.WithRetryer(cfg, p)
+	cfg.EnforceShouldRetryCheck = aws.Bool(true)
+
+}
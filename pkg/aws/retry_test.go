@@ -0,0 +1,191 @@
+package aws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+)
+
+const someRetryableCode = "AWS.SimpleQueueService.NonExistentQueue"
+
+func TestNewRetryPolicy(t *testing.T) {
+
+	p, err := NewRetryPolicy(3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, p.MaxRetries())
+	assert.Equal(t, DefaultBaseDelay, p.baseDelay)
+	assert.Equal(t, DefaultMaxDelay, p.maxDelay)
+
+	p, err = NewRetryPolicy(
+		5,
+		WithBackoff(time.Millisecond, time.Second),
+		WithRetryableCodes("some_code"),
+		WithRetryBudget(time.Minute),
+		WithRetryCallback(func(Retry) {}),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Millisecond, p.baseDelay)
+	assert.True(t, p.retryableCodes["some_code"])
+	assert.Equal(t, time.Minute, p.budget)
+	assert.NotNil(t, p.onRetry)
+
+	for _, c := range []struct {
+		maxAttempts int
+		opt         RetryOption
+		parameter   string
+	}{
+		{0, WithRetryBudget(time.Second), MaxAttempts},
+		{1, WithBackoff(0, time.Second), BaseDelay},
+		{1, WithBackoff(time.Second, time.Millisecond), MaxDelay},
+		{1, WithRetryBudget(0), Budget},
+		{1, WithRetryCallback(nil), OnRetry},
+	} {
+
+		_, err := NewRetryPolicy(c.maxAttempts, c.opt)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), c.parameter)
+
+	}
+
+}
+
+func TestRetryPolicy_delay(t *testing.T) {
+
+	p, err := NewRetryPolicy(100, WithBackoff(10*time.Millisecond, time.Second))
+
+	assert.NoError(t, err)
+
+	for retryCount := 0; retryCount < 100; retryCount++ {
+
+		ceiling := time.Second
+		if retryCount < 7 {
+			ceiling = (10 * time.Millisecond) << uint(retryCount)
+		}
+
+		d := p.delay(retryCount)
+
+		assert.True(t, d >= 0 && d <= ceiling, "retry %d waited %s", retryCount, d)
+
+	}
+
+}
+
+func TestRetryPolicy_Session(t *testing.T) {
+
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(someErrorResponse))
+			return
+		}
+
+		w.Write([]byte(someQueueUrlResponse))
+
+	}))
+	defer srv.Close()
+
+	var retries []Retry
+
+	policy, err := NewRetryPolicy(
+		3,
+		WithBackoff(time.Millisecond, 2*time.Millisecond),
+		WithRetryableCodes(someRetryableCode),
+		WithRetryCallback(func(r Retry) {
+			retries = append(retries, r)
+		}),
+	)
+
+	assert.NoError(t, err)
+
+	in, err := NewSessionInput(
+		"some_region",
+		WithStaticCredentials("some_key", "some_secret", ""),
+		WithDefaultRetryPolicy(policy),
+	)
+
+	assert.NoError(t, err)
+
+	svc, err := New(in)
+
+	assert.NoError(t, err)
+
+	client := sqs.New(svc.ServiceSession(WithEndpoint(srv.URL)))
+
+	_, err = client.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("some_queue")})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+	assert.Len(t, retries, 2)
+	assert.Equal(t, "GetQueueUrl", retries[0].Operation)
+	assert.Equal(t, 1, retries[0].Attempt)
+	assert.Equal(t, 2, retries[1].Attempt)
+	assert.Equal(t, someRetryableCode, retries[0].Err.(awserr.Error).Code())
+
+	atomic.StoreInt32(&requests, 0)
+
+	once, err := NewRetryPolicy(1)
+
+	assert.NoError(t, err)
+
+	client = sqs.New(svc.ServiceSession(WithEndpoint(srv.URL), WithRetryPolicy(once)))
+
+	_, err = client.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("some_queue")})
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+}
+
+func TestRetryPolicy_Budget(t *testing.T) {
+
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(someErrorResponse))
+	}))
+	defer srv.Close()
+
+	policy, err := NewRetryPolicy(
+		100,
+		WithBackoff(20*time.Millisecond, 20*time.Millisecond),
+		WithRetryableCodes(someRetryableCode),
+		WithRetryBudget(50*time.Millisecond),
+	)
+
+	assert.NoError(t, err)
+
+	in, err := NewSessionInput("some_region", WithStaticCredentials("some_key", "some_secret", ""))
+
+	assert.NoError(t, err)
+
+	svc, err := New(in)
+
+	assert.NoError(t, err)
+
+	client := sqs.New(svc.ServiceSession(WithEndpoint(srv.URL), WithRetryPolicy(policy)))
+
+	start := time.Now()
+
+	_, err = client.GetQueueUrl(&sqs.GetQueueUrlInput{QueueName: aws.String("some_queue")})
+
+	assert.Error(t, err)
+	assert.True(t, time.Since(start) < time.Second)
+	assert.True(t, atomic.LoadInt32(&requests) > 1)
+	assert.True(t, atomic.LoadInt32(&requests) < 100)
+
+}
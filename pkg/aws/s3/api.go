@@ -0,0 +1,85 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// S3API contains the helper methods of *S3.
+// Depend on it instead of *S3 to swap the real client with a mock in unit tests
+type S3API interface {
+	S3CreateBucket(bucketName string, opts ...CreateBucketOption) error
+	S3CreateBucketWithContext(ctx context.Context, bucketName string, opts ...CreateBucketOption) error
+	S3GetObject(bucketName, sourceImage string, opts ...EncryptionOption) ([]byte, error)
+	S3GetObjectWithContext(ctx context.Context, bucketName, sourceImage string, opts ...EncryptionOption) ([]byte, error)
+	S3PutObject(bucketName, objectName, objectPath string, opts ...PutOption) error
+	S3PutObjectWithContext(ctx context.Context, bucketName, objectName, objectPath string, opts ...PutOption) error
+	S3Upload(bucketName, objectName string, body io.Reader, opts ...UploadOption) error
+	S3UploadWithContext(ctx context.Context, bucketName, objectName string, body io.Reader, opts ...UploadOption) error
+	S3UploadFile(bucketName, objectName, path string, opts ...UploadOption) error
+	S3UploadFileWithContext(ctx context.Context, bucketName, objectName, path string, opts ...UploadOption) error
+	S3UploadReaderAt(bucketName, objectName string, body io.ReaderAt, size int64, opts ...UploadOption) error
+	S3UploadReaderAtWithContext(ctx context.Context, bucketName, objectName string, body io.ReaderAt, size int64, opts ...UploadOption) error
+	S3Download(bucketName, objectName string, w io.Writer, opts ...DownloadOption) (int64, error)
+	S3DownloadWithContext(ctx context.Context, bucketName, objectName string, w io.Writer, opts ...DownloadOption) (int64, error)
+	S3DownloadAt(bucketName, objectName string, w io.WriterAt, opts ...DownloadOption) (int64, error)
+	S3DownloadAtWithContext(ctx context.Context, bucketName, objectName string, w io.WriterAt, opts ...DownloadOption) (int64, error)
+	S3ListObjects(bucketName string, opts ...ListOption) *ObjectIterator
+	S3ListObjectsWithContext(ctx context.Context, bucketName string, opts ...ListOption) *ObjectIterator
+	S3DeleteObject(bucketName, objectName string, opts ...DeleteOption) error
+	S3DeleteObjectWithContext(ctx context.Context, bucketName, objectName string, opts ...DeleteOption) error
+	S3DeleteObjects(bucketName string, objectNames []string, opts ...DeleteOption) (*DeleteResult, error)
+	S3DeleteObjectsWithContext(ctx context.Context, bucketName string, objectNames []string, opts ...DeleteOption) (*DeleteResult, error)
+	S3DeletePrefix(bucketName, prefix string, opts ...DeleteOption) (*DeleteResult, error)
+	S3DeletePrefixWithContext(ctx context.Context, bucketName, prefix string, opts ...DeleteOption) (*DeleteResult, error)
+	S3Copy(srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...CopyOption) error
+	S3CopyWithContext(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...CopyOption) error
+	S3Move(srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...CopyOption) error
+	S3MoveWithContext(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...CopyOption) error
+	S3ReplaceMetadata(bucketName, objectName string, metadata map[string]string, opts ...CopyOption) error
+	S3ReplaceMetadataWithContext(ctx context.Context, bucketName, objectName string, metadata map[string]string, opts ...CopyOption) error
+	S3Exists(bucketName, objectName string, opts ...EncryptionOption) (bool, error)
+	S3ExistsWithContext(ctx context.Context, bucketName, objectName string, opts ...EncryptionOption) (bool, error)
+	S3Stat(bucketName, objectName string, opts ...EncryptionOption) (*ObjectInfo, error)
+	S3StatWithContext(ctx context.Context, bucketName, objectName string, opts ...EncryptionOption) (*ObjectInfo, error)
+	S3GetObjectTags(bucketName, objectName string) (map[string]string, error)
+	S3GetObjectTagsWithContext(ctx context.Context, bucketName, objectName string) (map[string]string, error)
+	S3PutObjectTags(bucketName, objectName string, tags map[string]string) error
+	S3PutObjectTagsWithContext(ctx context.Context, bucketName, objectName string, tags map[string]string) error
+	S3DeleteObjectTags(bucketName, objectName string) error
+	S3DeleteObjectTagsWithContext(ctx context.Context, bucketName, objectName string) error
+	S3PresignGet(bucketName, objectName string, expiry time.Duration, opts ...PresignOption) (string, error)
+	S3PresignPut(bucketName, objectName string, expiry time.Duration, opts ...PresignOption) (*PresignedRequest, error)
+	S3PresignPost(bucketName, objectName string, expiry time.Duration, opts ...PresignOption) (*PresignedPost, error)
+	S3SyncUp(localDir, bucketName, prefix string, opts ...SyncOption) (*SyncReport, error)
+	S3SyncUpWithContext(ctx context.Context, localDir, bucketName, prefix string, opts ...SyncOption) (*SyncReport, error)
+	S3SyncDown(bucketName, prefix, localDir string, opts ...SyncOption) (*SyncReport, error)
+	S3SyncDownWithContext(ctx context.Context, bucketName, prefix, localDir string, opts ...SyncOption) (*SyncReport, error)
+	S3GetBucketVersioning(bucketName string) (VersioningStatus, error)
+	S3GetBucketVersioningWithContext(ctx context.Context, bucketName string) (VersioningStatus, error)
+	S3PutBucketVersioning(bucketName string, status VersioningStatus) error
+	S3PutBucketVersioningWithContext(ctx context.Context, bucketName string, status VersioningStatus) error
+	S3GetBucketLifecycle(bucketName string) ([]LifecycleRule, error)
+	S3GetBucketLifecycleWithContext(ctx context.Context, bucketName string) ([]LifecycleRule, error)
+	S3PutBucketLifecycle(bucketName string, rules []LifecycleRule) error
+	S3PutBucketLifecycleWithContext(ctx context.Context, bucketName string, rules []LifecycleRule) error
+	S3GetBucketCORS(bucketName string) ([]CORSRule, error)
+	S3GetBucketCORSWithContext(ctx context.Context, bucketName string) ([]CORSRule, error)
+	S3PutBucketCORS(bucketName string, rules []CORSRule) error
+	S3PutBucketCORSWithContext(ctx context.Context, bucketName string, rules []CORSRule) error
+	S3GetPublicAccessBlock(bucketName string) (*PublicAccessBlock, error)
+	S3GetPublicAccessBlockWithContext(ctx context.Context, bucketName string) (*PublicAccessBlock, error)
+	S3PutPublicAccessBlock(bucketName string, block *PublicAccessBlock) error
+	S3PutPublicAccessBlockWithContext(ctx context.Context, bucketName string, block *PublicAccessBlock) error
+	S3GetBucketPolicy(bucketName string) (string, error)
+	S3GetBucketPolicyWithContext(ctx context.Context, bucketName string) (string, error)
+	S3PutBucketPolicy(bucketName, policy string) error
+	S3PutBucketPolicyWithContext(ctx context.Context, bucketName, policy string) error
+	S3GetBucketEncryption(bucketName string) (*BucketEncryption, error)
+	S3GetBucketEncryptionWithContext(ctx context.Context, bucketName string) (*BucketEncryption, error)
+	S3PutBucketEncryption(bucketName string, enc *BucketEncryption) error
+	S3PutBucketEncryptionWithContext(ctx context.Context, bucketName string, enc *BucketEncryption) error
+}
+
+var _ S3API = (*S3)(nil)
@@ -0,0 +1,735 @@
+package s3
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+const (
+
+	// MaxLifecycleRules is the largest number of lifecycle rules a bucket can have
+	MaxLifecycleRules = 1000
+
+	// MaxCORSRules is the largest number of CORS rules a bucket can have
+	MaxCORSRules = 100
+
+	// errCodeNoSuchLifecycleConfiguration, errCodeNoSuchCORSConfiguration, errCodeNoSuchPublicAccessBlock,
+	// errCodeNoSuchBucketPolicy and errCodeNoSuchEncryption are the aws error codes of a bucket without those configurations
+	errCodeNoSuchLifecycleConfiguration = "NoSuchLifecycleConfiguration"
+	errCodeNoSuchCORSConfiguration      = "NoSuchCORSConfiguration"
+	errCodeNoSuchPublicAccessBlock      = "NoSuchPublicAccessBlockConfiguration"
+	errCodeNoSuchBucketPolicy           = "NoSuchBucketPolicy"
+	errCodeNoSuchEncryption             = "ServerSideEncryptionConfigurationNotFoundError"
+)
+
+// VersioningStatus is the versioning state of a bucket
+type VersioningStatus string
+
+const (
+
+	// VersioningUnversioned is the status of a bucket whose versioning has never been enabled
+	VersioningUnversioned VersioningStatus = ""
+
+	// VersioningEnabled keeps every version of the objects of a bucket
+	VersioningEnabled VersioningStatus = s3.BucketVersioningStatusEnabled
+
+	// VersioningSuspended stops adding versions, keeping the existing ones
+	VersioningSuspended VersioningStatus = s3.BucketVersioningStatusSuspended
+)
+
+// LifecycleRule expires or transitions the objects of a bucket matching Prefix and every one of Tags.
+// A rule without Prefix nor Tags applies to the whole bucket
+type LifecycleRule struct {
+	ID      string
+	Enabled bool
+	Prefix  string
+	Tags    map[string]string
+	// ExpirationDays expires objects that many days after their creation
+	ExpirationDays int64
+	// NoncurrentExpirationDays permanently deletes versions that many days after becoming noncurrent
+	NoncurrentExpirationDays int64
+	// AbortIncompleteUploadDays aborts multipart uploads left incomplete that many days after their initiation
+	AbortIncompleteUploadDays int64
+	Transitions               []LifecycleTransition
+}
+
+// LifecycleTransition moves objects to StorageClass, like s3.TransitionStorageClassGlacier, Days after their creation
+type LifecycleTransition struct {
+	Days         int64
+	StorageClass string
+}
+
+// CORSRule allows browsers from AllowedOrigins to send AllowedMethods requests to a bucket
+type CORSRule struct {
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	ExposeHeaders  []string
+	// MaxAge is how long browsers can cache the response to a preflight request, rounded down to the second
+	MaxAge time.Duration
+}
+
+// PublicAccessBlock blocks the public access to a bucket and its objects granted by ACLs or policies
+type PublicAccessBlock struct {
+	BlockPublicACLs       bool
+	IgnorePublicACLs      bool
+	BlockPublicPolicy     bool
+	RestrictPublicBuckets bool
+}
+
+// BucketEncryption is the server-side encryption of the objects written to a bucket without encryption parameters
+type BucketEncryption struct {
+	// Algorithm is s3.ServerSideEncryptionAes256 for SSE-S3 or s3.ServerSideEncryptionAwsKms for SSE-KMS
+	Algorithm string
+	// KMSKeyID is the KMS key of SSE-KMS, an ID, ARN or alias. An empty KMSKeyID uses the aws/s3 key managed by AWS
+	KMSKeyID string
+}
+
+// S3GetBucketVersioning returns the versioning status of bucketName
+func (svc *S3) S3GetBucketVersioning(bucketName string) (VersioningStatus, error) {
+	return svc.S3GetBucketVersioningWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketVersioningWithContext is the same as S3GetBucketVersioning with the addition of a context.Context
+func (svc *S3) S3GetBucketVersioningWithContext(ctx context.Context, bucketName string) (VersioningStatus, error) {
+
+	if bucketName == "" {
+		return "", intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+
+	in := &s3.GetBucketVersioningInput{}
+	in = in.SetBucket(bucketName)
+
+	out, err := svc.S3.GetBucketVersioningWithContext(ctx, in)
+	if err != nil {
+		return "", intErr.Wrap(err)
+	}
+
+	return VersioningStatus(aws.StringValue(out.Status)), nil
+
+}
+
+// S3PutBucketVersioning enables or suspends the versioning of bucketName.
+// A versioned bucket can never be unversioned again
+func (svc *S3) S3PutBucketVersioning(bucketName string, status VersioningStatus) error {
+	return svc.S3PutBucketVersioningWithContext(context.Background(), bucketName, status)
+}
+
+// S3PutBucketVersioningWithContext is the same as S3PutBucketVersioning with the addition of a context.Context
+func (svc *S3) S3PutBucketVersioningWithContext(ctx context.Context, bucketName string, status VersioningStatus) error {
+
+	if bucketName == "" {
+		return intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+	if status != VersioningEnabled && status != VersioningSuspended {
+		return intErr.NewValidationError(ErrInvalidParameter, Versioning)
+	}
+
+	in := &s3.PutBucketVersioningInput{}
+	in = in.SetBucket(bucketName)
+	in = in.SetVersioningConfiguration((&s3.VersioningConfiguration{}).SetStatus(string(status)))
+
+	if _, err := svc.S3.PutBucketVersioningWithContext(ctx, in); err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
+
+// S3GetBucketLifecycle returns the lifecycle rules of bucketName, empty when it has none
+func (svc *S3) S3GetBucketLifecycle(bucketName string) ([]LifecycleRule, error) {
+	return svc.S3GetBucketLifecycleWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketLifecycleWithContext is the same as S3GetBucketLifecycle with the addition of a context.Context
+func (svc *S3) S3GetBucketLifecycleWithContext(ctx context.Context, bucketName string) ([]LifecycleRule, error) {
+
+	if bucketName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+
+	in := &s3.GetBucketLifecycleConfigurationInput{}
+	in = in.SetBucket(bucketName)
+
+	out, err := svc.S3.GetBucketLifecycleConfigurationWithContext(ctx, in)
+	if isErrCode(err, errCodeNoSuchLifecycleConfiguration) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	rules := make([]LifecycleRule, 0, len(out.Rules))
+	for _, r := range out.Rules {
+		rules = append(rules, lifecycleRule(r))
+	}
+
+	return rules, nil
+
+}
+
+// S3PutBucketLifecycle replaces the lifecycle rules of bucketName with rules, which has at most
+// MaxLifecycleRules rules. Every rule needs at least one expiration or transition. Empty rules
+// remove the lifecycle configuration
+func (svc *S3) S3PutBucketLifecycle(bucketName string, rules []LifecycleRule) error {
+	return svc.S3PutBucketLifecycleWithContext(context.Background(), bucketName, rules)
+}
+
+// S3PutBucketLifecycleWithContext is the same as S3PutBucketLifecycle with the addition of a context.Context
+func (svc *S3) S3PutBucketLifecycleWithContext(ctx context.Context, bucketName string, rules []LifecycleRule) error {
+
+	if bucketName == "" {
+		return intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+	if len(rules) > MaxLifecycleRules {
+		return intErr.NewValidationError(ErrInvalidParameter, LifecycleRules)
+	}
+
+	if len(rules) == 0 {
+
+		in := &s3.DeleteBucketLifecycleInput{}
+		in = in.SetBucket(bucketName)
+
+		if _, err := svc.S3.DeleteBucketLifecycleWithContext(ctx, in); err != nil {
+			return intErr.Wrap(err)
+		}
+
+		return nil
+
+	}
+
+	sdkRules := make([]*s3.LifecycleRule, 0, len(rules))
+	for _, r := range rules {
+
+		sdkRule, err := r.sdkRule()
+		if err != nil {
+			return err
+		}
+
+		sdkRules = append(sdkRules, sdkRule)
+
+	}
+
+	in := &s3.PutBucketLifecycleConfigurationInput{}
+	in = in.SetBucket(bucketName)
+	in = in.SetLifecycleConfiguration((&s3.BucketLifecycleConfiguration{}).SetRules(sdkRules))
+
+	if _, err := svc.S3.PutBucketLifecycleConfigurationWithContext(ctx, in); err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
+
+// S3GetBucketCORS returns the CORS rules of bucketName, empty when it has none
+func (svc *S3) S3GetBucketCORS(bucketName string) ([]CORSRule, error) {
+	return svc.S3GetBucketCORSWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketCORSWithContext is the same as S3GetBucketCORS with the addition of a context.Context
+func (svc *S3) S3GetBucketCORSWithContext(ctx context.Context, bucketName string) ([]CORSRule, error) {
+
+	if bucketName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+
+	in := &s3.GetBucketCorsInput{}
+	in = in.SetBucket(bucketName)
+
+	out, err := svc.S3.GetBucketCorsWithContext(ctx, in)
+	if isErrCode(err, errCodeNoSuchCORSConfiguration) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	rules := make([]CORSRule, 0, len(out.CORSRules))
+	for _, r := range out.CORSRules {
+		rules = append(rules, CORSRule{
+			AllowedOrigins: stringValues(r.AllowedOrigins),
+			AllowedMethods: stringValues(r.AllowedMethods),
+			AllowedHeaders: stringValues(r.AllowedHeaders),
+			ExposeHeaders:  stringValues(r.ExposeHeaders),
+			MaxAge:         time.Duration(aws.Int64Value(r.MaxAgeSeconds)) * time.Second,
+		})
+	}
+
+	return rules, nil
+
+}
+
+// S3PutBucketCORS replaces the CORS rules of bucketName with rules, which has at most MaxCORSRules rules.
+// Every rule needs AllowedOrigins and AllowedMethods. Empty rules remove the CORS configuration
+func (svc *S3) S3PutBucketCORS(bucketName string, rules []CORSRule) error {
+	return svc.S3PutBucketCORSWithContext(context.Background(), bucketName, rules)
+}
+
+// S3PutBucketCORSWithContext is the same as S3PutBucketCORS with the addition of a context.Context
+func (svc *S3) S3PutBucketCORSWithContext(ctx context.Context, bucketName string, rules []CORSRule) error {
+
+	if bucketName == "" {
+		return intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+	if len(rules) > MaxCORSRules {
+		return intErr.NewValidationError(ErrInvalidParameter, CORSRules)
+	}
+
+	if len(rules) == 0 {
+
+		in := &s3.DeleteBucketCorsInput{}
+		in = in.SetBucket(bucketName)
+
+		if _, err := svc.S3.DeleteBucketCorsWithContext(ctx, in); err != nil {
+			return intErr.Wrap(err)
+		}
+
+		return nil
+
+	}
+
+	sdkRules := make([]*s3.CORSRule, 0, len(rules))
+	for _, r := range rules {
+
+		if len(r.AllowedOrigins) == 0 || len(r.AllowedMethods) == 0 {
+			return intErr.NewValidationError(ErrEmptyParameter, CORSRules)
+		}
+		if r.MaxAge < 0 {
+			return intErr.NewValidationError(ErrInvalidParameter, CORSRules)
+		}
+
+		sdkRule := &s3.CORSRule{}
+		sdkRule = sdkRule.SetAllowedOrigins(aws.StringSlice(r.AllowedOrigins))
+		sdkRule = sdkRule.SetAllowedMethods(aws.StringSlice(r.AllowedMethods))
+
+		if len(r.AllowedHeaders) > 0 {
+			sdkRule = sdkRule.SetAllowedHeaders(aws.StringSlice(r.AllowedHeaders))
+		}
+		if len(r.ExposeHeaders) > 0 {
+			sdkRule = sdkRule.SetExposeHeaders(aws.StringSlice(r.ExposeHeaders))
+		}
+		if r.MaxAge > 0 {
+			sdkRule = sdkRule.SetMaxAgeSeconds(int64(r.MaxAge / time.Second))
+		}
+
+		sdkRules = append(sdkRules, sdkRule)
+
+	}
+
+	in := &s3.PutBucketCorsInput{}
+	in = in.SetBucket(bucketName)
+	in = in.SetCORSConfiguration((&s3.CORSConfiguration{}).SetCORSRules(sdkRules))
+
+	if _, err := svc.S3.PutBucketCorsWithContext(ctx, in); err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
+
+// S3GetPublicAccessBlock returns the public access block of bucketName, nil when it has none
+func (svc *S3) S3GetPublicAccessBlock(bucketName string) (*PublicAccessBlock, error) {
+	return svc.S3GetPublicAccessBlockWithContext(context.Background(), bucketName)
+}
+
+// S3GetPublicAccessBlockWithContext is the same as S3GetPublicAccessBlock with the addition of a context.Context
+func (svc *S3) S3GetPublicAccessBlockWithContext(ctx context.Context, bucketName string) (*PublicAccessBlock, error) {
+
+	if bucketName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+
+	in := &s3.GetPublicAccessBlockInput{}
+	in = in.SetBucket(bucketName)
+
+	out, err := svc.S3.GetPublicAccessBlockWithContext(ctx, in)
+	if isErrCode(err, errCodeNoSuchPublicAccessBlock) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	block := &PublicAccessBlock{}
+	if c := out.PublicAccessBlockConfiguration; c != nil {
+		block.BlockPublicACLs = aws.BoolValue(c.BlockPublicAcls)
+		block.IgnorePublicACLs = aws.BoolValue(c.IgnorePublicAcls)
+		block.BlockPublicPolicy = aws.BoolValue(c.BlockPublicPolicy)
+		block.RestrictPublicBuckets = aws.BoolValue(c.RestrictPublicBuckets)
+	}
+
+	return block, nil
+
+}
+
+// S3PutPublicAccessBlock replaces the public access block of bucketName with block. A nil block removes it
+func (svc *S3) S3PutPublicAccessBlock(bucketName string, block *PublicAccessBlock) error {
+	return svc.S3PutPublicAccessBlockWithContext(context.Background(), bucketName, block)
+}
+
+// S3PutPublicAccessBlockWithContext is the same as S3PutPublicAccessBlock with the addition of a context.Context
+func (svc *S3) S3PutPublicAccessBlockWithContext(ctx context.Context, bucketName string, block *PublicAccessBlock) error {
+
+	if bucketName == "" {
+		return intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+
+	if block == nil {
+
+		in := &s3.DeletePublicAccessBlockInput{}
+		in = in.SetBucket(bucketName)
+
+		if _, err := svc.S3.DeletePublicAccessBlockWithContext(ctx, in); err != nil {
+			return intErr.Wrap(err)
+		}
+
+		return nil
+
+	}
+
+	config := &s3.PublicAccessBlockConfiguration{}
+	config = config.SetBlockPublicAcls(block.BlockPublicACLs)
+	config = config.SetIgnorePublicAcls(block.IgnorePublicACLs)
+	config = config.SetBlockPublicPolicy(block.BlockPublicPolicy)
+	config = config.SetRestrictPublicBuckets(block.RestrictPublicBuckets)
+
+	in := &s3.PutPublicAccessBlockInput{}
+	in = in.SetBucket(bucketName)
+	in = in.SetPublicAccessBlockConfiguration(config)
+
+	if _, err := svc.S3.PutPublicAccessBlockWithContext(ctx, in); err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
+
+// S3GetBucketPolicy returns the JSON policy of bucketName, empty when it has none
+func (svc *S3) S3GetBucketPolicy(bucketName string) (string, error) {
+	return svc.S3GetBucketPolicyWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketPolicyWithContext is the same as S3GetBucketPolicy with the addition of a context.Context
+func (svc *S3) S3GetBucketPolicyWithContext(ctx context.Context, bucketName string) (string, error) {
+
+	if bucketName == "" {
+		return "", intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+
+	in := &s3.GetBucketPolicyInput{}
+	in = in.SetBucket(bucketName)
+
+	out, err := svc.S3.GetBucketPolicyWithContext(ctx, in)
+	if isErrCode(err, errCodeNoSuchBucketPolicy) {
+		return "", nil
+	}
+	if err != nil {
+		return "", intErr.Wrap(err)
+	}
+
+	return aws.StringValue(out.Policy), nil
+
+}
+
+// S3PutBucketPolicy replaces the policy of bucketName with the JSON document policy. An empty policy removes it
+func (svc *S3) S3PutBucketPolicy(bucketName, policy string) error {
+	return svc.S3PutBucketPolicyWithContext(context.Background(), bucketName, policy)
+}
+
+// S3PutBucketPolicyWithContext is the same as S3PutBucketPolicy with the addition of a context.Context
+func (svc *S3) S3PutBucketPolicyWithContext(ctx context.Context, bucketName, policy string) error {
+
+	if bucketName == "" {
+		return intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+
+	if policy == "" {
+
+		in := &s3.DeleteBucketPolicyInput{}
+		in = in.SetBucket(bucketName)
+
+		if _, err := svc.S3.DeleteBucketPolicyWithContext(ctx, in); err != nil {
+			return intErr.Wrap(err)
+		}
+
+		return nil
+
+	}
+
+	if !json.Valid([]byte(policy)) {
+		return intErr.NewValidationError(ErrInvalidParameter, Policy)
+	}
+
+	in := &s3.PutBucketPolicyInput{}
+	in = in.SetBucket(bucketName)
+	in = in.SetPolicy(policy)
+
+	if _, err := svc.S3.PutBucketPolicyWithContext(ctx, in); err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
+
+// S3GetBucketEncryption returns the default encryption of bucketName, nil when it has none
+func (svc *S3) S3GetBucketEncryption(bucketName string) (*BucketEncryption, error) {
+	return svc.S3GetBucketEncryptionWithContext(context.Background(), bucketName)
+}
+
+// S3GetBucketEncryptionWithContext is the same as S3GetBucketEncryption with the addition of a context.Context
+func (svc *S3) S3GetBucketEncryptionWithContext(ctx context.Context, bucketName string) (*BucketEncryption, error) {
+
+	if bucketName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+
+	in := &s3.GetBucketEncryptionInput{}
+	in = in.SetBucket(bucketName)
+
+	out, err := svc.S3.GetBucketEncryptionWithContext(ctx, in)
+	if isErrCode(err, errCodeNoSuchEncryption) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	if out.ServerSideEncryptionConfiguration == nil {
+		return nil, nil
+	}
+
+	for _, r := range out.ServerSideEncryptionConfiguration.Rules {
+		if d := r.ApplyServerSideEncryptionByDefault; d != nil {
+			return &BucketEncryption{
+				Algorithm: aws.StringValue(d.SSEAlgorithm),
+				KMSKeyID:  aws.StringValue(d.KMSMasterKeyID),
+			}, nil
+		}
+	}
+
+	return nil, nil
+
+}
+
+// S3PutBucketEncryption replaces the default encryption of bucketName with enc. A nil enc removes it
+func (svc *S3) S3PutBucketEncryption(bucketName string, enc *BucketEncryption) error {
+	return svc.S3PutBucketEncryptionWithContext(context.Background(), bucketName, enc)
+}
+
+// S3PutBucketEncryptionWithContext is the same as S3PutBucketEncryption with the addition of a context.Context
+func (svc *S3) S3PutBucketEncryptionWithContext(ctx context.Context, bucketName string, enc *BucketEncryption) error {
+
+	if bucketName == "" {
+		return intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+
+	if enc == nil {
+
+		in := &s3.DeleteBucketEncryptionInput{}
+		in = in.SetBucket(bucketName)
+
+		if _, err := svc.S3.DeleteBucketEncryptionWithContext(ctx, in); err != nil {
+			return intErr.Wrap(err)
+		}
+
+		return nil
+
+	}
+
+	switch {
+	case enc.Algorithm != s3.ServerSideEncryptionAes256 && enc.Algorithm != s3.ServerSideEncryptionAwsKms:
+		return intErr.NewValidationError(ErrInvalidParameter, ServerSideEncryption)
+	case enc.Algorithm == s3.ServerSideEncryptionAes256 && enc.KMSKeyID != "":
+		return intErr.NewValidationError(ErrInvalidParameter, KeyID)
+	}
+
+	def := &s3.ServerSideEncryptionByDefault{}
+	def = def.SetSSEAlgorithm(enc.Algorithm)
+
+	if enc.KMSKeyID != "" {
+		def = def.SetKMSMasterKeyID(enc.KMSKeyID)
+	}
+
+	rule := (&s3.ServerSideEncryptionRule{}).SetApplyServerSideEncryptionByDefault(def)
+
+	in := &s3.PutBucketEncryptionInput{}
+	in = in.SetBucket(bucketName)
+	in = in.SetServerSideEncryptionConfiguration((&s3.ServerSideEncryptionConfiguration{}).SetRules([]*s3.ServerSideEncryptionRule{rule}))
+
+	if _, err := svc.S3.PutBucketEncryptionWithContext(ctx, in); err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
+
+// sdkRule validates r and returns it as an *s3.LifecycleRule
+func (r LifecycleRule) sdkRule() (*s3.LifecycleRule, error) {
+
+	if r.ExpirationDays == 0 && r.NoncurrentExpirationDays == 0 && r.AbortIncompleteUploadDays == 0 && len(r.Transitions) == 0 {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, LifecycleRules)
+	}
+	if r.ExpirationDays < 0 || r.NoncurrentExpirationDays < 0 || r.AbortIncompleteUploadDays < 0 {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, LifecycleRules)
+	}
+
+	status := s3.ExpirationStatusDisabled
+	if r.Enabled {
+		status = s3.ExpirationStatusEnabled
+	}
+
+	out := &s3.LifecycleRule{}
+	out = out.SetStatus(status)
+	out = out.SetFilter(r.filter())
+
+	if r.ID != "" {
+		out = out.SetID(r.ID)
+	}
+	if r.ExpirationDays > 0 {
+		out = out.SetExpiration((&s3.LifecycleExpiration{}).SetDays(r.ExpirationDays))
+	}
+	if r.NoncurrentExpirationDays > 0 {
+		out = out.SetNoncurrentVersionExpiration((&s3.NoncurrentVersionExpiration{}).SetNoncurrentDays(r.NoncurrentExpirationDays))
+	}
+	if r.AbortIncompleteUploadDays > 0 {
+		out = out.SetAbortIncompleteMultipartUpload((&s3.AbortIncompleteMultipartUpload{}).SetDaysAfterInitiation(r.AbortIncompleteUploadDays))
+	}
+
+	for _, t := range r.Transitions {
+
+		if t.StorageClass == "" {
+			return nil, intErr.NewValidationError(ErrEmptyParameter, LifecycleRules)
+		}
+		if t.Days < 0 {
+			return nil, intErr.NewValidationError(ErrInvalidParameter, LifecycleRules)
+		}
+
+		out.Transitions = append(out.Transitions, (&s3.Transition{}).SetDays(t.Days).SetStorageClass(t.StorageClass))
+
+	}
+
+	return out, nil
+
+}
+
+// filter returns the *s3.LifecycleRuleFilter selecting the objects of r
+func (r LifecycleRule) filter() *s3.LifecycleRuleFilter {
+
+	filter := &s3.LifecycleRuleFilter{}
+
+	keys := make([]string, 0, len(r.Tags))
+	for k := range r.Tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	tags := make([]*s3.Tag, 0, len(keys))
+	for _, k := range keys {
+		tags = append(tags, (&s3.Tag{}).SetKey(k).SetValue(r.Tags[k]))
+	}
+
+	switch {
+	case len(tags) == 0:
+		return filter.SetPrefix(r.Prefix)
+	case len(tags) == 1 && r.Prefix == "":
+		return filter.SetTag(tags[0])
+	}
+
+	and := &s3.LifecycleRuleAndOperator{}
+	and = and.SetTags(tags)
+
+	if r.Prefix != "" {
+		and = and.SetPrefix(r.Prefix)
+	}
+
+	return filter.SetAnd(and)
+
+}
+
+// lifecycleRule returns the LifecycleRule matching r
+func lifecycleRule(r *s3.LifecycleRule) LifecycleRule {
+
+	out := LifecycleRule{
+		ID:      aws.StringValue(r.ID),
+		Enabled: aws.StringValue(r.Status) == s3.ExpirationStatusEnabled,
+		Prefix:  aws.StringValue(r.Prefix),
+	}
+
+	if f := r.Filter; f != nil {
+
+		var tags []*s3.Tag
+
+		switch {
+		case f.And != nil:
+			out.Prefix = aws.StringValue(f.And.Prefix)
+			tags = f.And.Tags
+		case f.Tag != nil:
+			tags = []*s3.Tag{f.Tag}
+		default:
+			out.Prefix = aws.StringValue(f.Prefix)
+		}
+
+		if len(tags) > 0 {
+			out.Tags = make(map[string]string, len(tags))
+			for _, t := range tags {
+				out.Tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+			}
+		}
+
+	}
+
+	if r.Expiration != nil {
+		out.ExpirationDays = aws.Int64Value(r.Expiration.Days)
+	}
+	if r.NoncurrentVersionExpiration != nil {
+		out.NoncurrentExpirationDays = aws.Int64Value(r.NoncurrentVersionExpiration.NoncurrentDays)
+	}
+	if r.AbortIncompleteMultipartUpload != nil {
+		out.AbortIncompleteUploadDays = aws.Int64Value(r.AbortIncompleteMultipartUpload.DaysAfterInitiation)
+	}
+
+	for _, t := range r.Transitions {
+		out.Transitions = append(out.Transitions, LifecycleTransition{
+			Days:         aws.Int64Value(t.Days),
+			StorageClass: aws.StringValue(t.StorageClass),
+		})
+	}
+
+	return out
+
+}
+
+// stringValues returns the values of s, nil when empty
+func stringValues(s []*string) []string {
+
+	if len(s) == 0 {
+		return nil
+	}
+
+	return aws.StringValueSlice(s)
+
+}
+
+// isErrCode reports whether err is an aws error of code
+func isErrCode(err error, code string) bool {
+
+	awsErr, ok := err.(awserr.Error)
+
+	return ok && awsErr.Code() == code
+
+}
@@ -0,0 +1,346 @@
+package s3
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestS3_S3CreateBucket_Location(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	assert.NoError(t, s3Svc.S3CreateBucket(cfg.S3.Bucket))
+
+	location, err := s3Svc.S3.GetBucketLocation((&s3.GetBucketLocationInput{}).SetBucket(cfg.S3.Bucket))
+
+	assert.NoError(t, err)
+	assert.Equal(t, cfg.Region, aws.StringValue(location.LocationConstraint))
+
+	// buckets already owned are created
+	assert.NoError(t, s3Svc.S3CreateBucket(cfg.S3.Bucket))
+
+	assert.NoError(t, s3Svc.S3CreateBucket("other_bucket", WithLocationConstraint("us-east-1")))
+
+	location, err = s3Svc.S3.GetBucketLocation((&s3.GetBucketLocationInput{}).SetBucket("other_bucket"))
+
+	assert.NoError(t, err)
+	assert.Empty(t, aws.StringValue(location.LocationConstraint))
+
+	err = s3Svc.S3CreateBucket("some_bucket", WithLocationConstraint(""))
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrEmptyParameter, LocationConstraint)))
+
+	_, err = s3Svc.S3.HeadBucket((&s3.HeadBucketInput{}).SetBucket("some_bucket"))
+
+	assert.Error(t, err)
+
+}
+
+func TestS3_S3PutBucketVersioning(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	status, err := s3Svc.S3GetBucketVersioning(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Equal(t, VersioningUnversioned, status)
+
+	assert.NoError(t, s3Svc.S3PutBucketVersioning(cfg.S3.Bucket, VersioningEnabled))
+
+	status, err = s3Svc.S3GetBucketVersioning(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Equal(t, VersioningEnabled, status)
+
+	assert.NoError(t, s3Svc.S3PutBucketVersioning(cfg.S3.Bucket, VersioningSuspended))
+
+	status, _ = s3Svc.S3GetBucketVersioning(cfg.S3.Bucket)
+
+	assert.Equal(t, VersioningSuspended, status)
+
+	err = s3Svc.S3PutBucketVersioning(cfg.S3.Bucket, VersioningUnversioned)
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrInvalidParameter, Versioning)))
+
+	_, err = s3Svc.S3GetBucketVersioning("some_missing_bucket")
+
+	assert.Error(t, err)
+
+}
+
+func TestS3_S3PutBucketLifecycle(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	rules, err := s3Svc.S3GetBucketLifecycle(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+
+	in := []LifecycleRule{
+		{
+			ID:                        "expire-tmp",
+			Enabled:                   true,
+			Prefix:                    "tmp/",
+			ExpirationDays:            7,
+			AbortIncompleteUploadDays: 1,
+		},
+		{
+			ID:      "archive-logs",
+			Enabled: true,
+			Prefix:  "logs/",
+			Tags:    map[string]string{"archive": "true", "team": "some_team"},
+			Transitions: []LifecycleTransition{
+				{Days: 30, StorageClass: s3.TransitionStorageClassStandardIa},
+				{Days: 90, StorageClass: s3.TransitionStorageClassGlacier},
+			},
+		},
+		{
+			ID:                       "noncurrent",
+			Tags:                     map[string]string{"some": "tag"},
+			NoncurrentExpirationDays: 30,
+		},
+	}
+
+	assert.NoError(t, s3Svc.S3PutBucketLifecycle(cfg.S3.Bucket, in))
+
+	rules, err = s3Svc.S3GetBucketLifecycle(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Equal(t, in, rules)
+
+	assert.NoError(t, s3Svc.S3PutBucketLifecycle(cfg.S3.Bucket, nil))
+
+	rules, err = s3Svc.S3GetBucketLifecycle(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+
+	for _, rule := range []LifecycleRule{
+		{ID: "no_action"},
+		{ExpirationDays: -1},
+		{Transitions: []LifecycleTransition{{Days: 30}}},
+	} {
+
+		err := s3Svc.S3PutBucketLifecycle(cfg.S3.Bucket, []LifecycleRule{rule})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), LifecycleRules)
+
+	}
+
+	err = s3Svc.S3PutBucketLifecycle(cfg.S3.Bucket, make([]LifecycleRule, MaxLifecycleRules+1))
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrInvalidParameter, LifecycleRules)))
+
+}
+
+func TestS3_S3PutBucketCORS(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	rules, err := s3Svc.S3GetBucketCORS(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+
+	in := []CORSRule{
+		{
+			AllowedOrigins: []string{"https://example.com"},
+			AllowedMethods: []string{"GET", "PUT"},
+			AllowedHeaders: []string{"*"},
+			ExposeHeaders:  []string{"ETag"},
+			MaxAge:         time.Hour,
+		},
+		{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"GET"},
+		},
+	}
+
+	assert.NoError(t, s3Svc.S3PutBucketCORS(cfg.S3.Bucket, in))
+
+	rules, err = s3Svc.S3GetBucketCORS(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Equal(t, in, rules)
+
+	assert.NoError(t, s3Svc.S3PutBucketCORS(cfg.S3.Bucket, nil))
+
+	rules, err = s3Svc.S3GetBucketCORS(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+
+	err = s3Svc.S3PutBucketCORS(cfg.S3.Bucket, []CORSRule{{AllowedOrigins: []string{"*"}}})
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrEmptyParameter, CORSRules)))
+
+	err = s3Svc.S3PutBucketCORS(cfg.S3.Bucket, make([]CORSRule, MaxCORSRules+1))
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrInvalidParameter, CORSRules)))
+
+}
+
+func TestS3_S3PutPublicAccessBlock(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	block, err := s3Svc.S3GetPublicAccessBlock(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Nil(t, block)
+
+	in := &PublicAccessBlock{BlockPublicACLs: true, BlockPublicPolicy: true, RestrictPublicBuckets: true}
+
+	assert.NoError(t, s3Svc.S3PutPublicAccessBlock(cfg.S3.Bucket, in))
+
+	block, err = s3Svc.S3GetPublicAccessBlock(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Equal(t, in, block)
+
+	assert.NoError(t, s3Svc.S3PutPublicAccessBlock(cfg.S3.Bucket, nil))
+
+	block, err = s3Svc.S3GetPublicAccessBlock(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Nil(t, block)
+
+}
+
+func TestS3_S3PutBucketPolicy(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	policy, err := s3Svc.S3GetBucketPolicy(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Empty(t, policy)
+
+	in := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::some_bucket/*"}]}`
+
+	assert.NoError(t, s3Svc.S3PutBucketPolicy(cfg.S3.Bucket, in))
+
+	policy, err = s3Svc.S3GetBucketPolicy(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, in, policy)
+
+	assert.NoError(t, s3Svc.S3PutBucketPolicy(cfg.S3.Bucket, ""))
+
+	policy, err = s3Svc.S3GetBucketPolicy(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Empty(t, policy)
+
+	err = s3Svc.S3PutBucketPolicy(cfg.S3.Bucket, "some_policy")
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrInvalidParameter, Policy)))
+
+}
+
+func TestS3_S3PutBucketEncryption(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	enc, err := s3Svc.S3GetBucketEncryption(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Nil(t, enc)
+
+	for _, in := range []*BucketEncryption{
+		{Algorithm: s3.ServerSideEncryptionAes256},
+		{Algorithm: s3.ServerSideEncryptionAwsKms},
+		{Algorithm: s3.ServerSideEncryptionAwsKms, KMSKeyID: "alias/some-key"},
+	} {
+
+		assert.NoError(t, s3Svc.S3PutBucketEncryption(cfg.S3.Bucket, in))
+
+		enc, err = s3Svc.S3GetBucketEncryption(cfg.S3.Bucket)
+
+		assert.NoError(t, err)
+		assert.Equal(t, in, enc)
+
+	}
+
+	assert.NoError(t, s3Svc.S3PutBucketEncryption(cfg.S3.Bucket, nil))
+
+	enc, err = s3Svc.S3GetBucketEncryption(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Nil(t, enc)
+
+	err = s3Svc.S3PutBucketEncryption(cfg.S3.Bucket, &BucketEncryption{Algorithm: "some_algorithm"})
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrInvalidParameter, ServerSideEncryption)))
+
+	err = s3Svc.S3PutBucketEncryption(cfg.S3.Bucket, &BucketEncryption{Algorithm: s3.ServerSideEncryptionAes256, KMSKeyID: "some_key"})
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrInvalidParameter, KeyID)))
+
+}
+
+func TestS3_BucketValidation(t *testing.T) {
+
+	svc := &S3{}
+
+	for _, f := range []func() error{
+		func() error { _, err := svc.S3GetBucketVersioning(""); return err },
+		func() error { return svc.S3PutBucketVersioning("", VersioningEnabled) },
+		func() error { _, err := svc.S3GetBucketLifecycle(""); return err },
+		func() error { return svc.S3PutBucketLifecycle("", nil) },
+		func() error { _, err := svc.S3GetBucketCORS(""); return err },
+		func() error { return svc.S3PutBucketCORS("", nil) },
+		func() error { _, err := svc.S3GetPublicAccessBlock(""); return err },
+		func() error { return svc.S3PutPublicAccessBlock("", nil) },
+		func() error { _, err := svc.S3GetBucketPolicy(""); return err },
+		func() error { return svc.S3PutBucketPolicy("", "") },
+		func() error { _, err := svc.S3GetBucketEncryption(""); return err },
+		func() error { return svc.S3PutBucketEncryption("", nil) },
+	} {
+		assert.True(t, errors.Is(f(), intErr.NewValidationError(ErrEmptyParameter, BucketName)))
+	}
+
+}
@@ -0,0 +1,479 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+// MaxCopyObjectSize is the size of the largest object CopyObject can copy at once
+const MaxCopyObjectSize = MaxPartSize
+
+// CopyInput contains the optional parameters of S3Copy and S3Move
+type CopyInput struct {
+	metadata        map[string]string
+	replaceMetadata bool
+	tags            map[string]string
+	replaceTags     bool
+	partSize        int64
+	concurrency     int
+	encryption      *Encryption
+	srcEncryption   *Encryption
+	keepEncryption  bool
+}
+
+// CopyOption sets an optional parameter on a *CopyInput
+type CopyOption func(*CopyInput) error
+
+// WithReplaceMetadata replaces the user metadata of the copy with metadata, instead of
+// keeping the one of the source. The system metadata, like the content type, is kept
+func WithReplaceMetadata(metadata map[string]string) CopyOption {
+	return func(in *CopyInput) error {
+
+		for k := range metadata {
+			if k == "" {
+				return intErr.NewValidationError(ErrEmptyParameter, Metadata)
+			}
+		}
+
+		in.metadata = metadata
+		in.replaceMetadata = true
+
+		return nil
+
+	}
+}
+
+// WithReplaceTags replaces the tags of the copy with tags, instead of keeping the ones
+// of the source. An empty map removes every tag
+func WithReplaceTags(tags map[string]string) CopyOption {
+	return func(in *CopyInput) error {
+
+		for k := range tags {
+			if k == "" {
+				return intErr.NewValidationError(ErrEmptyParameter, Tags)
+			}
+		}
+
+		in.tags = tags
+		in.replaceTags = true
+
+		return nil
+
+	}
+}
+
+// WithCopyPartSize sets the size above which objects are copied with a multipart upload,
+// in parts of partSize. It is MaxCopyObjectSize by default, the largest size CopyObject accepts
+func WithCopyPartSize(partSize int64) CopyOption {
+	return func(in *CopyInput) error {
+
+		if partSize < MinPartSize || partSize > MaxCopyObjectSize {
+			return intErr.NewValidationError(ErrInvalidParameter, PartSize)
+		}
+
+		in.partSize = partSize
+
+		return nil
+
+	}
+}
+
+// WithCopyConcurrency sets how many parts of a multipart copy are copied at the same time, DefaultConcurrency by default
+func WithCopyConcurrency(concurrency int) CopyOption {
+	return func(in *CopyInput) error {
+
+		if concurrency < 1 {
+			return intErr.NewValidationError(ErrInvalidParameter, Concurrency)
+		}
+
+		in.concurrency = concurrency
+
+		return nil
+
+	}
+}
+
+// WithCopyEncryption encrypts the copy as set by opts. The encryption of the source is
+// never copied: without this option, the copy gets the default encryption of its bucket
+func WithCopyEncryption(opts ...EncryptionOption) CopyOption {
+	return func(in *CopyInput) error {
+
+		enc, err := newEncryption(opts...)
+		if err != nil {
+			return err
+		}
+
+		in.encryption = enc
+
+		return nil
+
+	}
+}
+
+// WithCopySourceEncryption sets the key of a source encrypted with SSE-C.
+// Only WithSSEC applies, like for downloads
+func WithCopySourceEncryption(opts ...EncryptionOption) CopyOption {
+	return func(in *CopyInput) error {
+
+		enc, err := newReadEncryption(opts...)
+		if err != nil {
+			return err
+		}
+
+		in.srcEncryption = enc
+
+		return nil
+
+	}
+}
+
+// S3Copy copies srcObjectName from srcBucketName to dstObjectName in dstBucketName, which can be
+// the same bucket. Objects up to MaxCopyObjectSize are copied with CopyObject, larger ones with
+// a multipart upload of UploadPartCopy parts. Metadata and tags are kept unless replaced.
+// The copy fails with ErrObjectChanged if the source is modified while being copied
+func (svc *S3) S3Copy(srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...CopyOption) error {
+	return svc.S3CopyWithContext(context.Background(), srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts...)
+}
+
+// S3CopyWithContext is the same as S3Copy with the addition of a context.Context
+func (svc *S3) S3CopyWithContext(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...CopyOption) error {
+
+	in, err := newCopyInput(srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts...)
+	if err != nil {
+		return err
+	}
+
+	_, err = svc.copyObject(ctx, in, srcBucketName, srcObjectName, dstBucketName, dstObjectName)
+
+	return err
+
+}
+
+// S3Move moves srcObjectName from srcBucketName to dstObjectName in dstBucketName like S3Copy,
+// then deletes the source once the copy has been verified. A copy made with a single CopyObject
+// must have the ETag of the source, unless either of them is encrypted with SSE-KMS or SSE-C or
+// the source is a multipart upload, whose ETags are not the MD5 of the body. Only the size is
+// compared for those and for multipart copies. A failed copy leaves the source in place, a failed
+// verification returns a *CopyNotVerifiedError and leaves the source in place too
+func (svc *S3) S3Move(srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...CopyOption) error {
+	return svc.S3MoveWithContext(context.Background(), srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts...)
+}
+
+// S3MoveWithContext is the same as S3Move with the addition of a context.Context
+func (svc *S3) S3MoveWithContext(ctx context.Context, srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...CopyOption) error {
+
+	in, err := newCopyInput(srcBucketName, srcObjectName, dstBucketName, dstObjectName, opts...)
+	if err != nil {
+		return err
+	}
+
+	if srcBucketName == dstBucketName && srcObjectName == dstObjectName {
+		return intErr.NewValidationError(ErrInvalidParameter, ObjectName)
+	}
+
+	src, err := svc.copyObject(ctx, in, srcBucketName, srcObjectName, dstBucketName, dstObjectName)
+	if err != nil {
+		return err
+	}
+
+	head := &s3.HeadObjectInput{}
+	head = head.SetBucket(dstBucketName)
+	head = head.SetKey(dstObjectName)
+
+	in.encryption.headObject(head)
+
+	dst, err := svc.S3.HeadObjectWithContext(ctx, head)
+	if err != nil {
+		return intErr.Wrap(err)
+	}
+
+	if aws.Int64Value(dst.ContentLength) != aws.Int64Value(src.ContentLength) {
+		return &CopyNotVerifiedError{BucketName: dstBucketName, ObjectName: dstObjectName, Field: ContentLength}
+	}
+
+	if !in.multipart(src) && md5ETag(src) && md5ETag(dst) && aws.StringValue(dst.ETag) != aws.StringValue(src.ETag) {
+		return &CopyNotVerifiedError{BucketName: dstBucketName, ObjectName: dstObjectName, Field: ETag}
+	}
+
+	return svc.S3DeleteObjectWithContext(ctx, srcBucketName, srcObjectName)
+
+}
+
+// S3ReplaceMetadata replaces the user metadata of objectName in bucketName with metadata by copying
+// the object onto itself, so that its body is never downloaded. An empty map removes every user
+// metadata. The system metadata, tags and server-side encryption of the object are kept, unless
+// replaced with WithReplaceTags and WithCopyEncryption. Objects encrypted with SSE-C need their key
+// set with WithCopySourceEncryption, which is kept for the copy too
+func (svc *S3) S3ReplaceMetadata(bucketName, objectName string, metadata map[string]string, opts ...CopyOption) error {
+	return svc.S3ReplaceMetadataWithContext(context.Background(), bucketName, objectName, metadata, opts...)
+}
+
+// S3ReplaceMetadataWithContext is the same as S3ReplaceMetadata with the addition of a context.Context
+func (svc *S3) S3ReplaceMetadataWithContext(ctx context.Context, bucketName, objectName string, metadata map[string]string, opts ...CopyOption) error {
+
+	in, err := newCopyInput(bucketName, objectName, bucketName, objectName, append(opts, WithReplaceMetadata(metadata))...)
+	if err != nil {
+		return err
+	}
+
+	in.keepEncryption = true
+
+	_, err = svc.copyObject(ctx, in, bucketName, objectName, bucketName, objectName)
+
+	return err
+
+}
+
+// newCopyInput validates the parameters shared by every copy and returns a new *CopyInput
+func newCopyInput(srcBucketName, srcObjectName, dstBucketName, dstObjectName string, opts ...CopyOption) (*CopyInput, error) {
+
+	if srcBucketName == "" || dstBucketName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+	if srcObjectName == "" || dstObjectName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, ObjectName)
+	}
+
+	in := &CopyInput{
+		partSize:    MaxCopyObjectSize,
+		concurrency: DefaultConcurrency,
+	}
+
+	for _, opt := range opts {
+		if err := opt(in); err != nil {
+			return nil, err
+		}
+	}
+
+	return in, nil
+
+}
+
+// copyObject copies the source object to the destination and returns the metadata of the source
+func (svc *S3) copyObject(ctx context.Context, in *CopyInput, srcBucketName, srcObjectName, dstBucketName, dstObjectName string) (*s3.HeadObjectOutput, error) {
+
+	head := &s3.HeadObjectInput{}
+	head = head.SetBucket(srcBucketName)
+	head = head.SetKey(srcObjectName)
+
+	in.srcEncryption.headObject(head)
+
+	src, err := svc.S3.HeadObjectWithContext(ctx, head)
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	if in.keepEncryption && in.encryption == nil {
+		in.encryption = sourceEncryption(src, in.srcEncryption)
+	}
+
+	if in.multipart(src) {
+		err = svc.copyMultipart(ctx, in, src, srcBucketName, srcObjectName, dstBucketName, dstObjectName)
+	} else {
+		err = svc.copySingle(ctx, in, src, srcBucketName, srcObjectName, dstBucketName, dstObjectName)
+	}
+
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == errCodePreconditionFailed {
+		return nil, intErr.NewValidationError(ErrObjectChanged, ObjectName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return src, nil
+
+}
+
+// multipart reports whether src is copied with copyMultipart rather than copySingle
+func (in *CopyInput) multipart(src *s3.HeadObjectOutput) bool {
+	return aws.Int64Value(src.ContentLength) > in.partSize
+}
+
+// md5ETag reports whether the ETag of the object described by head is the MD5 of its body,
+// which it is not for multipart uploads and objects encrypted with SSE-KMS or SSE-C
+func md5ETag(head *s3.HeadObjectOutput) bool {
+	return !strings.Contains(aws.StringValue(head.ETag), "-") &&
+		aws.StringValue(head.ServerSideEncryption) != s3.ServerSideEncryptionAwsKms &&
+		head.SSECustomerAlgorithm == nil
+}
+
+// copySingle copies src with a single CopyObject
+func (svc *S3) copySingle(ctx context.Context, in *CopyInput, src *s3.HeadObjectOutput, srcBucketName, srcObjectName, dstBucketName, dstObjectName string) error {
+
+	out := &s3.CopyObjectInput{}
+	out = out.SetBucket(dstBucketName)
+	out = out.SetKey(dstObjectName)
+	out = out.SetCopySource(copySource(srcBucketName, srcObjectName))
+	out = out.SetCopySourceIfMatch(aws.StringValue(src.ETag))
+
+	if in.replaceMetadata {
+
+		out = out.SetMetadataDirective(s3.MetadataDirectiveReplace)
+		out = out.SetMetadata(aws.StringMap(in.metadata))
+
+		// REPLACE drops the system metadata too, which is kept like with COPY
+		if src.ContentType != nil {
+			out = out.SetContentType(*src.ContentType)
+		}
+		if src.CacheControl != nil {
+			out = out.SetCacheControl(*src.CacheControl)
+		}
+		if src.ContentDisposition != nil {
+			out = out.SetContentDisposition(*src.ContentDisposition)
+		}
+		if src.ContentEncoding != nil {
+			out = out.SetContentEncoding(*src.ContentEncoding)
+		}
+		if src.ContentLanguage != nil {
+			out = out.SetContentLanguage(*src.ContentLanguage)
+		}
+
+	}
+
+	if in.replaceTags {
+		out = out.SetTaggingDirective(s3.TaggingDirectiveReplace)
+		out = out.SetTagging(encodeTags(in.tags))
+	}
+
+	in.encryption.copyObject(out)
+	in.srcEncryption.copySource(out)
+
+	if _, err := svc.S3.CopyObjectWithContext(ctx, out, in.encryption.requestOptions()...); err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
+
+// copyMultipart copies src with a multipart upload of in.partSize UploadPartCopy parts.
+// Unlike CopyObject, multipart uploads don't copy metadata and tags, which are read from the source
+func (svc *S3) copyMultipart(ctx context.Context, in *CopyInput, src *s3.HeadObjectOutput, srcBucketName, srcObjectName, dstBucketName, dstObjectName string) error {
+
+	size := aws.Int64Value(src.ContentLength)
+
+	if (size+in.partSize-1)/in.partSize > MaxParts {
+		return intErr.NewValidationError(ErrTooManyParts, PartSize)
+	}
+
+	upload := &UploadInput{
+		contentType:          aws.StringValue(src.ContentType),
+		cacheControl:         aws.StringValue(src.CacheControl),
+		contentDisposition:   aws.StringValue(src.ContentDisposition),
+		contentEncoding:      aws.StringValue(src.ContentEncoding),
+		contentLanguage:      aws.StringValue(src.ContentLanguage),
+		metadata:             aws.StringValueMap(src.Metadata),
+		tags:                 in.tags,
+		concurrency:          in.concurrency,
+		partRetries:          DefaultPartRetries,
+		encryption:           in.encryption,
+		copySourceEncryption: in.srcEncryption,
+	}
+
+	if in.replaceMetadata {
+		upload.metadata = in.metadata
+	}
+
+	if !in.replaceTags {
+
+		tagging := &s3.GetObjectTaggingInput{}
+		tagging = tagging.SetBucket(srcBucketName)
+		tagging = tagging.SetKey(srcObjectName)
+
+		out, err := svc.S3.GetObjectTaggingWithContext(ctx, tagging)
+		if err != nil {
+			return intErr.Wrap(err)
+		}
+
+		upload.tags = make(map[string]string, len(out.TagSet))
+		for _, t := range out.TagSet {
+			upload.tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+		}
+
+	}
+
+	u := &multipartUpload{
+		svc: svc,
+		in:  upload,
+		state: &UploadState{
+			Bucket:   dstBucketName,
+			Key:      dstObjectName,
+			Size:     size,
+			PartSize: in.partSize,
+		},
+	}
+
+	if err := u.create(ctx); err != nil {
+		return err
+	}
+
+	source := copySource(srcBucketName, srcObjectName)
+	etag := aws.StringValue(src.ETag)
+
+	return u.upload(ctx, func(ctx context.Context, parts chan<- *partBody) error {
+
+		number := int64(1)
+
+		for start := int64(0); start < size; start += in.partSize {
+
+			end := start + in.partSize
+			if end > size {
+				end = size
+			}
+
+			p := &partBody{
+				number:      number,
+				size:        end - start,
+				copySource:  source,
+				copyRange:   fmt.Sprintf("bytes=%d-%d", start, end-1),
+				copyIfMatch: etag,
+			}
+
+			select {
+			case parts <- p:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			number++
+
+		}
+
+		return nil
+
+	})
+
+}
+
+// copySource returns the URL encoded CopySource of objectName in bucketName
+func copySource(bucketName, objectName string) string {
+	return url.PathEscape(bucketName) + "/" + (&url.URL{Path: objectName}).EscapedPath()
+}
+
+// encodeTags returns tags URL encoded, as expected by the Tagging parameters
+func encodeTags(tags map[string]string) string {
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	values := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, url.QueryEscape(k)+"="+url.QueryEscape(tags[k]))
+	}
+
+	return strings.Join(values, "&")
+
+}
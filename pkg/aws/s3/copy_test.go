@@ -0,0 +1,405 @@
+package s3
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestS3_S3Copy(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+	assert.NoError(t, s3Svc.S3CreateBucket("other_bucket"))
+
+	putTaggedObject(t, s3Svc, cfg.S3.Bucket, "some key", []byte("some_body"))
+
+	assert.NoError(t, s3Svc.S3Copy(cfg.S3.Bucket, "some key", "other_bucket", "some/key"))
+
+	head := headObject(t, s3Svc, "other_bucket", "some/key")
+
+	assert.Equal(t, "text/plain", *head.ContentType)
+	assert.Equal(t, "some_value", *head.Metadata["Some-Meta"])
+
+	tags, _ := srv.Tags("other_bucket", "some/key")
+
+	assert.Equal(t, map[string]string{"some_tag": "some value"}, tags)
+
+	err := s3Svc.S3Copy(cfg.S3.Bucket, "some key", cfg.S3.Bucket, "some key",
+		WithReplaceMetadata(map[string]string{"Other-Meta": "other_value"}),
+		WithReplaceTags(map[string]string{}),
+	)
+
+	assert.NoError(t, err)
+
+	head = headObject(t, s3Svc, cfg.S3.Bucket, "some key")
+
+	assert.Equal(t, "text/plain", *head.ContentType)
+	assert.Nil(t, head.Metadata["Some-Meta"])
+	assert.Equal(t, "other_value", *head.Metadata["Other-Meta"])
+
+	tags, _ = srv.Tags(cfg.S3.Bucket, "some key")
+
+	assert.Empty(t, tags)
+
+	err = s3Svc.S3Copy(cfg.S3.Bucket, "some_missing_key", "other_bucket", "some/key")
+
+	assert.Error(t, err)
+	assert.Equal(t, "NotFound", err.(awserr.Error).Code())
+
+}
+
+func TestS3_S3Copy_Multipart(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	body := bytes.Repeat([]byte("0123456789"), (2*MinPartSize+MinPartSize/2)/10)
+
+	_, err := s3Svc.PutObject(&s3.PutObjectInput{
+		Bucket:          aws.String(cfg.S3.Bucket),
+		Key:             aws.String("some/key"),
+		Body:            bytes.NewReader(body),
+		ContentType:     aws.String("text/plain"),
+		ContentEncoding: aws.String("gzip"),
+		ContentLanguage: aws.String("en-US"),
+		Metadata:        map[string]*string{"Some-Meta": aws.String("some_value")},
+		Tagging:         aws.String(encodeTags(map[string]string{"some_tag": "some value"})),
+	})
+
+	assert.NoError(t, err)
+
+	var parts int32
+
+	s3Svc.Handlers.Send.PushBack(func(r *request.Request) {
+		if _, ok := r.Params.(*s3.UploadPartCopyInput); ok {
+			atomic.AddInt32(&parts, 1)
+		}
+	})
+
+	err = s3Svc.S3Copy(cfg.S3.Bucket, "some/key", cfg.S3.Bucket, "other/key", WithCopyPartSize(MinPartSize), WithCopyConcurrency(2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&parts))
+
+	copied, ok := srv.Object(cfg.S3.Bucket, "other/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, body, copied)
+
+	head := headObject(t, s3Svc, cfg.S3.Bucket, "other/key")
+
+	assert.Equal(t, "text/plain", *head.ContentType)
+	assert.Equal(t, "gzip", aws.StringValue(head.ContentEncoding))
+	assert.Equal(t, "en-US", aws.StringValue(head.ContentLanguage))
+	assert.Equal(t, "some_value", *head.Metadata["Some-Meta"])
+
+	tags, _ := srv.Tags(cfg.S3.Bucket, "other/key")
+
+	assert.Equal(t, map[string]string{"some_tag": "some value"}, tags)
+
+	err = s3Svc.S3Copy(cfg.S3.Bucket, "some/key", cfg.S3.Bucket, "other/key",
+		WithCopyPartSize(MinPartSize),
+		WithReplaceMetadata(nil),
+		WithReplaceTags(map[string]string{"other_tag": "other_value"}),
+	)
+
+	assert.NoError(t, err)
+
+	head = headObject(t, s3Svc, cfg.S3.Bucket, "other/key")
+
+	assert.Empty(t, head.Metadata)
+	assert.Equal(t, "gzip", aws.StringValue(head.ContentEncoding))
+	assert.Equal(t, "en-US", aws.StringValue(head.ContentLanguage))
+
+	tags, _ = srv.Tags(cfg.S3.Bucket, "other/key")
+
+	assert.Equal(t, map[string]string{"other_tag": "other_value"}, tags)
+	assert.Empty(t, srv.Uploads(cfg.S3.Bucket))
+
+}
+
+func TestS3_S3Copy_ObjectChanged(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	body := bytes.Repeat([]byte("0123456789"), MinPartSize/5)
+
+	for _, opt := range []CopyOption{WithCopyPartSize(MaxCopyObjectSize), WithCopyPartSize(MinPartSize)} {
+
+		assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/key", bytes.NewReader(body)))
+
+		s3Svc.Handlers.Send.PushFrontNamed(request.NamedHandler{
+			Name: "test.ChangeSource",
+			Fn: func(r *request.Request) {
+				if r.Operation.Name == "CopyObject" || r.Operation.Name == "CreateMultipartUpload" {
+					_, err := s3Svc.PutObject(&s3.PutObjectInput{
+						Bucket: aws.String(cfg.S3.Bucket),
+						Key:    aws.String("some/key"),
+						Body:   strings.NewReader("some_other_body"),
+					})
+					assert.NoError(t, err)
+				}
+			},
+		})
+
+		err := s3Svc.S3Copy(cfg.S3.Bucket, "some/key", cfg.S3.Bucket, "other/key", opt, WithCopyConcurrency(1))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), ErrObjectChanged)
+		assert.Empty(t, srv.Uploads(cfg.S3.Bucket))
+
+		s3Svc.Handlers.Send.RemoveByName("test.ChangeSource")
+
+	}
+
+}
+
+func TestS3_S3Move(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+	assert.NoError(t, s3Svc.S3CreateBucket("other_bucket"))
+
+	putTaggedObject(t, s3Svc, cfg.S3.Bucket, "some/key", []byte("some_body"))
+
+	assert.NoError(t, s3Svc.S3Move(cfg.S3.Bucket, "some/key", "other_bucket", "other/key"))
+
+	_, ok := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.False(t, ok)
+
+	moved, ok := srv.Object("other_bucket", "other/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, "some_body", string(moved))
+
+	// the source is kept when the copy fails
+	err := s3Svc.S3Move("other_bucket", "other/key", "some_missing_bucket", "other/key")
+
+	assert.Error(t, err)
+	assert.Equal(t, s3.ErrCodeNoSuchBucket, err.(awserr.Error).Code())
+
+	_, ok = srv.Object("other_bucket", "other/key")
+
+	assert.True(t, ok)
+
+	// and when the copy does not match the source, by size or by ETag
+	overwrite := ""
+
+	s3Svc.Handlers.Send.PushBack(func(r *request.Request) {
+		if r.Operation.Name == "CopyObject" {
+			_, err := s3Svc.PutObject(&s3.PutObjectInput{
+				Bucket: aws.String(cfg.S3.Bucket),
+				Key:    aws.String("some/key"),
+				Body:   strings.NewReader(overwrite),
+			})
+			assert.NoError(t, err)
+		}
+	})
+
+	for body, field := range map[string]string{
+		"some_other_body": ContentLength,
+		"some_bodx":       ETag,
+	} {
+
+		overwrite = body
+
+		err = s3Svc.S3Move("other_bucket", "other/key", cfg.S3.Bucket, "some/key")
+
+		var notVerified *CopyNotVerifiedError
+
+		assert.True(t, errors.As(err, &notVerified))
+		assert.Equal(t, cfg.S3.Bucket, notVerified.BucketName)
+		assert.Equal(t, "some/key", notVerified.ObjectName)
+		assert.Equal(t, field, notVerified.Field)
+
+		_, ok = srv.Object("other_bucket", "other/key")
+
+		assert.True(t, ok)
+
+	}
+
+}
+
+func TestS3_S3Copy_Validation(t *testing.T) {
+
+	s3Svc := &S3{}
+
+	err := s3Svc.S3Copy("", "some/key", "other_bucket", "other/key")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), BucketName)
+
+	err = s3Svc.S3Move("some_bucket", "some/key", "other_bucket", "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ObjectName)
+
+	err = s3Svc.S3Move("some_bucket", "some/key", "some_bucket", "some/key")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidParameter)
+
+	for param, opt := range map[string]CopyOption{
+		Metadata:    WithReplaceMetadata(map[string]string{"": "some_value"}),
+		Tags:        WithReplaceTags(map[string]string{"": "some_value"}),
+		PartSize:    WithCopyPartSize(MaxCopyObjectSize + 1),
+		Concurrency: WithCopyConcurrency(0),
+	} {
+
+		err = s3Svc.S3Copy("some_bucket", "some/key", "other_bucket", "other/key", opt)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), param)
+
+	}
+
+}
+
+func TestS3_S3ReplaceMetadata(t *testing.T) {
+
+	srv := fake.NewTLS()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+	putTaggedObject(t, s3Svc, cfg.S3.Bucket, "some/key", []byte("some_body"))
+
+	err := s3Svc.S3ReplaceMetadata(cfg.S3.Bucket, "some/key", map[string]string{"Other-Meta": "other_value"})
+
+	assert.NoError(t, err)
+
+	head := headObject(t, s3Svc, cfg.S3.Bucket, "some/key")
+
+	assert.Equal(t, map[string]string{"Other-Meta": "other_value"}, aws.StringValueMap(head.Metadata))
+	assert.Equal(t, "text/plain", aws.StringValue(head.ContentType))
+
+	tags, _ := srv.Tags(cfg.S3.Bucket, "some/key")
+
+	assert.Equal(t, map[string]string{"some_tag": "some value"}, tags)
+
+	body, _ := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.Equal(t, []byte("some_body"), body)
+
+	assert.NoError(t, s3Svc.S3ReplaceMetadata(cfg.S3.Bucket, "some/key", nil, WithReplaceTags(nil)))
+
+	head = headObject(t, s3Svc, cfg.S3.Bucket, "some/key")
+
+	assert.Empty(t, head.Metadata)
+
+	tags, _ = srv.Tags(cfg.S3.Bucket, "some/key")
+
+	assert.Empty(t, tags)
+
+	// the server-side encryption of the object is kept
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "kms/key", bytes.NewReader([]byte("some_body")), WithEncryption(WithSSEKMS("some_key"))))
+	assert.NoError(t, s3Svc.S3ReplaceMetadata(cfg.S3.Bucket, "kms/key", map[string]string{"Some-Meta": "some_value"}))
+
+	enc, _ := srv.Encryption(cfg.S3.Bucket, "kms/key")
+
+	assert.Equal(t, s3.ServerSideEncryptionAwsKms, enc.Get("X-Amz-Server-Side-Encryption"))
+	assert.Equal(t, "some_key", enc.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"))
+
+	key := bytes.Repeat([]byte("k"), CustomerKeySize)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "sse-c/key", bytes.NewReader([]byte("some_body")), WithEncryption(WithSSEC(key))))
+	assert.Error(t, s3Svc.S3ReplaceMetadata(cfg.S3.Bucket, "sse-c/key", nil))
+	assert.NoError(t, s3Svc.S3ReplaceMetadata(cfg.S3.Bucket, "sse-c/key", map[string]string{"Some-Meta": "some_value"}, WithCopySourceEncryption(WithSSEC(key))))
+
+	info, err := s3Svc.S3Stat(cfg.S3.Bucket, "sse-c/key", WithSSEC(key))
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Some-Meta": "some_value"}, info.Metadata)
+
+	err = s3Svc.S3ReplaceMetadata("", "some/key", nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), BucketName)
+
+}
+
+func TestEncodeTags(t *testing.T) {
+
+	assert.Equal(t, "a=1&b+c=2%263", encodeTags(map[string]string{"b c": "2&3", "a": "1"}))
+	assert.Empty(t, encodeTags(nil))
+
+}
+
+// putTaggedObject puts body under objectName with a content type, metadata and tags
+func putTaggedObject(t *testing.T, svc *S3, bucketName, objectName string, body []byte) {
+
+	_, err := svc.PutObject(&s3.PutObjectInput{
+		Bucket:      aws.String(bucketName),
+		Key:         aws.String(objectName),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String("text/plain"),
+		Metadata:    map[string]*string{"Some-Meta": aws.String("some_value")},
+		Tagging:     aws.String(encodeTags(map[string]string{"some_tag": "some value"})),
+	})
+
+	assert.NoError(t, err)
+
+}
+
+// headObject returns the metadata of objectName
+func headObject(t *testing.T, svc *S3, bucketName, objectName string) *s3.HeadObjectOutput {
+
+	out, err := svc.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(objectName),
+	})
+
+	assert.NoError(t, err)
+
+	return out
+
+}
+
+func TestS3_md5ETag(t *testing.T) {
+
+	assert.True(t, md5ETag(&s3.HeadObjectOutput{ETag: aws.String(`"some_etag"`)}))
+	assert.True(t, md5ETag(&s3.HeadObjectOutput{
+		ETag:                 aws.String(`"some_etag"`),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAes256),
+	}))
+	assert.False(t, md5ETag(&s3.HeadObjectOutput{ETag: aws.String(`"some_etag-2"`)}))
+	assert.False(t, md5ETag(&s3.HeadObjectOutput{
+		ETag:                 aws.String(`"some_etag"`),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAwsKms),
+	}))
+	assert.False(t, md5ETag(&s3.HeadObjectOutput{
+		ETag:                 aws.String(`"some_etag"`),
+		SSECustomerAlgorithm: aws.String("AES256"),
+	}))
+
+}
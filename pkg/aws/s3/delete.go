@@ -0,0 +1,394 @@
+package s3
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+// MaxDeleteKeys is the maximum number of keys deleted by a single DeleteObjects request
+const MaxDeleteKeys = 1000
+
+// DeleteInput contains the optional parameters of S3DeleteObject, S3DeleteObjects and S3DeletePrefix
+type DeleteInput struct {
+	versionID   string
+	allVersions bool
+}
+
+// DeleteOption sets an optional parameter on a *DeleteInput
+type DeleteOption func(*DeleteInput) error
+
+// DeleteResult describes the outcome of a batch deletion, key by key
+type DeleteResult struct {
+	Deleted []DeletedObject
+	Errors  []DeleteError
+}
+
+// DeletedObject is an object version or a delete marker successfully deleted
+type DeletedObject struct {
+	Key string
+	// VersionID is the version that has been deleted, if a version was targeted
+	VersionID string
+	// DeleteMarker is true when a delete marker has been either created or deleted
+	DeleteMarker bool
+	// DeleteMarkerVersionID is the version of the delete marker, if any
+	DeleteMarkerVersionID string
+}
+
+// DeleteError is an object version S3 refused to delete
+type DeleteError struct {
+	Key       string
+	VersionID string
+	// Err satisfies awserr.Error, reporting the error code and message returned by S3
+	Err error
+}
+
+// WithVersionID deletes a specific version of the object instead of the current one.
+// Only S3DeleteObject accepts it
+func WithVersionID(versionID string) DeleteOption {
+	return func(in *DeleteInput) error {
+
+		if versionID == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, VersionID)
+		}
+
+		in.versionID = versionID
+
+		return nil
+
+	}
+}
+
+// WithAllVersions permanently deletes every version and delete marker of the objects
+// of a versioned bucket, instead of hiding them behind a new delete marker
+func WithAllVersions() DeleteOption {
+	return func(in *DeleteInput) error {
+
+		in.allVersions = true
+
+		return nil
+
+	}
+}
+
+// S3DeleteObject deletes objectName from bucketName.
+// Deleting a missing object is not an error
+func (svc *S3) S3DeleteObject(bucketName, objectName string, opts ...DeleteOption) error {
+	return svc.S3DeleteObjectWithContext(context.Background(), bucketName, objectName, opts...)
+}
+
+// S3DeleteObjectWithContext is the same as S3DeleteObject with the addition of a context.Context
+func (svc *S3) S3DeleteObjectWithContext(ctx context.Context, bucketName, objectName string, opts ...DeleteOption) error {
+
+	in, err := newDeleteInput(bucketName, opts...)
+	if err != nil {
+		return err
+	}
+
+	if objectName == "" {
+		return intErr.NewValidationError(ErrEmptyParameter, ObjectName)
+	}
+
+	if in.allVersions {
+
+		result, err := svc.deleteVersions(ctx, bucketName, []string{objectName})
+		if err != nil {
+			return err
+		}
+		if len(result.Errors) > 0 {
+			return result.Errors[0].Err
+		}
+
+		return nil
+
+	}
+
+	input := &s3.DeleteObjectInput{}
+	input = input.SetBucket(bucketName)
+	input = input.SetKey(objectName)
+
+	if in.versionID != "" {
+		input = input.SetVersionId(in.versionID)
+	}
+
+	if _, err := svc.S3.DeleteObjectWithContext(ctx, input); err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
+
+// S3DeleteObjects deletes objectNames from bucketName with as many DeleteObjects requests
+// as needed, MaxDeleteKeys keys at a time. The keys S3 refuses to delete are reported
+// in DeleteResult.Errors, while the returned error is only set when a request fails as a whole
+func (svc *S3) S3DeleteObjects(bucketName string, objectNames []string, opts ...DeleteOption) (*DeleteResult, error) {
+	return svc.S3DeleteObjectsWithContext(context.Background(), bucketName, objectNames, opts...)
+}
+
+// S3DeleteObjectsWithContext is the same as S3DeleteObjects with the addition of a context.Context
+func (svc *S3) S3DeleteObjectsWithContext(ctx context.Context, bucketName string, objectNames []string, opts ...DeleteOption) (*DeleteResult, error) {
+
+	in, err := newBatchDeleteInput(bucketName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, objectName := range objectNames {
+		if objectName == "" {
+			return nil, intErr.NewValidationError(ErrEmptyParameter, ObjectName)
+		}
+	}
+
+	if in.allVersions {
+		return svc.deleteVersions(ctx, bucketName, objectNames)
+	}
+
+	batch := newDeleteBatch(svc, bucketName)
+
+	for _, objectName := range objectNames {
+		if err := batch.add(ctx, objectName, ""); err != nil {
+			return batch.result, err
+		}
+	}
+
+	return batch.result, batch.flush(ctx)
+
+}
+
+// S3DeletePrefix deletes every object of bucketName whose key starts with prefix.
+// prefix can't be empty, so that a whole bucket is never emptied by mistake.
+// The keys S3 refuses to delete are reported in DeleteResult.Errors
+func (svc *S3) S3DeletePrefix(bucketName, prefix string, opts ...DeleteOption) (*DeleteResult, error) {
+	return svc.S3DeletePrefixWithContext(context.Background(), bucketName, prefix, opts...)
+}
+
+// S3DeletePrefixWithContext is the same as S3DeletePrefix with the addition of a context.Context
+func (svc *S3) S3DeletePrefixWithContext(ctx context.Context, bucketName, prefix string, opts ...DeleteOption) (*DeleteResult, error) {
+
+	in, err := newBatchDeleteInput(bucketName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if prefix == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Prefix)
+	}
+
+	batch := newDeleteBatch(svc, bucketName)
+
+	if in.allVersions {
+
+		if err := svc.listVersions(ctx, bucketName, prefix, batch.addVersion(ctx)); err != nil {
+			return batch.result, err
+		}
+
+		return batch.result, batch.flush(ctx)
+
+	}
+
+	it := svc.S3ListObjectsWithContext(ctx, bucketName, WithPrefix(prefix))
+
+	for it.Next() {
+		if err := batch.add(ctx, it.Entry().Key, ""); err != nil {
+			return batch.result, err
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return batch.result, err
+	}
+
+	return batch.result, batch.flush(ctx)
+
+}
+
+// newDeleteInput validates the parameters shared by every deletion and returns a new *DeleteInput
+func newDeleteInput(bucketName string, opts ...DeleteOption) (*DeleteInput, error) {
+
+	if bucketName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+
+	in := &DeleteInput{}
+
+	for _, opt := range opts {
+		if err := opt(in); err != nil {
+			return nil, err
+		}
+	}
+
+	if in.versionID != "" && in.allVersions {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, VersionID)
+	}
+
+	return in, nil
+
+}
+
+// newBatchDeleteInput is the same as newDeleteInput, rejecting WithVersionID
+func newBatchDeleteInput(bucketName string, opts ...DeleteOption) (*DeleteInput, error) {
+
+	in, err := newDeleteInput(bucketName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.versionID != "" {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, VersionID)
+	}
+
+	return in, nil
+
+}
+
+// deleteVersions deletes every version and delete marker of objectNames
+func (svc *S3) deleteVersions(ctx context.Context, bucketName string, objectNames []string) (*DeleteResult, error) {
+
+	batch := newDeleteBatch(svc, bucketName)
+
+	for _, objectName := range objectNames {
+
+		add := batch.addVersion(ctx)
+
+		// a prefix listing also returns the longer keys starting with objectName
+		err := svc.listVersions(ctx, bucketName, objectName, func(key, versionID string) error {
+			if key != objectName {
+				return nil
+			}
+			return add(key, versionID)
+		})
+		if err != nil {
+			return batch.result, err
+		}
+
+	}
+
+	return batch.result, batch.flush(ctx)
+
+}
+
+// listVersions calls fn with every version and delete marker of the keys starting with prefix,
+// stopping at the first error
+func (svc *S3) listVersions(ctx context.Context, bucketName, prefix string, fn func(key, versionID string) error) error {
+
+	input := &s3.ListObjectVersionsInput{}
+	input = input.SetBucket(bucketName)
+	input = input.SetPrefix(prefix)
+
+	var fnErr error
+
+	err := svc.S3.ListObjectVersionsPagesWithContext(ctx, input, func(out *s3.ListObjectVersionsOutput, last bool) bool {
+
+		for _, v := range out.Versions {
+			if fnErr = fn(aws.StringValue(v.Key), aws.StringValue(v.VersionId)); fnErr != nil {
+				return false
+			}
+		}
+		for _, m := range out.DeleteMarkers {
+			if fnErr = fn(aws.StringValue(m.Key), aws.StringValue(m.VersionId)); fnErr != nil {
+				return false
+			}
+		}
+
+		return true
+
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
+
+// deleteBatch accumulates the objects to delete and deletes them MaxDeleteKeys at a time
+type deleteBatch struct {
+	svc        *S3
+	bucketName string
+	objects    []*s3.ObjectIdentifier
+	result     *DeleteResult
+}
+
+// newDeleteBatch returns a new empty *deleteBatch
+func newDeleteBatch(svc *S3, bucketName string) *deleteBatch {
+	return &deleteBatch{
+		svc:        svc,
+		bucketName: bucketName,
+		result:     &DeleteResult{},
+	}
+}
+
+// add queues a key, or one of its versions if versionID is not empty, flushing the batch once full
+func (b *deleteBatch) add(ctx context.Context, key, versionID string) error {
+
+	id := &s3.ObjectIdentifier{}
+	id = id.SetKey(key)
+
+	if versionID != "" {
+		id = id.SetVersionId(versionID)
+	}
+
+	b.objects = append(b.objects, id)
+
+	if len(b.objects) < MaxDeleteKeys {
+		return nil
+	}
+
+	return b.flush(ctx)
+
+}
+
+// addVersion returns add bound to ctx, for listVersions
+func (b *deleteBatch) addVersion(ctx context.Context) func(key, versionID string) error {
+	return func(key, versionID string) error {
+		return b.add(ctx, key, versionID)
+	}
+}
+
+// flush deletes the queued objects
+func (b *deleteBatch) flush(ctx context.Context) error {
+
+	if len(b.objects) == 0 {
+		return nil
+	}
+
+	del := &s3.Delete{}
+	del = del.SetObjects(b.objects)
+
+	input := &s3.DeleteObjectsInput{}
+	input = input.SetBucket(b.bucketName)
+	input = input.SetDelete(del)
+
+	b.objects = nil
+
+	out, err := b.svc.S3.DeleteObjectsWithContext(ctx, input)
+	if err != nil {
+		return intErr.Wrap(err)
+	}
+
+	for _, d := range out.Deleted {
+		b.result.Deleted = append(b.result.Deleted, DeletedObject{
+			Key:                   aws.StringValue(d.Key),
+			VersionID:             aws.StringValue(d.VersionId),
+			DeleteMarker:          aws.BoolValue(d.DeleteMarker),
+			DeleteMarkerVersionID: aws.StringValue(d.DeleteMarkerVersionId),
+		})
+	}
+	for _, e := range out.Errors {
+		b.result.Errors = append(b.result.Errors, DeleteError{
+			Key:       aws.StringValue(e.Key),
+			VersionID: aws.StringValue(e.VersionId),
+			Err:       intErr.NewAWSError(aws.StringValue(e.Code), aws.StringValue(e.Message), nil),
+		})
+	}
+
+	return nil
+
+}
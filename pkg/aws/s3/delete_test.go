@@ -0,0 +1,290 @@
+package s3
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestS3_S3DeleteObject(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/key", strings.NewReader("some_body")))
+	assert.NoError(t, s3Svc.S3DeleteObject(cfg.S3.Bucket, "some/key"))
+	assert.NoError(t, s3Svc.S3DeleteObject(cfg.S3.Bucket, "some/key"))
+
+	_, ok := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.False(t, ok)
+
+	enableVersioning(t, s3Svc, cfg.S3.Bucket)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/key", strings.NewReader("some_body")))
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/key", strings.NewReader("some_other_body")))
+
+	versions := listVersionIDs(t, s3Svc, cfg.S3.Bucket)
+
+	assert.Len(t, versions["some/key"], 2)
+	assert.NoError(t, s3Svc.S3DeleteObject(cfg.S3.Bucket, "some/key", WithVersionID(versions["some/key"][0])))
+
+	body, ok := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, "some_body", string(body))
+
+	assert.NoError(t, s3Svc.S3DeleteObject(cfg.S3.Bucket, "some/key"))
+	assert.Len(t, listVersionIDs(t, s3Svc, cfg.S3.Bucket)["some/key"], 2)
+
+	assert.NoError(t, s3Svc.S3DeleteObject(cfg.S3.Bucket, "some/key", WithAllVersions()))
+	assert.Empty(t, listVersionIDs(t, s3Svc, cfg.S3.Bucket))
+
+	err := s3Svc.S3DeleteObject(cfg.S3.Bucket, "some/key", WithVersionID("some_version"))
+
+	assert.Error(t, err)
+	assert.Equal(t, "InvalidArgument", err.(awserr.Error).Code())
+
+}
+
+func TestS3_S3DeleteObjects(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	keys := make([]string, MaxDeleteKeys+5)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("some/key/%04d", i)
+		if i%100 == 0 {
+			assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, keys[i], strings.NewReader("some_body")))
+		}
+	}
+
+	requests := 0
+
+	s3Svc.Handlers.Send.PushBack(func(r *request.Request) {
+		if _, ok := r.Params.(*s3.DeleteObjectsInput); ok {
+			requests++
+		}
+	})
+
+	result, err := s3Svc.S3DeleteObjects(cfg.S3.Bucket, keys)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Len(t, result.Deleted, len(keys))
+	assert.Empty(t, result.Errors)
+	assert.Empty(t, listVersionIDs(t, s3Svc, cfg.S3.Bucket))
+
+	// per-key failures are collected instead of aborting the deletion
+	s3Svc.Handlers.Build.PushFront(func(r *request.Request) {
+		if in, ok := r.Params.(*s3.DeleteObjectsInput); ok && len(in.Delete.Objects) > 1 {
+			in.Delete.Objects[1].VersionId = aws.String("some_version")
+		}
+	})
+
+	result, err = s3Svc.S3DeleteObjects(cfg.S3.Bucket, []string{"a", "b", "c"})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Deleted, 2)
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, "b", result.Errors[0].Key)
+	assert.Equal(t, "some_version", result.Errors[0].VersionID)
+	assert.Equal(t, "InvalidArgument", result.Errors[0].Err.(awserr.Error).Code())
+
+	_, err = s3Svc.S3DeleteObjects("some_missing_bucket", []string{"a"})
+
+	assert.Error(t, err)
+	assert.Equal(t, s3.ErrCodeNoSuchBucket, err.(awserr.Error).Code())
+
+}
+
+func TestS3_S3DeleteObjects_AllVersions(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "a", strings.NewReader("some_body")))
+
+	enableVersioning(t, s3Svc, cfg.S3.Bucket)
+
+	for _, key := range []string{"a", "a", "ab", "b"} {
+		assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, key, strings.NewReader(key)))
+	}
+
+	assert.NoError(t, s3Svc.S3DeleteObject(cfg.S3.Bucket, "b"))
+
+	result, err := s3Svc.S3DeleteObjects(cfg.S3.Bucket, []string{"a", "b"}, WithAllVersions())
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Deleted, 5)
+	assert.Empty(t, result.Errors)
+
+	markers := 0
+	for _, d := range result.Deleted {
+		if d.DeleteMarker {
+			markers++
+		}
+	}
+
+	assert.Equal(t, 1, markers)
+	assert.Equal(t, []string{"ab"}, keysOf(listVersionIDs(t, s3Svc, cfg.S3.Bucket)))
+
+}
+
+func TestS3_S3DeletePrefix(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, fmt.Sprintf("some/%d", i), strings.NewReader("some_body")))
+	}
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "other/key", strings.NewReader("some_body")))
+
+	result, err := s3Svc.S3DeletePrefix(cfg.S3.Bucket, "some/")
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Deleted, 5)
+	assert.Equal(t, []string{"other/key"}, keysOf(listVersionIDs(t, s3Svc, cfg.S3.Bucket)))
+
+	enableVersioning(t, s3Svc, cfg.S3.Bucket)
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 2; j++ {
+			assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, fmt.Sprintf("some/%d", i), strings.NewReader("some_body")))
+		}
+	}
+
+	result, err = s3Svc.S3DeletePrefix(cfg.S3.Bucket, "some/")
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Deleted, 3)
+	assert.Len(t, listVersionIDs(t, s3Svc, cfg.S3.Bucket)["some/0"], 3)
+
+	// the versions are listed over several pages
+	s3Svc.Handlers.Build.PushFront(func(r *request.Request) {
+		if in, ok := r.Params.(*s3.ListObjectVersionsInput); ok && in.Prefix != nil {
+			in.MaxKeys = aws.Int64(2)
+		}
+	})
+
+	result, err = s3Svc.S3DeletePrefix(cfg.S3.Bucket, "some/", WithAllVersions())
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Deleted, 9)
+	assert.Equal(t, []string{"other/key"}, keysOf(listVersionIDs(t, s3Svc, cfg.S3.Bucket)))
+
+}
+
+func TestS3_S3Delete_Validation(t *testing.T) {
+
+	s3Svc := &S3{}
+
+	err := s3Svc.S3DeleteObject("", "some/key")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), BucketName)
+
+	err = s3Svc.S3DeleteObject("some_bucket", "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ObjectName)
+
+	err = s3Svc.S3DeleteObject("some_bucket", "some/key", WithVersionID(""))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), VersionID)
+
+	err = s3Svc.S3DeleteObject("some_bucket", "some/key", WithVersionID("some_version"), WithAllVersions())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidParameter)
+
+	_, err = s3Svc.S3DeleteObjects("some_bucket", []string{"some/key", ""})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ObjectName)
+
+	_, err = s3Svc.S3DeleteObjects("some_bucket", []string{"some/key"}, WithVersionID("some_version"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), VersionID)
+
+	_, err = s3Svc.S3DeletePrefix("some_bucket", "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), Prefix)
+
+}
+
+// enableVersioning enables versioning on bucketName
+func enableVersioning(t *testing.T, svc *S3, bucketName string) {
+
+	_, err := svc.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket:                  aws.String(bucketName),
+		VersioningConfiguration: &s3.VersioningConfiguration{Status: aws.String(s3.BucketVersioningStatusEnabled)},
+	})
+
+	assert.NoError(t, err)
+
+}
+
+// listVersionIDs returns the IDs of every version and delete marker of bucketName, by key
+func listVersionIDs(t *testing.T, svc *S3, bucketName string) map[string][]string {
+
+	out, err := svc.ListObjectVersions(&s3.ListObjectVersionsInput{Bucket: aws.String(bucketName)})
+
+	assert.NoError(t, err)
+
+	versions := make(map[string][]string)
+
+	for _, v := range out.Versions {
+		versions[*v.Key] = append(versions[*v.Key], *v.VersionId)
+	}
+	for _, m := range out.DeleteMarkers {
+		versions[*m.Key] = append(versions[*m.Key], *m.VersionId)
+	}
+
+	return versions
+
+}
+
+// keysOf returns the sorted keys of versions
+func keysOf(versions map[string][]string) []string {
+
+	keys := make([]string, 0, len(versions))
+	for key := range versions {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+
+}
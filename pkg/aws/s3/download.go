@@ -0,0 +1,360 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+const (
+
+	// DefaultDownloadPartSize is the size of the ranges fetched by S3DownloadAt by default
+	DefaultDownloadPartSize = 8 * 1024 * 1024
+
+	// errCodePreconditionFailed is the aws error code of a failed If-Match condition
+	errCodePreconditionFailed = "PreconditionFailed"
+)
+
+// DownloadInput contains the optional parameters of S3Download and S3DownloadAt
+type DownloadInput struct {
+	offset      int64
+	length      int64
+	partSize    int64
+	concurrency int
+	encryption  *Encryption
+}
+
+// DownloadOption sets an optional parameter on a *DownloadInput
+type DownloadOption func(*DownloadInput) error
+
+// byteRange is a range of bytes of an object, end excluded
+type byteRange struct {
+	start int64
+	end   int64
+}
+
+// offsetWriter writes to an io.WriterAt sequentially, starting from offset
+type offsetWriter struct {
+	w      io.WriterAt
+	offset int64
+}
+
+// WithRange only downloads length bytes of the object, starting from offset.
+// A length of 0 downloads up to the end of the object
+func WithRange(offset, length int64) DownloadOption {
+	return func(in *DownloadInput) error {
+
+		if offset < 0 {
+			return intErr.NewValidationError(ErrInvalidParameter, Offset)
+		}
+		if length < 0 {
+			return intErr.NewValidationError(ErrInvalidParameter, Length)
+		}
+
+		in.offset = offset
+		in.length = length
+
+		return nil
+
+	}
+}
+
+// WithDownloadPartSize sets the size of the ranges fetched by S3DownloadAt, DefaultDownloadPartSize by default
+func WithDownloadPartSize(partSize int64) DownloadOption {
+	return func(in *DownloadInput) error {
+
+		if partSize < 1 {
+			return intErr.NewValidationError(ErrInvalidParameter, PartSize)
+		}
+
+		in.partSize = partSize
+
+		return nil
+
+	}
+}
+
+// WithDownloadConcurrency sets how many ranges S3DownloadAt fetches at the same time, DefaultConcurrency by default
+func WithDownloadConcurrency(concurrency int) DownloadOption {
+	return func(in *DownloadInput) error {
+
+		if concurrency < 1 {
+			return intErr.NewValidationError(ErrInvalidParameter, Concurrency)
+		}
+
+		in.concurrency = concurrency
+
+		return nil
+
+	}
+}
+
+// WithDownloadEncryption sets the key of an object encrypted with SSE-C. Only WithSSEC applies to
+// downloads, since objects encrypted with SSE-S3 or SSE-KMS are decrypted without any parameter
+func WithDownloadEncryption(opts ...EncryptionOption) DownloadOption {
+	return func(in *DownloadInput) error {
+
+		enc, err := newReadEncryption(opts...)
+		if err != nil {
+			return err
+		}
+
+		in.encryption = enc
+
+		return nil
+
+	}
+}
+
+// S3Download streams objectName from bucketName to w with a single request and returns
+// the number of bytes written. Empty objects are valid and write nothing
+func (svc *S3) S3Download(bucketName, objectName string, w io.Writer, opts ...DownloadOption) (int64, error) {
+	return svc.S3DownloadWithContext(context.Background(), bucketName, objectName, w, opts...)
+}
+
+// S3DownloadWithContext is the same as S3Download with the addition of a context.Context
+func (svc *S3) S3DownloadWithContext(ctx context.Context, bucketName, objectName string, w io.Writer, opts ...DownloadOption) (int64, error) {
+
+	if w == nil {
+		return 0, intErr.NewValidationError(ErrEmptyParameter, Writer)
+	}
+
+	in, err := newDownloadInput(bucketName, objectName, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	get, err := NewGetObjectInput(bucketName, objectName)
+	if err != nil {
+		return 0, err
+	}
+
+	if in.offset > 0 || in.length > 0 {
+		get = get.SetRange(in.rangeHeader())
+	}
+
+	in.encryption.getObject(get)
+
+	return svc.download(ctx, get, w)
+
+}
+
+// S3DownloadAt downloads objectName from bucketName to w with parallel ranged requests
+// and returns the number of bytes written. Every range must match the ETag the object had
+// when the download started, so that the ranges of different versions are never mixed.
+// The first byte of the object, or of the range set with WithRange, is written at offset 0 of w.
+// Empty objects are valid and write nothing
+func (svc *S3) S3DownloadAt(bucketName, objectName string, w io.WriterAt, opts ...DownloadOption) (int64, error) {
+	return svc.S3DownloadAtWithContext(context.Background(), bucketName, objectName, w, opts...)
+}
+
+// S3DownloadAtWithContext is the same as S3DownloadAt with the addition of a context.Context
+func (svc *S3) S3DownloadAtWithContext(ctx context.Context, bucketName, objectName string, w io.WriterAt, opts ...DownloadOption) (int64, error) {
+
+	if w == nil {
+		return 0, intErr.NewValidationError(ErrEmptyParameter, Writer)
+	}
+
+	in, err := newDownloadInput(bucketName, objectName, opts...)
+	if err != nil {
+		return 0, err
+	}
+
+	head := &s3.HeadObjectInput{}
+	head = head.SetBucket(bucketName)
+	head = head.SetKey(objectName)
+
+	in.encryption.headObject(head)
+
+	headOut, err := svc.S3.HeadObjectWithContext(ctx, head)
+	if err != nil {
+		return 0, intErr.Wrap(err)
+	}
+
+	size := aws.Int64Value(headOut.ContentLength)
+	etag := aws.StringValue(headOut.ETag)
+
+	if in.offset > size {
+		return 0, intErr.NewValidationError(ErrInvalidParameter, Offset)
+	}
+
+	end := size
+	if in.length > 0 && in.offset+in.length < size {
+		end = in.offset + in.length
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ranges := make(chan byteRange)
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		written  int64
+	)
+
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < in.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+
+			defer wg.Done()
+
+			for r := range ranges {
+
+				n, err := svc.downloadRange(ctx, bucketName, objectName, etag, in.encryption, r, &offsetWriter{w: w, offset: r.start - in.offset})
+
+				mu.Lock()
+				written += n
+				if err != nil && firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+
+			}
+
+		}()
+	}
+
+feed:
+	for start := in.offset; start < end; start += in.partSize {
+
+		r := byteRange{start: start, end: start + in.partSize}
+		if r.end > end {
+			r.end = end
+		}
+
+		select {
+		case ranges <- r:
+		case <-ctx.Done():
+			break feed
+		}
+
+	}
+
+	close(ranges)
+	wg.Wait()
+
+	if firstErr != nil {
+		return written, firstErr
+	}
+
+	return written, ctx.Err()
+
+}
+
+// newDownloadInput validates the parameters shared by every download and returns a new *DownloadInput
+func newDownloadInput(bucketName, objectName string, opts ...DownloadOption) (*DownloadInput, error) {
+
+	if bucketName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+	if objectName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, ObjectName)
+	}
+
+	return applyDownloadOptions(opts...)
+
+}
+
+// applyDownloadOptions returns a new *DownloadInput with its defaults, set by opts
+func applyDownloadOptions(opts ...DownloadOption) (*DownloadInput, error) {
+
+	in := &DownloadInput{
+		partSize:    DefaultDownloadPartSize,
+		concurrency: DefaultConcurrency,
+	}
+
+	for _, opt := range opts {
+		if err := opt(in); err != nil {
+			return nil, err
+		}
+	}
+
+	return in, nil
+
+}
+
+// rangeHeader returns the Range header matching the offset and length of in
+func (in *DownloadInput) rangeHeader() string {
+
+	if in.length == 0 {
+		return fmt.Sprintf("bytes=%d-", in.offset)
+	}
+
+	return fmt.Sprintf("bytes=%d-%d", in.offset, in.offset+in.length-1)
+
+}
+
+// download copies the body of the object described by in to w
+func (svc *S3) download(ctx context.Context, in *s3.GetObjectInput, w io.Writer) (int64, error) {
+
+	out, err := svc.S3.GetObjectWithContext(ctx, in)
+	if err != nil {
+		return 0, intErr.Wrap(err)
+	}
+
+	defer out.Body.Close()
+
+	return io.Copy(w, out.Body)
+
+}
+
+// downloadRange copies r of the object to w, as long as its ETag is still etag
+func (svc *S3) downloadRange(ctx context.Context, bucketName, objectName, etag string, enc *Encryption, r byteRange, w io.Writer) (int64, error) {
+
+	in, err := NewGetObjectInput(bucketName, objectName)
+	if err != nil {
+		return 0, err
+	}
+
+	in = in.SetRange(fmt.Sprintf("bytes=%d-%d", r.start, r.end-1))
+	in = in.SetIfMatch(etag)
+
+	enc.getObject(in)
+
+	out, err := svc.S3.GetObjectWithContext(ctx, in)
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == errCodePreconditionFailed {
+		return 0, intErr.NewValidationError(ErrObjectChanged, ObjectName)
+	}
+	if err != nil {
+		return 0, intErr.Wrap(err)
+	}
+
+	defer out.Body.Close()
+
+	if aws.StringValue(out.ETag) != etag {
+		return 0, intErr.NewValidationError(ErrObjectChanged, ObjectName)
+	}
+
+	n, err := io.Copy(w, out.Body)
+	if err != nil {
+		return n, err
+	}
+	if n != r.end-r.start {
+		return n, io.ErrUnexpectedEOF
+	}
+
+	return n, nil
+
+}
+
+// Write implements io.Writer
+func (w *offsetWriter) Write(p []byte) (int, error) {
+
+	n, err := w.w.WriteAt(p, w.offset)
+	w.offset += int64(n)
+
+	return n, err
+
+}
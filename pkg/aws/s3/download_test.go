@@ -0,0 +1,204 @@
+package s3
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestS3_S3Download(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/key", strings.NewReader("some_body")))
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/empty", strings.NewReader("")))
+
+	buf := &bytes.Buffer{}
+
+	n, err := s3Svc.S3Download(cfg.S3.Bucket, "some/key", buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), n)
+	assert.Equal(t, "some_body", buf.String())
+
+	buf.Reset()
+
+	n, err = s3Svc.S3Download(cfg.S3.Bucket, "some/key", buf, WithRange(5, 2))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, "bo", buf.String())
+
+	buf.Reset()
+
+	_, err = s3Svc.S3Download(cfg.S3.Bucket, "some/key", buf, WithRange(5, 0))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "body", buf.String())
+
+	buf.Reset()
+
+	n, err = s3Svc.S3Download(cfg.S3.Bucket, "some/empty", buf)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	_, err = s3Svc.S3Download(cfg.S3.Bucket, "some_missing_key", buf)
+
+	assert.Error(t, err)
+	assert.Equal(t, s3.ErrCodeNoSuchKey, err.(awserr.Error).Code())
+
+}
+
+func TestS3_S3DownloadAt(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	body := bytes.Repeat([]byte("0123456789"), 1000)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/key", bytes.NewReader(body)))
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/empty", strings.NewReader("")))
+
+	w := &writerAt{}
+
+	n, err := s3Svc.S3DownloadAt(cfg.S3.Bucket, "some/key", w, WithDownloadPartSize(999), WithDownloadConcurrency(3))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(body)), n)
+	assert.Equal(t, body, w.buf)
+
+	w = &writerAt{}
+
+	n, err = s3Svc.S3DownloadAt(cfg.S3.Bucket, "some/key", w, WithRange(2500, 2000), WithDownloadPartSize(300))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2000), n)
+	assert.Equal(t, body[2500:4500], w.buf)
+
+	w = &writerAt{}
+
+	n, err = s3Svc.S3DownloadAt(cfg.S3.Bucket, "some/empty", w)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+	assert.Empty(t, w.buf)
+
+	_, err = s3Svc.S3DownloadAt(cfg.S3.Bucket, "some/key", w, WithRange(int64(len(body))+1, 0))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), Offset)
+
+}
+
+func TestS3_S3DownloadAt_ObjectChanged(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	body := bytes.Repeat([]byte("0123456789"), 100)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/key", bytes.NewReader(body)))
+
+	ranges := 0
+
+	s3Svc.Handlers.Send.PushFront(func(r *request.Request) {
+
+		in, ok := r.Params.(*s3.GetObjectInput)
+		if !ok || in.Range == nil {
+			return
+		}
+
+		if ranges++; ranges == 2 {
+			_, err := s3Svc.PutObject(&s3.PutObjectInput{
+				Bucket: aws.String(cfg.S3.Bucket),
+				Key:    aws.String("some/key"),
+				Body:   strings.NewReader("some_other_body"),
+			})
+			assert.NoError(t, err)
+		}
+
+	})
+
+	_, err := s3Svc.S3DownloadAt(cfg.S3.Bucket, "some/key", &writerAt{}, WithDownloadPartSize(100), WithDownloadConcurrency(1))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrObjectChanged)
+
+}
+
+func TestS3_S3Download_Validation(t *testing.T) {
+
+	s3Svc := &S3{}
+
+	_, err := s3Svc.S3Download("some_bucket", "some/key", nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), Writer)
+
+	_, err = s3Svc.S3Download("", "some/key", &bytes.Buffer{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), BucketName)
+
+	_, err = s3Svc.S3DownloadAt("some_bucket", "", &writerAt{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ObjectName)
+
+	for param, opt := range map[string]DownloadOption{
+		Offset:      WithRange(-1, 0),
+		Length:      WithRange(0, -1),
+		PartSize:    WithDownloadPartSize(0),
+		Concurrency: WithDownloadConcurrency(0),
+	} {
+
+		_, err = s3Svc.S3DownloadAt("some_bucket", "some/key", &writerAt{}, opt)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), param)
+
+	}
+
+}
+
+// writerAt is an in-memory io.WriterAt
+type writerAt struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (w *writerAt) WriteAt(p []byte, off int64) (int, error) {
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if end := int(off) + len(p); end > len(w.buf) {
+		w.buf = append(w.buf, make([]byte, end-len(w.buf))...)
+	}
+
+	return copy(w.buf[off:], p), nil
+
+}
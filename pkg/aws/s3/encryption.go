@@ -0,0 +1,329 @@
+package s3
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+const (
+
+	// CustomerKeySize is the size of the AES-256 keys of SSE-C
+	CustomerKeySize = 32
+
+	// sseCustomerAlgorithm is the only algorithm of SSE-C
+	sseCustomerAlgorithm = "AES256"
+
+	// sseContextHeader and sseBucketKeyHeader are the SSE-KMS headers the SDK has no field for
+	sseContextHeader   = "X-Amz-Server-Side-Encryption-Context"
+	sseBucketKeyHeader = "X-Amz-Server-Side-Encryption-Bucket-Key-Enabled"
+)
+
+// Encryption contains the server-side encryption parameters of an object.
+// Only one of SSE-S3, SSE-KMS and SSE-C can be used at once
+type Encryption struct {
+	algorithm   string
+	kmsKeyID    string
+	kmsContext  map[string]string
+	bucketKey   bool
+	customerKey []byte
+}
+
+// EncryptionOption sets a server-side encryption parameter on an *Encryption
+type EncryptionOption func(*Encryption) error
+
+// WithSSES3 encrypts the object with keys managed by S3
+func WithSSES3() EncryptionOption {
+	return func(e *Encryption) error {
+		return e.setAlgorithm(s3.ServerSideEncryptionAes256)
+	}
+}
+
+// WithSSEKMS encrypts the object with the KMS key kmsKeyID, an ID, ARN or alias.
+// An empty kmsKeyID uses the aws/s3 key managed by AWS
+func WithSSEKMS(kmsKeyID string) EncryptionOption {
+	return func(e *Encryption) error {
+
+		e.kmsKeyID = kmsKeyID
+
+		return e.setAlgorithm(s3.ServerSideEncryptionAwsKms)
+
+	}
+}
+
+// WithKMSEncryptionContext sets the encryption context of an object encrypted with WithSSEKMS,
+// which KMS requires to match on every use of the data key
+func WithKMSEncryptionContext(context map[string]string) EncryptionOption {
+	return func(e *Encryption) error {
+
+		if len(context) == 0 {
+			return intErr.NewValidationError(ErrEmptyParameter, EncryptionContext)
+		}
+		for k := range context {
+			if k == "" {
+				return intErr.NewValidationError(ErrEmptyParameter, EncryptionContext)
+			}
+		}
+
+		e.kmsContext = context
+
+		return nil
+
+	}
+}
+
+// WithBucketKey has an object encrypted with WithSSEKMS use an S3 bucket key,
+// which reduces the number of requests made to KMS
+func WithBucketKey() EncryptionOption {
+	return func(e *Encryption) error {
+
+		e.bucketKey = true
+
+		return nil
+
+	}
+}
+
+// WithSSEC encrypts the object with key, a CustomerKeySize bytes AES-256 key provided on every
+// request and never stored by S3. Reads of the object have to provide the same key
+func WithSSEC(key []byte) EncryptionOption {
+	return func(e *Encryption) error {
+
+		if len(key) != CustomerKeySize {
+			return intErr.NewValidationError(ErrInvalidParameter, CustomerKey)
+		}
+
+		e.customerKey = key
+
+		return e.setAlgorithm(sseCustomerAlgorithm)
+
+	}
+}
+
+// newEncryption returns a new *Encryption, or nil when opts is empty
+func newEncryption(opts ...EncryptionOption) (*Encryption, error) {
+
+	if len(opts) == 0 {
+		return nil, nil
+	}
+
+	e := &Encryption{}
+
+	for _, opt := range opts {
+		if err := opt(e); err != nil {
+			return nil, err
+		}
+	}
+
+	if e.algorithm != s3.ServerSideEncryptionAwsKms && (e.kmsContext != nil || e.bucketKey) {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, ServerSideEncryption)
+	}
+
+	return e, nil
+
+}
+
+// newReadEncryption is the same as newEncryption, only accepting WithSSEC since
+// reads need no parameter of the other kinds of encryption
+func newReadEncryption(opts ...EncryptionOption) (*Encryption, error) {
+
+	e, err := newEncryption(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if e != nil && e.customerKey == nil {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, ServerSideEncryption)
+	}
+
+	return e, nil
+
+}
+
+// setAlgorithm sets the encryption algorithm, failing when one has already been set
+func (e *Encryption) setAlgorithm(algorithm string) error {
+
+	if e.algorithm != "" {
+		return intErr.NewValidationError(ErrInvalidParameter, ServerSideEncryption)
+	}
+
+	e.algorithm = algorithm
+
+	return nil
+
+}
+
+// sourceEncryption returns the encryption of the object described by src, whose SSE-C key is the one of srcEnc
+func sourceEncryption(src *s3.HeadObjectOutput, srcEnc *Encryption) *Encryption {
+
+	switch {
+	case src.SSECustomerAlgorithm != nil:
+		return srcEnc
+	case src.ServerSideEncryption != nil:
+		return &Encryption{
+			algorithm: aws.StringValue(src.ServerSideEncryption),
+			kmsKeyID:  aws.StringValue(src.SSEKMSKeyId),
+		}
+	}
+
+	return nil
+
+}
+
+// customerKeyMD5 returns the base64 encoded MD5 of the SSE-C key, which S3 checks the key against
+func (e *Encryption) customerKeyMD5() string {
+
+	sum := md5.Sum(e.customerKey)
+
+	return base64.StdEncoding.EncodeToString(sum[:])
+
+}
+
+// requestOptions returns the request options sending the SSE-KMS parameters missing from the SDK inputs
+func (e *Encryption) requestOptions() []request.Option {
+
+	if e == nil || (e.kmsContext == nil && !e.bucketKey) {
+		return nil
+	}
+
+	return []request.Option{func(r *request.Request) {
+		r.Handlers.Build.PushBack(func(r *request.Request) {
+
+			if e.kmsContext != nil {
+				context, _ := json.Marshal(e.kmsContext)
+				r.HTTPRequest.Header.Set(sseContextHeader, base64.StdEncoding.EncodeToString(context))
+			}
+			if e.bucketKey {
+				r.HTTPRequest.Header.Set(sseBucketKeyHeader, "true")
+			}
+
+		})
+	}}
+
+}
+
+// putObject sets the encryption parameters of in
+func (e *Encryption) putObject(in *s3.PutObjectInput) {
+
+	switch {
+	case e == nil:
+	case e.customerKey != nil:
+		in.SetSSECustomerAlgorithm(sseCustomerAlgorithm)
+		in.SetSSECustomerKey(string(e.customerKey))
+		in.SetSSECustomerKeyMD5(e.customerKeyMD5())
+	default:
+		in.SetServerSideEncryption(e.algorithm)
+		if e.kmsKeyID != "" {
+			in.SetSSEKMSKeyId(e.kmsKeyID)
+		}
+	}
+
+}
+
+// createMultipartUpload sets the encryption parameters of in
+func (e *Encryption) createMultipartUpload(in *s3.CreateMultipartUploadInput) {
+
+	switch {
+	case e == nil:
+	case e.customerKey != nil:
+		in.SetSSECustomerAlgorithm(sseCustomerAlgorithm)
+		in.SetSSECustomerKey(string(e.customerKey))
+		in.SetSSECustomerKeyMD5(e.customerKeyMD5())
+	default:
+		in.SetServerSideEncryption(e.algorithm)
+		if e.kmsKeyID != "" {
+			in.SetSSEKMSKeyId(e.kmsKeyID)
+		}
+	}
+
+}
+
+// copyObject sets the encryption parameters of the copy made by in
+func (e *Encryption) copyObject(in *s3.CopyObjectInput) {
+
+	switch {
+	case e == nil:
+	case e.customerKey != nil:
+		in.SetSSECustomerAlgorithm(sseCustomerAlgorithm)
+		in.SetSSECustomerKey(string(e.customerKey))
+		in.SetSSECustomerKeyMD5(e.customerKeyMD5())
+	default:
+		in.SetServerSideEncryption(e.algorithm)
+		if e.kmsKeyID != "" {
+			in.SetSSEKMSKeyId(e.kmsKeyID)
+		}
+	}
+
+}
+
+// copySource sets the SSE-C key of the source of in
+func (e *Encryption) copySource(in *s3.CopyObjectInput) {
+
+	if e != nil && e.customerKey != nil {
+		in.SetCopySourceSSECustomerAlgorithm(sseCustomerAlgorithm)
+		in.SetCopySourceSSECustomerKey(string(e.customerKey))
+		in.SetCopySourceSSECustomerKeyMD5(e.customerKeyMD5())
+	}
+
+}
+
+// uploadPart sets the SSE-C key of in, which has to match the one of the upload
+func (e *Encryption) uploadPart(in *s3.UploadPartInput) {
+
+	if e != nil && e.customerKey != nil {
+		in.SetSSECustomerAlgorithm(sseCustomerAlgorithm)
+		in.SetSSECustomerKey(string(e.customerKey))
+		in.SetSSECustomerKeyMD5(e.customerKeyMD5())
+	}
+
+}
+
+// uploadPartCopy sets the SSE-C key of in, which has to match the one of the upload
+func (e *Encryption) uploadPartCopy(in *s3.UploadPartCopyInput) {
+
+	if e != nil && e.customerKey != nil {
+		in.SetSSECustomerAlgorithm(sseCustomerAlgorithm)
+		in.SetSSECustomerKey(string(e.customerKey))
+		in.SetSSECustomerKeyMD5(e.customerKeyMD5())
+	}
+
+}
+
+// uploadPartCopySource sets the SSE-C key of the source of in
+func (e *Encryption) uploadPartCopySource(in *s3.UploadPartCopyInput) {
+
+	if e != nil && e.customerKey != nil {
+		in.SetCopySourceSSECustomerAlgorithm(sseCustomerAlgorithm)
+		in.SetCopySourceSSECustomerKey(string(e.customerKey))
+		in.SetCopySourceSSECustomerKeyMD5(e.customerKeyMD5())
+	}
+
+}
+
+// getObject sets the SSE-C key of in
+func (e *Encryption) getObject(in *s3.GetObjectInput) {
+
+	if e != nil && e.customerKey != nil {
+		in.SetSSECustomerAlgorithm(sseCustomerAlgorithm)
+		in.SetSSECustomerKey(string(e.customerKey))
+		in.SetSSECustomerKeyMD5(e.customerKeyMD5())
+	}
+
+}
+
+// headObject sets the SSE-C key of in
+func (e *Encryption) headObject(in *s3.HeadObjectInput) {
+
+	if e != nil && e.customerKey != nil {
+		in.SetSSECustomerAlgorithm(sseCustomerAlgorithm)
+		in.SetSSECustomerKey(string(e.customerKey))
+		in.SetSSECustomerKeyMD5(e.customerKeyMD5())
+	}
+
+}
@@ -0,0 +1,212 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestS3_S3PutObject_Encryption(t *testing.T) {
+
+	srv := fake.NewTLS()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	path := "../../../assets/compare_faces_test-source.jpg"
+
+	err := s3Svc.S3PutObject(cfg.S3.Bucket, "some/kms", path, WithPutEncryption(
+		WithSSEKMS("some_key"),
+		WithKMSEncryptionContext(map[string]string{"some": "context"}),
+		WithBucketKey(),
+	))
+
+	assert.NoError(t, err)
+
+	enc, _ := srv.Encryption(cfg.S3.Bucket, "some/kms")
+
+	assert.Equal(t, s3.ServerSideEncryptionAwsKms, enc.Get("X-Amz-Server-Side-Encryption"))
+	assert.Equal(t, "some_key", enc.Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"))
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(`{"some":"context"}`)), enc.Get("X-Amz-Server-Side-Encryption-Context"))
+	assert.Equal(t, "true", enc.Get("X-Amz-Server-Side-Encryption-Bucket-Key-Enabled"))
+
+	key := bytes.Repeat([]byte("k"), CustomerKeySize)
+
+	assert.NoError(t, s3Svc.S3PutObject(cfg.S3.Bucket, "some/sse-c", path, WithPutEncryption(WithSSEC(key))))
+
+	_, err = s3Svc.S3GetObject(cfg.S3.Bucket, "some/sse-c")
+
+	assert.Error(t, err)
+	assert.Equal(t, "InvalidRequest", err.(awserr.Error).Code())
+
+	_, err = s3Svc.S3GetObject(cfg.S3.Bucket, "some/sse-c", WithSSEC(bytes.Repeat([]byte("o"), CustomerKeySize)))
+
+	assert.Error(t, err)
+	assert.Equal(t, "AccessDenied", err.(awserr.Error).Code())
+
+	body, err := s3Svc.S3GetObject(cfg.S3.Bucket, "some/sse-c", WithSSEC(key))
+
+	assert.NoError(t, err)
+
+	stored, _ := srv.Object(cfg.S3.Bucket, "some/sse-c")
+
+	assert.Equal(t, stored, body)
+
+}
+
+func TestS3_S3Upload_Encryption(t *testing.T) {
+
+	srv := fake.NewTLS()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	key := bytes.Repeat([]byte("k"), CustomerKeySize)
+	body := bytes.Repeat([]byte("0123456789"), (MinPartSize+MinPartSize/2)/10)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/key", bytes.NewReader(body), WithEncryption(WithSSEC(key))))
+
+	enc, _ := srv.Encryption(cfg.S3.Bucket, "some/key")
+
+	assert.Equal(t, "AES256", enc.Get("X-Amz-Server-Side-Encryption-Customer-Algorithm"))
+
+	_, err := s3Svc.S3Download(cfg.S3.Bucket, "some/key", &bytes.Buffer{})
+
+	assert.Error(t, err)
+
+	buf := &bytes.Buffer{}
+
+	n, err := s3Svc.S3Download(cfg.S3.Bucket, "some/key", buf, WithDownloadEncryption(WithSSEC(key)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(body)), n)
+	assert.Equal(t, body, buf.Bytes())
+
+	w := &writerAt{}
+
+	n, err = s3Svc.S3DownloadAt(cfg.S3.Bucket, "some/key", w, WithDownloadEncryption(WithSSEC(key)), WithDownloadPartSize(MinPartSize))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(body)), n)
+	assert.Equal(t, body, w.buf)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "other/key", bytes.NewReader([]byte("some_body")), WithEncryption(WithSSES3())))
+
+	enc, _ = srv.Encryption(cfg.S3.Bucket, "other/key")
+
+	assert.Equal(t, s3.ServerSideEncryptionAes256, enc.Get("X-Amz-Server-Side-Encryption"))
+
+}
+
+func TestS3_S3Copy_Encryption(t *testing.T) {
+
+	srv := fake.NewTLS()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	key := bytes.Repeat([]byte("k"), CustomerKeySize)
+	otherKey := bytes.Repeat([]byte("o"), CustomerKeySize)
+	body := bytes.Repeat([]byte("0123456789"), (MinPartSize+MinPartSize/2)/10)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/key", bytes.NewReader(body), WithEncryption(WithSSEC(key))))
+
+	err := s3Svc.S3Copy(cfg.S3.Bucket, "some/key", cfg.S3.Bucket, "other/key")
+
+	assert.Error(t, err)
+
+	err = s3Svc.S3Copy(cfg.S3.Bucket, "some/key", cfg.S3.Bucket, "other/key",
+		WithCopySourceEncryption(WithSSEC(key)),
+		WithCopyEncryption(WithSSEKMS("")),
+	)
+
+	assert.NoError(t, err)
+
+	enc, _ := srv.Encryption(cfg.S3.Bucket, "other/key")
+
+	assert.Equal(t, s3.ServerSideEncryptionAwsKms, enc.Get("X-Amz-Server-Side-Encryption"))
+	assert.Empty(t, enc.Get("X-Amz-Server-Side-Encryption-Customer-Algorithm"))
+
+	// multipart copies and moves re-encrypt every part with the key of the destination
+	err = s3Svc.S3Move(cfg.S3.Bucket, "some/key", cfg.S3.Bucket, "moved/key",
+		WithCopySourceEncryption(WithSSEC(key)),
+		WithCopyEncryption(WithSSEC(otherKey)),
+		WithCopyPartSize(MinPartSize),
+	)
+
+	assert.NoError(t, err)
+
+	_, ok := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.False(t, ok)
+
+	moved, err := s3Svc.S3GetObject(cfg.S3.Bucket, "moved/key", WithSSEC(otherKey))
+
+	assert.NoError(t, err)
+	assert.Equal(t, body, moved)
+
+}
+
+func TestEncryption_Validation(t *testing.T) {
+
+	key := bytes.Repeat([]byte("k"), CustomerKeySize)
+
+	for param, opts := range map[string][]EncryptionOption{
+		ServerSideEncryption: {WithSSES3(), WithSSEC(key)},
+		CustomerKey:          {WithSSEC(key[1:])},
+		EncryptionContext:    {WithSSEKMS(""), WithKMSEncryptionContext(map[string]string{"": "context"})},
+	} {
+
+		_, err := newEncryption(opts...)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), param)
+
+	}
+
+	// the SSE-KMS parameters need SSE-KMS
+	for _, opt := range []EncryptionOption{WithKMSEncryptionContext(map[string]string{"some": "context"}), WithBucketKey()} {
+
+		_, err := newEncryption(WithSSES3(), opt)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), ServerSideEncryption)
+
+	}
+
+	enc, err := newEncryption()
+
+	assert.NoError(t, err)
+	assert.Nil(t, enc)
+	assert.Nil(t, enc.requestOptions())
+
+	s3Svc := &S3{}
+
+	_, err = s3Svc.S3Download("some_bucket", "some/key", &bytes.Buffer{}, WithDownloadEncryption(WithSSES3()))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ServerSideEncryption)
+
+	err = s3Svc.S3Copy("some_bucket", "some/key", "other_bucket", "other/key", WithCopySourceEncryption(WithSSEKMS("")))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ServerSideEncryption)
+
+	err = s3Svc.S3Upload("some_bucket", "some/key", &bytes.Buffer{}, WithEncryption(WithBucketKey()))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ServerSideEncryption)
+
+}
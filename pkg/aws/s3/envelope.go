@@ -0,0 +1,234 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+const (
+
+	// ContentAlgorithmAESGCM is the algorithm EncryptionClient encrypts object bodies with
+	ContentAlgorithmAESGCM = "AES/GCM/NoPadding"
+
+	// gcmTagSize is the size in bits of the authentication tag appended to encrypted bodies
+	gcmTagSize = 128
+
+	// the metadata of encrypted objects, named as the ones of the encryption clients of the AWS SDKs
+	metaKey                 = "X-Amz-Key-V2"
+	metaIV                  = "X-Amz-Iv"
+	metaWrapAlgorithm       = "X-Amz-Wrap-Alg"
+	metaContentAlgorithm    = "X-Amz-Cek-Alg"
+	metaTagLength           = "X-Amz-Tag-Len"
+	metaUnencryptedLength   = "X-Amz-Unencrypted-Content-Length"
+	metaMaterialDescription = "X-Amz-Matdesc"
+)
+
+// EncryptionClient encrypts objects client-side before they are put on S3, so that their body
+// never leaves the process in clear. Every object is encrypted with AES-GCM and its own data key,
+// stored in its metadata once wrapped by the KeyProvider
+type EncryptionClient struct {
+	svc      *S3
+	provider KeyProvider
+}
+
+// NewEncryptionClient returns a new *EncryptionClient putting and getting objects with svc,
+// and their data keys with provider
+func NewEncryptionClient(svc *S3, provider KeyProvider) (*EncryptionClient, error) {
+
+	if svc == nil {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Service)
+	}
+	if provider == nil {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Provider)
+	}
+
+	return &EncryptionClient{svc: svc, provider: provider}, nil
+
+}
+
+// S3PutObject encrypts a given object and puts it on S3. opts can also set its content type,
+// or encrypt it server-side with WithPutEncryption
+func (c *EncryptionClient) S3PutObject(bucketName, objectName, objectPath string, opts ...PutOption) error {
+	return c.S3PutObjectWithContext(context.Background(), bucketName, objectName, objectPath, opts...)
+}
+
+// S3PutObjectWithContext is the same as S3PutObject with the addition of a context.Context
+func (c *EncryptionClient) S3PutObjectWithContext(ctx context.Context, bucketName, objectName, objectPath string, opts ...PutOption) error {
+
+	put, err := newPutInput(opts...)
+	if err != nil {
+		return err
+	}
+
+	imgMeta, err := readImage(objectPath, put.contentType)
+	if err != nil {
+		return err
+	}
+
+	in, err := NewPutObjectInput(
+		bucketName,
+		objectName,
+		imgMeta.ContentType,
+		imgMeta.Body,
+		imgMeta.ContentSize,
+	)
+	if err != nil {
+		return err
+	}
+
+	put.encryption.putObject(in)
+
+	key, err := c.provider.GenerateDataKey(ctx)
+	if err != nil {
+		return err
+	}
+
+	body, metadata, err := encryptBody(key, imgMeta.Body)
+	if err != nil {
+		return err
+	}
+
+	in = in.SetBody(bytes.NewReader(body))
+	in = in.SetContentLength(int64(len(body)))
+	in = in.SetMetadata(aws.StringMap(metadata))
+
+	_, err = c.svc.S3.PutObjectWithContext(ctx, in, put.encryption.requestOptions()...)
+	if err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
+
+// S3GetObject retrieves an object from S3 and decrypts it when it has been put by an *EncryptionClient.
+// Objects stored in clear are returned as is. Objects encrypted with SSE-C need the WithSSEC key they were written with
+func (c *EncryptionClient) S3GetObject(bucketName, sourceImage string, opts ...EncryptionOption) ([]byte, error) {
+	return c.S3GetObjectWithContext(context.Background(), bucketName, sourceImage, opts...)
+}
+
+// S3GetObjectWithContext is the same as S3GetObject with the addition of a context.Context
+func (c *EncryptionClient) S3GetObjectWithContext(ctx context.Context, bucketName, sourceImage string, opts ...EncryptionOption) ([]byte, error) {
+
+	in, err := NewGetObjectInput(
+		bucketName,
+		sourceImage,
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.svc.S3.GetObjectWithContext(ctx, in)
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	defer out.Body.Close()
+
+	body, err := ioutil.ReadAll(out.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make(map[string]string, len(out.Metadata))
+	for k, v := range out.Metadata {
+		metadata[http.CanonicalHeaderKey(k)] = aws.StringValue(v)
+	}
+
+	if _, ok := metadata[metaKey]; !ok {
+		return body, nil
+	}
+
+	return c.decryptBody(ctx, metadata, body)
+
+}
+
+// encryptBody encrypts body with key and returns it along with the metadata needed to decrypt it
+func encryptBody(key *DataKey, body []byte) ([]byte, map[string]string, error) {
+
+	aead, err := newGCM(key.Plaintext)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	iv, err := randomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	description, err := json.Marshal(key.Description)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata := map[string]string{
+		metaKey:                 base64.StdEncoding.EncodeToString(key.Wrapped),
+		metaIV:                  base64.StdEncoding.EncodeToString(iv),
+		metaWrapAlgorithm:       key.WrapAlgorithm,
+		metaContentAlgorithm:    ContentAlgorithmAESGCM,
+		metaTagLength:           strconv.Itoa(gcmTagSize),
+		metaUnencryptedLength:   strconv.Itoa(len(body)),
+		metaMaterialDescription: string(description),
+	}
+
+	return aead.Seal(nil, iv, body, nil), metadata, nil
+
+}
+
+// decryptBody decrypts body with the data key and IV stored in metadata
+func (c *EncryptionClient) decryptBody(ctx context.Context, metadata map[string]string, body []byte) ([]byte, error) {
+
+	if metadata[metaContentAlgorithm] != ContentAlgorithmAESGCM || metadata[metaTagLength] != strconv.Itoa(gcmTagSize) {
+		return nil, intErr.NewValidationError(ErrUnsupportedEncryption, ObjectName)
+	}
+
+	wrapped, err := base64.StdEncoding.DecodeString(metadata[metaKey])
+	if err != nil {
+		return nil, intErr.NewValidationError(ErrDecryptionFailed, ObjectName)
+	}
+
+	iv, err := base64.StdEncoding.DecodeString(metadata[metaIV])
+	if err != nil {
+		return nil, intErr.NewValidationError(ErrDecryptionFailed, ObjectName)
+	}
+
+	key := &DataKey{
+		Wrapped:       wrapped,
+		WrapAlgorithm: metadata[metaWrapAlgorithm],
+	}
+
+	if err := json.Unmarshal([]byte(metadata[metaMaterialDescription]), &key.Description); err != nil {
+		return nil, intErr.NewValidationError(ErrDecryptionFailed, ObjectName)
+	}
+
+	plaintext, err := c.provider.DecryptDataKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newGCM(plaintext)
+	if err != nil {
+		return nil, intErr.NewValidationError(ErrDecryptionFailed, ObjectName)
+	}
+	if len(iv) != aead.NonceSize() {
+		return nil, intErr.NewValidationError(ErrDecryptionFailed, ObjectName)
+	}
+
+	out, err := aead.Open(nil, iv, body, nil)
+	if err != nil {
+		return nil, intErr.NewValidationError(ErrDecryptionFailed, ObjectName)
+	}
+
+	return out, nil
+
+}
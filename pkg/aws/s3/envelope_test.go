@@ -0,0 +1,181 @@
+package s3
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestEncryptionClient_S3PutObject(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	provider, err := NewLocalKeyProvider(bytes.Repeat([]byte("m"), DataKeySize))
+
+	assert.NoError(t, err)
+
+	c, err := NewEncryptionClient(s3Svc, provider)
+
+	assert.NoError(t, err)
+
+	path := "../../../assets/compare_faces_test-source.jpg"
+
+	img, err := ReadImage(path)
+
+	assert.NoError(t, err)
+	assert.NoError(t, c.S3PutObject(cfg.S3.Bucket, "some/key", path))
+
+	stored, _ := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.NotEqual(t, img.Body, stored)
+	assert.Len(t, stored, len(img.Body)+gcmTagSize/8)
+
+	head := headObject(t, s3Svc, cfg.S3.Bucket, "some/key")
+
+	assert.Equal(t, img.ContentType, aws.StringValue(head.ContentType))
+	assert.Equal(t, WrapAlgorithmAESGCM, aws.StringValue(head.Metadata[metaWrapAlgorithm]))
+	assert.Equal(t, ContentAlgorithmAESGCM, aws.StringValue(head.Metadata[metaContentAlgorithm]))
+	assert.NotEmpty(t, aws.StringValue(head.Metadata[metaKey]))
+	assert.NotEmpty(t, aws.StringValue(head.Metadata[metaIV]))
+
+	body, err := c.S3GetObject(cfg.S3.Bucket, "some/key")
+
+	assert.NoError(t, err)
+	assert.Equal(t, img.Body, body)
+
+	// every object has its own data key
+	assert.NoError(t, c.S3PutObject(cfg.S3.Bucket, "other/key", path, WithPutContentType("some/type")))
+
+	other := headObject(t, s3Svc, cfg.S3.Bucket, "other/key")
+
+	assert.NotEqual(t, head.Metadata[metaKey], other.Metadata[metaKey])
+	assert.Equal(t, "some/type", aws.StringValue(other.ContentType))
+
+	// objects stored in clear are still readable
+	assert.NoError(t, s3Svc.S3PutObject(cfg.S3.Bucket, "plain/key", path))
+
+	body, err = c.S3GetObject(cfg.S3.Bucket, "plain/key")
+
+	assert.NoError(t, err)
+	assert.Equal(t, img.Body, body)
+
+	// with the wrong master key
+	otherProvider, _ := NewLocalKeyProvider(bytes.Repeat([]byte("o"), DataKeySize))
+	otherClient, _ := NewEncryptionClient(s3Svc, otherProvider)
+
+	_, err = otherClient.S3GetObject(cfg.S3.Bucket, "some/key")
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrDecryptionFailed, "")))
+
+	// with a tampered body
+	_, err = s3Svc.S3.PutObject(&s3.PutObjectInput{
+		Bucket:   aws.String(cfg.S3.Bucket),
+		Key:      aws.String("tampered/key"),
+		Body:     bytes.NewReader(append(stored[:len(stored)-1:len(stored)-1], stored[len(stored)-1]^1)),
+		Metadata: head.Metadata,
+	})
+
+	assert.NoError(t, err)
+
+	_, err = c.S3GetObject(cfg.S3.Bucket, "tampered/key")
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrDecryptionFailed, ObjectName)))
+
+	_, err = c.S3GetObject(cfg.S3.Bucket, "some_missing_key")
+
+	assert.Error(t, err)
+
+}
+
+func TestEncryptionClient_S3PutObject_Encryption(t *testing.T) {
+
+	srv := fake.NewTLS()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	provider, _ := NewLocalKeyProvider(bytes.Repeat([]byte("m"), DataKeySize))
+	c, _ := NewEncryptionClient(s3Svc, provider)
+
+	key := bytes.Repeat([]byte("k"), CustomerKeySize)
+	path := "../../../assets/compare_faces_test-source.jpg"
+
+	assert.NoError(t, c.S3PutObject(cfg.S3.Bucket, "some/key", path, WithPutEncryption(WithSSEC(key))))
+
+	_, err := c.S3GetObject(cfg.S3.Bucket, "some/key")
+
+	assert.Error(t, err)
+
+	body, err := c.S3GetObject(cfg.S3.Bucket, "some/key", WithSSEC(key))
+
+	assert.NoError(t, err)
+
+	img, _ := ReadImage(path)
+
+	assert.Equal(t, img.Body, body)
+
+}
+
+func TestEncryptionClient_Validation(t *testing.T) {
+
+	provider, _ := NewLocalKeyProvider(bytes.Repeat([]byte("m"), DataKeySize))
+
+	for param, f := range map[string]func() error{
+		Service: func() error {
+			_, err := NewEncryptionClient(nil, provider)
+			return err
+		},
+		Provider: func() error {
+			_, err := NewEncryptionClient(&S3{}, nil)
+			return err
+		},
+	} {
+
+		err := f()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), param)
+
+	}
+
+	c, _ := NewEncryptionClient(&S3{}, provider)
+
+	for param, f := range map[string]func() error{
+		BucketName: func() error {
+			return c.S3PutObject("", "some/key", "../../../assets/compare_faces_test-source.jpg")
+		},
+		Path: func() error {
+			return c.S3PutObject("some_bucket", "some/key", "")
+		},
+		Source: func() error {
+			_, err := c.S3GetObject("some_bucket", "")
+			return err
+		},
+		ServerSideEncryption: func() error {
+			_, err := c.S3GetObject("some_bucket", "some/key", WithSSES3())
+			return err
+		},
+	} {
+
+		err := f()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), param)
+
+	}
+
+}
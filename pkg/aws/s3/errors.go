@@ -1,5 +1,7 @@
 package s3
 
+import "strings"
+
 const (
 
 	// ErrEmptyParameter is used when a required parameter is empty
@@ -10,4 +12,37 @@ const (
 
 	// ErrEmptyContentLength is used when no content length has been passed
 	ErrEmptyContentLength = "EmptyContentLength"
+
+	// ErrInvalidParameter is used when a parameter is out of its allowed range
+	ErrInvalidParameter = "InvalidParameter"
+
+	// ErrTooManyParts is used when a body would be split in more than MaxParts parts
+	ErrTooManyParts = "TooManyParts"
+
+	// ErrObjectChanged is used when an object has been modified while being downloaded
+	ErrObjectChanged = "ObjectChanged"
+
+	// ErrCopyNotVerified is the code of a CopyNotVerifiedError
+	ErrCopyNotVerified = "CopyNotVerified"
+
+	// ErrUnsupportedEncryption is used when an object has been encrypted client-side with an algorithm or key provider not in use
+	ErrUnsupportedEncryption = "UnsupportedEncryption"
+
+	// ErrDecryptionFailed is used when an object or its data key cannot be decrypted
+	ErrDecryptionFailed = "DecryptionFailed"
 )
+
+// CopyNotVerifiedError is returned by S3Move when the copy of the moved object does not match
+// its source, which is then left in place
+type CopyNotVerifiedError struct {
+	// BucketName and ObjectName locate the copy
+	BucketName string
+	ObjectName string
+	// Field is the field of the copy that differs from the source, ContentLength or ETag
+	Field string
+}
+
+// Error returns the error as `CopyNotVerified : BucketName/ObjectName : Field`
+func (e *CopyNotVerifiedError) Error() string {
+	return strings.Join([]string{ErrCopyNotVerified, e.BucketName + "/" + e.ObjectName, e.Field}, " : ")
+}
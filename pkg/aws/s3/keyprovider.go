@@ -0,0 +1,208 @@
+package s3
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+const (
+
+	// DataKeySize is the size of the AES-256 data keys encrypting objects client-side,
+	// and of the master keys of LocalKeyProvider
+	DataKeySize = 32
+
+	// WrapAlgorithmAESGCM is the wrapping algorithm of LocalKeyProvider
+	WrapAlgorithmAESGCM = "AES/GCM"
+
+	// WrapAlgorithmKMS is the wrapping algorithm of KMSKeyProvider
+	WrapAlgorithmKMS = "kms"
+
+	// kmsKeyIDContext is the encryption context KMSKeyProvider binds its data keys to
+	kmsKeyIDContext = "kms_cmk_id"
+)
+
+// DataKey is a data key encrypting a single object client-side
+type DataKey struct {
+	// Plaintext is the key in clear, never stored
+	Plaintext []byte
+	// Wrapped is the key encrypted by the KeyProvider, stored in the object metadata
+	Wrapped []byte
+	// WrapAlgorithm is the algorithm Wrapped has been encrypted with
+	WrapAlgorithm string
+	// Description is the material description stored along Wrapped
+	Description map[string]string
+}
+
+// KeyProvider generates the data keys of EncryptionClient and unwraps them back
+type KeyProvider interface {
+	// GenerateDataKey returns a new DataKeySize bytes data key, in clear and wrapped
+	GenerateDataKey(ctx context.Context) (*DataKey, error)
+	// DecryptDataKey returns the plaintext of key, read from the object metadata
+	DecryptDataKey(ctx context.Context, key *DataKey) ([]byte, error)
+}
+
+// LocalKeyProvider wraps data keys with AES-GCM and a master key held in memory.
+// It is meant for tests and local development, KMSKeyProvider being used in production
+type LocalKeyProvider struct {
+	aead cipher.AEAD
+}
+
+// NewLocalKeyProvider returns a new *LocalKeyProvider given a DataKeySize bytes master key
+func NewLocalKeyProvider(masterKey []byte) (*LocalKeyProvider, error) {
+
+	if len(masterKey) != DataKeySize {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, MasterKey)
+	}
+
+	aead, err := newGCM(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalKeyProvider{aead: aead}, nil
+
+}
+
+// GenerateDataKey returns a new random data key wrapped with the master key
+func (p *LocalKeyProvider) GenerateDataKey(ctx context.Context) (*DataKey, error) {
+
+	key, err := randomBytes(DataKeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomBytes(p.aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+
+	out := &DataKey{
+		Plaintext:     key,
+		Wrapped:       p.aead.Seal(nonce, nonce, key, []byte(WrapAlgorithmAESGCM)),
+		WrapAlgorithm: WrapAlgorithmAESGCM,
+		Description:   map[string]string{},
+	}
+
+	return out, nil
+
+}
+
+// DecryptDataKey unwraps key with the master key
+func (p *LocalKeyProvider) DecryptDataKey(ctx context.Context, key *DataKey) ([]byte, error) {
+
+	if key.WrapAlgorithm != WrapAlgorithmAESGCM {
+		return nil, intErr.NewValidationError(ErrUnsupportedEncryption, Provider)
+	}
+
+	n := p.aead.NonceSize()
+	if len(key.Wrapped) < n {
+		return nil, intErr.NewValidationError(ErrDecryptionFailed, Provider)
+	}
+
+	out, err := p.aead.Open(nil, key.Wrapped[:n], key.Wrapped[n:], []byte(WrapAlgorithmAESGCM))
+	if err != nil {
+		return nil, intErr.NewValidationError(ErrDecryptionFailed, Provider)
+	}
+
+	return out, nil
+
+}
+
+// KMSKeyProvider generates data keys with KMS, under a KMS key
+type KMSKeyProvider struct {
+	svc   kmsiface.KMSAPI
+	keyID string
+}
+
+// NewKMSKeyProvider returns a new *KMSKeyProvider given a KMS client, like kms.New(awsSvc),
+// and the ID, ARN or alias of the KMS key data keys are generated under
+func NewKMSKeyProvider(svc kmsiface.KMSAPI, keyID string) (*KMSKeyProvider, error) {
+
+	if svc == nil {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Service)
+	}
+	if keyID == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, KeyID)
+	}
+
+	return &KMSKeyProvider{svc: svc, keyID: keyID}, nil
+
+}
+
+// GenerateDataKey returns a new data key generated by KMS, bound to an encryption context naming the KMS key
+func (p *KMSKeyProvider) GenerateDataKey(ctx context.Context) (*DataKey, error) {
+
+	description := map[string]string{kmsKeyIDContext: p.keyID}
+
+	in := &kms.GenerateDataKeyInput{}
+	in = in.SetKeyId(p.keyID)
+	in = in.SetKeySpec(kms.DataKeySpecAes256)
+	in = in.SetEncryptionContext(aws.StringMap(description))
+
+	out, err := p.svc.GenerateDataKeyWithContext(ctx, in)
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	return &DataKey{
+		Plaintext:     out.Plaintext,
+		Wrapped:       out.CiphertextBlob,
+		WrapAlgorithm: WrapAlgorithmKMS,
+		Description:   description,
+	}, nil
+
+}
+
+// DecryptDataKey has KMS decrypt key with the encryption context it was generated with
+func (p *KMSKeyProvider) DecryptDataKey(ctx context.Context, key *DataKey) ([]byte, error) {
+
+	if key.WrapAlgorithm != WrapAlgorithmKMS {
+		return nil, intErr.NewValidationError(ErrUnsupportedEncryption, Provider)
+	}
+
+	in := &kms.DecryptInput{}
+	in = in.SetCiphertextBlob(key.Wrapped)
+	in = in.SetEncryptionContext(aws.StringMap(key.Description))
+
+	out, err := p.svc.DecryptWithContext(ctx, in)
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	return out.Plaintext, nil
+
+}
+
+// newGCM returns the AES-GCM cipher of key
+func newGCM(key []byte) (cipher.AEAD, error) {
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+
+}
+
+// randomBytes returns n bytes read from crypto/rand
+func randomBytes(n int) ([]byte, error) {
+
+	out := make([]byte, n)
+
+	if _, err := io.ReadFull(rand.Reader, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+
+}
@@ -0,0 +1,139 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/stretchr/testify/assert"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+func TestLocalKeyProvider(t *testing.T) {
+
+	_, err := NewLocalKeyProvider([]byte("some_short_key"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), MasterKey)
+
+	p, err := NewLocalKeyProvider(bytes.Repeat([]byte("m"), DataKeySize))
+
+	assert.NoError(t, err)
+
+	key, err := p.GenerateDataKey(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, key.Plaintext, DataKeySize)
+	assert.Equal(t, WrapAlgorithmAESGCM, key.WrapAlgorithm)
+	assert.False(t, bytes.Contains(key.Wrapped, key.Plaintext))
+
+	plaintext, err := p.DecryptDataKey(context.Background(), &DataKey{Wrapped: key.Wrapped, WrapAlgorithm: key.WrapAlgorithm})
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Plaintext, plaintext)
+
+	_, err = p.DecryptDataKey(context.Background(), &DataKey{Wrapped: key.Wrapped, WrapAlgorithm: WrapAlgorithmKMS})
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrUnsupportedEncryption, Provider)))
+
+	for _, wrapped := range [][]byte{key.Wrapped[:4], append([]byte{key.Wrapped[0] ^ 1}, key.Wrapped[1:]...)} {
+
+		_, err = p.DecryptDataKey(context.Background(), &DataKey{Wrapped: wrapped, WrapAlgorithm: WrapAlgorithmAESGCM})
+
+		assert.True(t, errors.Is(err, intErr.NewValidationError(ErrDecryptionFailed, Provider)))
+
+	}
+
+}
+
+func TestKMSKeyProvider(t *testing.T) {
+
+	_, err := NewKMSKeyProvider(nil, "some_key")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), Service)
+
+	_, err = NewKMSKeyProvider(&kmsStub{}, "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), KeyID)
+
+	svc := &kmsStub{}
+
+	p, err := NewKMSKeyProvider(svc, "alias/some_key")
+
+	assert.NoError(t, err)
+
+	key, err := p.GenerateDataKey(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "alias/some_key", aws.StringValue(svc.generated.KeyId))
+	assert.Equal(t, kms.DataKeySpecAes256, aws.StringValue(svc.generated.KeySpec))
+	assert.Equal(t, WrapAlgorithmKMS, key.WrapAlgorithm)
+	assert.Equal(t, map[string]string{kmsKeyIDContext: "alias/some_key"}, key.Description)
+
+	plaintext, err := p.DecryptDataKey(context.Background(), &DataKey{
+		Wrapped:       key.Wrapped,
+		WrapAlgorithm: key.WrapAlgorithm,
+		Description:   key.Description,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, key.Plaintext, plaintext)
+
+	// KMS refuses data keys decrypted with another encryption context
+	_, err = p.DecryptDataKey(context.Background(), &DataKey{
+		Wrapped:       key.Wrapped,
+		WrapAlgorithm: key.WrapAlgorithm,
+		Description:   map[string]string{kmsKeyIDContext: "alias/other_key"},
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, kms.ErrCodeInvalidCiphertextException, err.(awserr.Error).Code())
+
+	_, err = p.DecryptDataKey(context.Background(), &DataKey{Wrapped: key.Wrapped, WrapAlgorithm: WrapAlgorithmAESGCM})
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrUnsupportedEncryption, Provider)))
+
+}
+
+// kmsStub generates data keys "wrapped" along their encryption context
+type kmsStub struct {
+	kmsiface.KMSAPI
+	generated *kms.GenerateDataKeyInput
+	contexts  map[string]map[string]*string
+}
+
+func (s *kmsStub) GenerateDataKeyWithContext(ctx aws.Context, in *kms.GenerateDataKeyInput, opts ...request.Option) (*kms.GenerateDataKeyOutput, error) {
+
+	s.generated = in
+
+	plaintext, _ := randomBytes(DataKeySize)
+	wrapped := append([]byte("wrapped:"), plaintext...)
+
+	if s.contexts == nil {
+		s.contexts = make(map[string]map[string]*string)
+	}
+	s.contexts[string(wrapped)] = in.EncryptionContext
+
+	return &kms.GenerateDataKeyOutput{Plaintext: plaintext, CiphertextBlob: wrapped, KeyId: in.KeyId}, nil
+
+}
+
+func (s *kmsStub) DecryptWithContext(ctx aws.Context, in *kms.DecryptInput, opts ...request.Option) (*kms.DecryptOutput, error) {
+
+	context, ok := s.contexts[string(in.CiphertextBlob)]
+	if !ok || !assert.ObjectsAreEqual(aws.StringValueMap(context), aws.StringValueMap(in.EncryptionContext)) {
+		return nil, awserr.New(kms.ErrCodeInvalidCiphertextException, "invalid ciphertext", nil)
+	}
+
+	return &kms.DecryptOutput{Plaintext: in.CiphertextBlob[len("wrapped:"):]}, nil
+
+}
@@ -0,0 +1,285 @@
+package s3
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+// Entry is an object or, when a delimiter is set, a common prefix returned by S3ListObjects
+type Entry struct {
+	Key          string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+	StorageClass string
+	// IsPrefix is true when Entry is a common prefix, a "directory" grouping every key
+	// sharing Key up to the delimiter. Only Key is set then
+	IsPrefix bool
+}
+
+// ListInput contains the optional parameters of S3ListObjects
+type ListInput struct {
+	prefix     string
+	delimiter  string
+	startAfter string
+	maxEntries int
+	pageSize   int64
+}
+
+// ListOption sets an optional parameter on a *ListInput
+type ListOption func(*ListInput) error
+
+// ObjectIterator iterates over the entries of a bucket in lexicographical order,
+// fetching ListObjectsV2 pages as needed:
+//
+//	it := svc.S3ListObjects("some_bucket", s3.WithPrefix("some/"))
+//	for it.Next() {
+//		entry := it.Entry()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ObjectIterator struct {
+	ctx   context.Context
+	svc   *S3
+	input *s3.ListObjectsV2Input
+	max   int
+
+	page  []Entry
+	entry Entry
+	count int
+	more  bool
+	err   error
+
+	// tail is the error set by NewObjectIterator, returned once its entries are exhausted
+	tail error
+}
+
+// WithPrefix only lists the keys starting with prefix
+func WithPrefix(prefix string) ListOption {
+	return func(in *ListInput) error {
+
+		in.prefix = prefix
+
+		return nil
+
+	}
+}
+
+// WithDelimiter groups the keys containing delimiter after the prefix into common prefixes,
+// returned as entries with IsPrefix set, like directories. delimiter is usually "/"
+func WithDelimiter(delimiter string) ListOption {
+	return func(in *ListInput) error {
+
+		if delimiter == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, Delimiter)
+		}
+
+		in.delimiter = delimiter
+
+		return nil
+
+	}
+}
+
+// WithStartAfter only lists the keys coming after startAfter
+func WithStartAfter(startAfter string) ListOption {
+	return func(in *ListInput) error {
+
+		if startAfter == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, StartAfter)
+		}
+
+		in.startAfter = startAfter
+
+		return nil
+
+	}
+}
+
+// WithMaxEntries stops the iteration after maxEntries entries, common prefixes included
+func WithMaxEntries(maxEntries int) ListOption {
+	return func(in *ListInput) error {
+
+		if maxEntries < 1 {
+			return intErr.NewValidationError(ErrInvalidParameter, MaxEntries)
+		}
+
+		in.maxEntries = maxEntries
+
+		return nil
+
+	}
+}
+
+// WithPageSize sets how many entries are fetched by every ListObjectsV2 request, at most 1000
+func WithPageSize(pageSize int64) ListOption {
+	return func(in *ListInput) error {
+
+		if pageSize < 1 || pageSize > 1000 {
+			return intErr.NewValidationError(ErrInvalidParameter, PageSize)
+		}
+
+		in.pageSize = pageSize
+
+		return nil
+
+	}
+}
+
+// S3ListObjects returns an *ObjectIterator over the entries of bucketName.
+// Validation errors are returned by its Err method
+func (svc *S3) S3ListObjects(bucketName string, opts ...ListOption) *ObjectIterator {
+	return svc.S3ListObjectsWithContext(context.Background(), bucketName, opts...)
+}
+
+// S3ListObjectsWithContext is the same as S3ListObjects with the addition of a context.Context.
+// The iteration stops as soon as ctx is done, with ctx.Err() returned by Err
+func (svc *S3) S3ListObjectsWithContext(ctx context.Context, bucketName string, opts ...ListOption) *ObjectIterator {
+
+	it := &ObjectIterator{
+		ctx:  ctx,
+		svc:  svc,
+		more: true,
+	}
+
+	if bucketName == "" {
+		it.err = intErr.NewValidationError(ErrEmptyParameter, BucketName)
+		return it
+	}
+
+	in := &ListInput{}
+
+	for _, opt := range opts {
+		if err := opt(in); err != nil {
+			it.err = err
+			return it
+		}
+	}
+
+	input := &s3.ListObjectsV2Input{}
+	input = input.SetBucket(bucketName)
+
+	if in.prefix != "" {
+		input = input.SetPrefix(in.prefix)
+	}
+	if in.delimiter != "" {
+		input = input.SetDelimiter(in.delimiter)
+	}
+	if in.startAfter != "" {
+		input = input.SetStartAfter(in.startAfter)
+	}
+	if in.pageSize > 0 {
+		input = input.SetMaxKeys(in.pageSize)
+	}
+
+	it.input = input
+	it.max = in.maxEntries
+
+	return it
+
+}
+
+// NewObjectIterator returns an *ObjectIterator over a fixed list of entries, followed by err
+// if not nil. It is meant for mocks and tests
+func NewObjectIterator(entries []Entry, err error) *ObjectIterator {
+	return &ObjectIterator{
+		ctx:  context.Background(),
+		page: entries,
+		more: err != nil,
+		tail: err,
+	}
+}
+
+// Next advances the iterator to the next entry and returns true, or returns false
+// when there are no entries left or an error occurred
+func (it *ObjectIterator) Next() bool {
+
+	if it.err != nil || (it.max > 0 && it.count >= it.max) {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	for len(it.page) == 0 {
+		if !it.more {
+			return false
+		}
+		if it.fetch(); it.err != nil {
+			return false
+		}
+	}
+
+	it.entry = it.page[0]
+	it.page = it.page[1:]
+	it.count++
+
+	return true
+
+}
+
+// Entry returns the current entry
+func (it *ObjectIterator) Entry() Entry {
+	return it.entry
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ObjectIterator) Err() error {
+	return it.err
+}
+
+// fetch fetches the next page of entries
+func (it *ObjectIterator) fetch() {
+
+	if it.svc == nil {
+		it.err = it.tail
+		it.more = false
+		return
+	}
+
+	out, err := it.svc.S3.ListObjectsV2WithContext(it.ctx, it.input)
+	if err != nil {
+		it.err = intErr.Wrap(err)
+		return
+	}
+
+	page := make([]Entry, 0, len(out.Contents)+len(out.CommonPrefixes))
+
+	for _, o := range out.Contents {
+		page = append(page, Entry{
+			Key:          aws.StringValue(o.Key),
+			Size:         aws.Int64Value(o.Size),
+			ETag:         aws.StringValue(o.ETag),
+			LastModified: aws.TimeValue(o.LastModified),
+			StorageClass: aws.StringValue(o.StorageClass),
+		})
+	}
+	for _, p := range out.CommonPrefixes {
+		page = append(page, Entry{
+			Key:      aws.StringValue(p.Prefix),
+			IsPrefix: true,
+		})
+	}
+
+	sort.Slice(page, func(i, j int) bool {
+		return page[i].Key < page[j].Key
+	})
+
+	it.page = page
+	it.more = aws.BoolValue(out.IsTruncated) && aws.StringValue(out.NextContinuationToken) != ""
+
+	if it.more {
+		it.input = it.input.SetContinuationToken(aws.StringValue(out.NextContinuationToken))
+	}
+
+}
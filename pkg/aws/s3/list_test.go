@@ -0,0 +1,157 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestS3_S3ListObjects(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	for _, key := range []string{"a", "dir/b", "dir/c", "dir/sub/d", "e", "f", "other/g"} {
+		assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, key, strings.NewReader(key)))
+	}
+
+	pages := 0
+
+	s3Svc.Handlers.Send.PushBack(func(r *request.Request) {
+		if _, ok := r.Params.(*s3.ListObjectsV2Input); ok {
+			pages++
+		}
+	})
+
+	it := s3Svc.S3ListObjects(cfg.S3.Bucket, WithPageSize(2))
+
+	assert.Equal(t, []string{"a", "dir/b", "dir/c", "dir/sub/d", "e", "f", "other/g"}, listKeys(t, it))
+	assert.Equal(t, 4, pages)
+
+	it = s3Svc.S3ListObjects(cfg.S3.Bucket, WithPrefix("dir/"))
+
+	assert.True(t, it.Next())
+
+	entry := it.Entry()
+
+	assert.Equal(t, "dir/b", entry.Key)
+	assert.Equal(t, int64(5), entry.Size)
+	assert.NotEmpty(t, entry.ETag)
+	assert.False(t, entry.LastModified.IsZero())
+	assert.Equal(t, "STANDARD", entry.StorageClass)
+	assert.False(t, entry.IsPrefix)
+
+	it = s3Svc.S3ListObjects(cfg.S3.Bucket, WithDelimiter("/"), WithPageSize(1))
+
+	assert.Equal(t, []string{"a", "dir/", "e", "f", "other/"}, listKeys(t, it))
+
+	it = s3Svc.S3ListObjects(cfg.S3.Bucket, WithPrefix("dir/"), WithDelimiter("/"))
+
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+	assert.True(t, it.Next())
+	assert.Equal(t, Entry{Key: "dir/sub/", IsPrefix: true}, it.Entry())
+	assert.False(t, it.Next())
+
+	it = s3Svc.S3ListObjects(cfg.S3.Bucket, WithStartAfter("dir/sub/d"), WithMaxEntries(2), WithPageSize(1))
+
+	assert.Equal(t, []string{"e", "f"}, listKeys(t, it))
+
+	it = s3Svc.S3ListObjects("some_missing_bucket")
+
+	assert.False(t, it.Next())
+	assert.Error(t, it.Err())
+	assert.Equal(t, s3.ErrCodeNoSuchBucket, it.Err().(awserr.Error).Code())
+
+}
+
+func TestS3_S3ListObjectsWithContext(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	for _, key := range []string{"a", "b", "c"} {
+		assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, key, strings.NewReader(key)))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	it := s3Svc.S3ListObjectsWithContext(ctx, cfg.S3.Bucket, WithPageSize(1))
+
+	assert.True(t, it.Next())
+
+	cancel()
+
+	assert.False(t, it.Next())
+	assert.Equal(t, context.Canceled, it.Err())
+
+}
+
+func TestS3_S3ListObjects_Validation(t *testing.T) {
+
+	s3Svc := &S3{}
+
+	it := s3Svc.S3ListObjects("")
+
+	assert.False(t, it.Next())
+	assert.Contains(t, it.Err().Error(), BucketName)
+
+	for param, opt := range map[string]ListOption{
+		Delimiter:  WithDelimiter(""),
+		StartAfter: WithStartAfter(""),
+		MaxEntries: WithMaxEntries(0),
+		PageSize:   WithPageSize(1001),
+	} {
+
+		it = s3Svc.S3ListObjects("some_bucket", opt)
+
+		assert.False(t, it.Next())
+		assert.Error(t, it.Err())
+		assert.Contains(t, it.Err().Error(), param)
+
+	}
+
+}
+
+func TestNewObjectIterator(t *testing.T) {
+
+	it := NewObjectIterator([]Entry{{Key: "some/key"}}, errors.New("some_error"))
+
+	assert.True(t, it.Next())
+	assert.Equal(t, "some/key", it.Entry().Key)
+	assert.NoError(t, it.Err())
+	assert.False(t, it.Next())
+	assert.EqualError(t, it.Err(), "some_error")
+
+}
+
+// listKeys returns the keys of every entry left in it
+func listKeys(t *testing.T, it *ObjectIterator) []string {
+
+	keys := []string{}
+
+	for it.Next() {
+		keys = append(keys, it.Entry().Key)
+	}
+
+	assert.NoError(t, it.Err())
+
+	return keys
+
+}
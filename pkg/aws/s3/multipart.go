@@ -0,0 +1,679 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/contenttype"
+)
+
+const (
+
+	// MaxPartSize is the maximum size of a part of a multipart upload
+	MaxPartSize = 5 * 1024 * 1024 * 1024
+
+	// MaxParts is the maximum number of parts of a multipart upload
+	MaxParts = 10000
+
+	// DefaultConcurrency is the number of parts uploaded at the same time by default
+	DefaultConcurrency = 4
+
+	// DefaultPartRetries is the number of times a failed part is retried by default
+	DefaultPartRetries = 3
+
+	// partRetryDelay is the delay before the first retry of a part, growing linearly
+	partRetryDelay = 100 * time.Millisecond
+)
+
+// partBody is the body of a part to be uploaded
+type partBody struct {
+	number  int64
+	body    io.ReadSeeker
+	size    int64
+	release func()
+
+	// copySource and copyRange are set instead of body when the part is copied
+	// from an existing object with UploadPartCopy, which must still match copyIfMatch
+	copySource  string
+	copyRange   string
+	copyIfMatch string
+}
+
+// feeder sends the parts to be uploaded to parts, until there is none left or ctx is done
+type feeder func(ctx context.Context, parts chan<- *partBody) error
+
+// multipartUpload uploads the parts of a single multipart upload
+type multipartUpload struct {
+	svc       *S3
+	in        *UploadInput
+	state     *UploadState
+	resumable bool
+
+	mu  sync.Mutex
+	err error
+}
+
+// WithPartSize sets the size of the parts of multipart uploads. By default, it is MinPartSize, raised for
+// uploads of a known size to the smallest multiple of a MiB fitting them in MaxParts parts.
+// Bodies not larger than partSize are uploaded with a single PutObject
+func WithPartSize(partSize int64) UploadOption {
+	return func(in *UploadInput) error {
+
+		if partSize < MinPartSize || partSize > MaxPartSize {
+			return intErr.NewValidationError(ErrInvalidParameter, PartSize)
+		}
+
+		in.partSize = partSize
+		in.partSizeSet = true
+
+		return nil
+
+	}
+}
+
+// WithConcurrency sets how many parts are uploaded at the same time, DefaultConcurrency
+// by default. When uploading from an io.Reader, up to concurrency+1 parts are held in memory
+func WithConcurrency(concurrency int) UploadOption {
+	return func(in *UploadInput) error {
+
+		if concurrency < 1 {
+			return intErr.NewValidationError(ErrInvalidParameter, Concurrency)
+		}
+
+		in.concurrency = concurrency
+
+		return nil
+
+	}
+}
+
+// WithPartRetries sets how many times a failed part is uploaded again before the whole
+// upload fails, DefaultPartRetries by default. Retries come on top of the ones made by
+// aws-sdk-go for every request
+func WithPartRetries(retries int) UploadOption {
+	return func(in *UploadInput) error {
+
+		if retries < 0 {
+			return intErr.NewValidationError(ErrInvalidParameter, PartRetries)
+		}
+
+		in.partRetries = retries
+
+		return nil
+
+	}
+}
+
+// WithStateStore makes S3UploadFile and S3UploadReaderAt save the state of their multipart
+// uploads to store after every part, and resume the upload saved for the same bucket and
+// object name, if any. Failed uploads are then kept, instead of being aborted, so that they
+// can be resumed by a later call, even from another process
+func WithStateStore(store StateStore) UploadOption {
+	return func(in *UploadInput) error {
+
+		if store == nil {
+			return intErr.NewValidationError(ErrEmptyParameter, Store)
+		}
+
+		in.stateStore = store
+
+		return nil
+
+	}
+}
+
+// S3UploadFile uploads the file at path to objectName in bucketName, using a multipart
+// upload when it is larger than the part size. The upload can be resumed with WithStateStore
+func (svc *S3) S3UploadFile(bucketName, objectName, path string, opts ...UploadOption) error {
+	return svc.S3UploadFileWithContext(context.Background(), bucketName, objectName, path, opts...)
+}
+
+// S3UploadFileWithContext is the same as S3UploadFile with the addition of a context.Context
+func (svc *S3) S3UploadFileWithContext(ctx context.Context, bucketName, objectName, path string, opts ...UploadOption) error {
+
+	if path == "" {
+		return intErr.NewValidationError(ErrEmptyParameter, Path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	return svc.uploadReaderAt(ctx, bucketName, objectName, file, fileInfo.Size(), path, fileInfo.ModTime(), opts...)
+
+}
+
+// S3UploadReaderAt uploads the size bytes of body to objectName in bucketName, using a multipart
+// upload when size is larger than the part size. The upload can be resumed with WithStateStore,
+// as long as body has the same content
+func (svc *S3) S3UploadReaderAt(bucketName, objectName string, body io.ReaderAt, size int64, opts ...UploadOption) error {
+	return svc.S3UploadReaderAtWithContext(context.Background(), bucketName, objectName, body, size, opts...)
+}
+
+// S3UploadReaderAtWithContext is the same as S3UploadReaderAt with the addition of a context.Context
+func (svc *S3) S3UploadReaderAtWithContext(ctx context.Context, bucketName, objectName string, body io.ReaderAt, size int64, opts ...UploadOption) error {
+	return svc.uploadReaderAt(ctx, bucketName, objectName, body, size, "", time.Time{}, opts...)
+}
+
+// partSizeOf returns the part size of an upload of size bytes: the one set with WithPartSize, or
+// the smallest multiple of a MiB not smaller than in.partSize splitting size in at most MaxParts parts
+func (in *UploadInput) partSizeOf(size int64) int64 {
+
+	if in.partSizeSet {
+		return in.partSize
+	}
+
+	const mib = 1024 * 1024
+
+	partSize := (size + MaxParts - 1) / MaxParts
+	partSize = (partSize + mib - 1) / mib * mib
+
+	if partSize < in.partSize {
+		return in.partSize
+	}
+
+	return partSize
+
+}
+
+// uploadReaderAt uploads size bytes of body, read from the file at path if any. modTime is saved
+// with the state of the upload, so that a modified source is never resumed
+func (svc *S3) uploadReaderAt(ctx context.Context, bucketName, objectName string, body io.ReaderAt, size int64, path string, modTime time.Time, opts ...UploadOption) error {
+
+	if body == nil {
+		return intErr.NewValidationError(ErrEmptyParameter, Body)
+	}
+	if size < 0 {
+		return intErr.NewValidationError(ErrInvalidParameter, Size)
+	}
+
+	in, err := newUploadInput(bucketName, objectName, opts...)
+	if err != nil {
+		return err
+	}
+
+	in.partSize = in.partSizeOf(size)
+
+	parts := (size + in.partSize - 1) / in.partSize
+	if parts > MaxParts || in.partSize > MaxPartSize {
+		return intErr.NewValidationError(ErrTooManyParts, PartSize)
+	}
+
+	if in.contentType == "" && size > 0 {
+		head := make([]byte, contenttype.SniffLen)
+		n, err := body.ReadAt(head, 0)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		in.contentType = contenttype.Resolve("", head[:n], path, objectName)
+	}
+
+	if size <= in.partSize {
+		return svc.putObject(ctx, in, bucketName, objectName, io.NewSectionReader(body, 0, size), size)
+	}
+
+	u := &multipartUpload{
+		svc: svc,
+		in:  in,
+		state: &UploadState{
+			Bucket:   bucketName,
+			Key:      objectName,
+			Size:     size,
+			PartSize: in.partSize,
+			ModTime:  modTime,
+		},
+		resumable: in.stateStore != nil,
+	}
+
+	resumed, err := u.resume(ctx)
+	if err != nil {
+		return err
+	}
+	if !resumed {
+		if err := u.create(ctx); err != nil {
+			return err
+		}
+	}
+
+	return u.upload(ctx, func(ctx context.Context, out chan<- *partBody) error {
+
+		uploaded := u.uploaded()
+
+		for number := int64(1); number <= parts; number++ {
+
+			if uploaded[number] {
+				continue
+			}
+
+			offset := (number - 1) * in.partSize
+			n := in.partSize
+			if offset+n > size {
+				n = size - offset
+			}
+
+			p := &partBody{
+				number: number,
+				body:   io.NewSectionReader(body, offset, n),
+				size:   n,
+			}
+
+			select {
+			case out <- p:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+		}
+
+		return nil
+
+	})
+
+}
+
+// streamParts returns a feeder sending first and then the rest of body as parts.
+// Buffers are recycled once their part is uploaded, so that at most concurrency+1
+// parts are held in memory
+func streamParts(in *UploadInput, first []byte, body io.Reader) feeder {
+	return func(ctx context.Context, out chan<- *partBody) error {
+
+		buffers := make(chan []byte, in.concurrency+1)
+		for i := 0; i < in.concurrency; i++ {
+			buffers <- nil
+		}
+
+		buf := first
+		n := len(first)
+
+		for number := int64(1); n > 0; number++ {
+
+			if number > MaxParts {
+				return intErr.NewValidationError(ErrTooManyParts, PartSize)
+			}
+
+			b := buf
+			p := &partBody{
+				number:  number,
+				body:    bytes.NewReader(b[:n]),
+				size:    int64(n),
+				release: func() { buffers <- b },
+			}
+
+			select {
+			case out <- p:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			select {
+			case buf = <-buffers:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			if buf == nil {
+				buf = make([]byte, in.partSize)
+			}
+
+			var err error
+
+			n, err = io.ReadFull(body, buf)
+			if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+				return err
+			}
+
+		}
+
+		return nil
+
+	}
+}
+
+// resume looks for a saved state matching u.state and returns true if its upload can be
+// resumed. Only the saved parts still listed by S3 with the same ETag are kept
+func (u *multipartUpload) resume(ctx context.Context) (bool, error) {
+
+	if !u.resumable {
+		return false, nil
+	}
+
+	saved, err := u.in.stateStore.Load(u.state.Bucket, u.state.Key)
+	if err != nil || saved == nil {
+		return false, err
+	}
+
+	if saved.UploadID == "" ||
+		saved.Size != u.state.Size ||
+		saved.PartSize != u.state.PartSize ||
+		!saved.ModTime.Equal(u.state.ModTime) {
+		if saved.UploadID != "" {
+			u.svc.abortMultipartUpload(saved.Bucket, saved.Key, saved.UploadID)
+		}
+		return false, u.in.stateStore.Delete(u.state.Bucket, u.state.Key)
+	}
+
+	listed := make(map[int64]string)
+
+	in := &s3.ListPartsInput{}
+	in = in.SetBucket(saved.Bucket)
+	in = in.SetKey(saved.Key)
+	in = in.SetUploadId(saved.UploadID)
+
+	err = u.svc.S3.ListPartsPagesWithContext(ctx, in, func(out *s3.ListPartsOutput, last bool) bool {
+		for _, p := range out.Parts {
+			listed[aws.Int64Value(p.PartNumber)] = aws.StringValue(p.ETag)
+		}
+		return true
+	})
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchUpload {
+		return false, u.in.stateStore.Delete(u.state.Bucket, u.state.Key)
+	}
+	if err != nil {
+		return false, intErr.Wrap(err)
+	}
+
+	u.state.UploadID = saved.UploadID
+
+	for _, p := range saved.Parts {
+		if listed[p.Number] == p.ETag {
+			u.state.Parts = append(u.state.Parts, p)
+		}
+	}
+
+	return true, nil
+
+}
+
+// create starts a new multipart upload and saves its state when the upload is resumable
+func (u *multipartUpload) create(ctx context.Context) error {
+
+	in := &s3.CreateMultipartUploadInput{}
+	in = in.SetBucket(u.state.Bucket)
+	in = in.SetKey(u.state.Key)
+
+	if u.in.contentType != "" {
+		in = in.SetContentType(u.in.contentType)
+	}
+	if u.in.cacheControl != "" {
+		in = in.SetCacheControl(u.in.cacheControl)
+	}
+	if u.in.contentDisposition != "" {
+		in = in.SetContentDisposition(u.in.contentDisposition)
+	}
+	if u.in.contentEncoding != "" {
+		in = in.SetContentEncoding(u.in.contentEncoding)
+	}
+	if u.in.contentLanguage != "" {
+		in = in.SetContentLanguage(u.in.contentLanguage)
+	}
+	if len(u.in.metadata) > 0 {
+		in = in.SetMetadata(aws.StringMap(u.in.metadata))
+	}
+	if len(u.in.tags) > 0 {
+		in = in.SetTagging(encodeTags(u.in.tags))
+	}
+
+	u.in.encryption.createMultipartUpload(in)
+
+	out, err := u.svc.S3.CreateMultipartUploadWithContext(ctx, in, u.in.encryption.requestOptions()...)
+	if err != nil {
+		return intErr.Wrap(err)
+	}
+
+	u.state.UploadID = *out.UploadId
+
+	if u.resumable {
+		return u.in.stateStore.Save(u.state)
+	}
+
+	return nil
+
+}
+
+// upload uploads the parts sent by feed with u.in.concurrency workers and completes the upload.
+// On failure, the upload is aborted unless it is resumable
+func (u *multipartUpload) upload(ctx context.Context, feed feeder) error {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	parts := make(chan *partBody)
+
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < u.in.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+
+			defer wg.Done()
+
+			for p := range parts {
+				if err := u.uploadPart(ctx, p); err != nil {
+					u.fail(err)
+					cancel()
+				}
+				if p.release != nil {
+					p.release()
+				}
+			}
+
+		}()
+	}
+
+	if err := feed(ctx, parts); err != nil {
+		u.fail(err)
+		cancel()
+	}
+
+	close(parts)
+	wg.Wait()
+
+	err := u.err
+	if err == nil {
+		err = u.complete(ctx)
+	}
+
+	if err != nil {
+		if !u.resumable {
+			u.svc.abortMultipartUpload(u.state.Bucket, u.state.Key, u.state.UploadID)
+		}
+		return err
+	}
+
+	if u.resumable {
+		return u.in.stateStore.Delete(u.state.Bucket, u.state.Key)
+	}
+
+	return nil
+
+}
+
+// uploadPart uploads p, retrying up to u.in.partRetries times
+func (u *multipartUpload) uploadPart(ctx context.Context, p *partBody) error {
+
+	var err error
+
+	for attempt := 0; attempt <= u.in.partRetries; attempt++ {
+
+		if attempt > 0 {
+			select {
+			case <-time.After(time.Duration(attempt) * partRetryDelay):
+			case <-ctx.Done():
+				return err
+			}
+			if p.body != nil {
+				if _, err := p.body.Seek(0, io.SeekStart); err != nil {
+					return err
+				}
+			}
+		}
+
+		var etag string
+
+		etag, err = u.sendPart(ctx, p)
+		if err == nil {
+			return u.uploadedPart(UploadedPart{Number: p.number, ETag: etag})
+		}
+
+		err = intErr.Wrap(err)
+
+		if ctx.Err() != nil {
+			return err
+		}
+
+	}
+
+	return err
+
+}
+
+// sendPart sends p with UploadPart, or with UploadPartCopy when it has no body, and returns its ETag
+func (u *multipartUpload) sendPart(ctx context.Context, p *partBody) (string, error) {
+
+	if p.body == nil {
+
+		in := &s3.UploadPartCopyInput{}
+		in = in.SetBucket(u.state.Bucket)
+		in = in.SetKey(u.state.Key)
+		in = in.SetUploadId(u.state.UploadID)
+		in = in.SetPartNumber(p.number)
+		in = in.SetCopySource(p.copySource)
+		in = in.SetCopySourceRange(p.copyRange)
+		in = in.SetCopySourceIfMatch(p.copyIfMatch)
+
+		u.in.encryption.uploadPartCopy(in)
+		u.in.copySourceEncryption.uploadPartCopySource(in)
+
+		out, err := u.svc.S3.UploadPartCopyWithContext(ctx, in)
+		if err != nil {
+			return "", err
+		}
+
+		return aws.StringValue(out.CopyPartResult.ETag), nil
+
+	}
+
+	in := &s3.UploadPartInput{}
+	in = in.SetBucket(u.state.Bucket)
+	in = in.SetKey(u.state.Key)
+	in = in.SetUploadId(u.state.UploadID)
+	in = in.SetPartNumber(p.number)
+	in = in.SetBody(p.body)
+	in = in.SetContentLength(p.size)
+
+	u.in.encryption.uploadPart(in)
+
+	out, err := u.svc.S3.UploadPartWithContext(ctx, in)
+	if err != nil {
+		return "", err
+	}
+
+	return aws.StringValue(out.ETag), nil
+
+}
+
+// uploadedPart records part and saves the state when the upload is resumable
+func (u *multipartUpload) uploadedPart(part UploadedPart) error {
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.state.Parts = append(u.state.Parts, part)
+
+	if u.resumable {
+		return u.in.stateStore.Save(u.state)
+	}
+
+	return nil
+
+}
+
+// uploaded returns the numbers of the parts already uploaded
+func (u *multipartUpload) uploaded() map[int64]bool {
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	out := make(map[int64]bool, len(u.state.Parts))
+
+	for _, p := range u.state.Parts {
+		out[p.Number] = true
+	}
+
+	return out
+
+}
+
+// fail records the first error of the upload
+func (u *multipartUpload) fail(err error) {
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.err == nil {
+		u.err = err
+	}
+
+}
+
+// complete completes the upload with all of its parts
+func (u *multipartUpload) complete(ctx context.Context) error {
+
+	sort.Slice(u.state.Parts, func(i, j int) bool {
+		return u.state.Parts[i].Number < u.state.Parts[j].Number
+	})
+
+	parts := make([]*s3.CompletedPart, 0, len(u.state.Parts))
+
+	for _, p := range u.state.Parts {
+		completed := &s3.CompletedPart{}
+		completed = completed.SetETag(p.ETag)
+		completed = completed.SetPartNumber(p.Number)
+		parts = append(parts, completed)
+	}
+
+	in := &s3.CompleteMultipartUploadInput{}
+	in = in.SetBucket(u.state.Bucket)
+	in = in.SetKey(u.state.Key)
+	in = in.SetUploadId(u.state.UploadID)
+	in = in.SetMultipartUpload(&s3.CompletedMultipartUpload{Parts: parts})
+
+	if _, err := u.svc.S3.CompleteMultipartUploadWithContext(ctx, in); err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
+
+// abortMultipartUpload aborts a failed multipart upload. It does not use the context of
+// the upload, which may be the reason of the failure. Its own error is ignored in favor
+// of the one that caused the abort
+func (svc *S3) abortMultipartUpload(bucketName, objectName, uploadID string) {
+
+	in := &s3.AbortMultipartUploadInput{}
+	in = in.SetBucket(bucketName)
+	in = in.SetKey(objectName)
+	in = in.SetUploadId(uploadID)
+
+	svc.S3.AbortMultipartUpload(in)
+
+}
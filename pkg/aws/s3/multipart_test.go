@@ -0,0 +1,338 @@
+package s3
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestS3_S3UploadFile(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	body := bytes.Repeat([]byte("some_body"), 2*MinPartSize/9+10)
+	path := writeTempFile(t, body)
+
+	err := s3Svc.S3UploadFile(cfg.S3.Bucket, "some/key", path, WithConcurrency(3))
+
+	assert.NoError(t, err)
+
+	stored, ok := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, body, stored)
+	assert.Empty(t, srv.Uploads(cfg.S3.Bucket))
+
+	out, err := s3Svc.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(cfg.S3.Bucket),
+		Key:    aws.String("some/key"),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(*out.ETag, `-3"`))
+	assert.Equal(t, "text/plain; charset=utf-8", *out.ContentType)
+
+	err = s3Svc.S3UploadFile(cfg.S3.Bucket, "some/small_key", writeTempFile(t, []byte("some_body")))
+
+	assert.NoError(t, err)
+
+	stored, ok = srv.Object(cfg.S3.Bucket, "some/small_key")
+
+	assert.True(t, ok)
+	assert.Equal(t, []byte("some_body"), stored)
+
+	path = filepath.Join(t.TempDir(), "some.json")
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("some_body"), 0600))
+
+	err = s3Svc.S3UploadFile(cfg.S3.Bucket, "some/json_key", path)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", *headObject(t, s3Svc, cfg.S3.Bucket, "some/json_key").ContentType)
+
+	err = s3Svc.S3UploadFile(cfg.S3.Bucket, "some/key", filepath.Join(t.TempDir(), "some_missing_file"))
+
+	assert.Error(t, err)
+
+}
+
+func TestS3_S3UploadReaderAt_PartRetries(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	attempts := failUploadParts(s3Svc, map[int64]int{2: 1})
+
+	body := bytes.Repeat([]byte("a"), 2*MinPartSize+1)
+
+	err := s3Svc.S3UploadReaderAt(cfg.S3.Bucket, "some/key", bytes.NewReader(body), int64(len(body)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts(2))
+
+	stored, ok := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, body, stored)
+
+	failUploadParts(s3Svc, map[int64]int{1: 2})
+
+	err = s3Svc.S3UploadReaderAt(cfg.S3.Bucket, "some/other_key", bytes.NewReader(body), int64(len(body)), WithPartRetries(1))
+
+	assert.Error(t, err)
+	assert.Empty(t, srv.Uploads(cfg.S3.Bucket))
+
+	_, ok = srv.Object(cfg.S3.Bucket, "some/other_key")
+
+	assert.False(t, ok)
+
+}
+
+func TestS3_S3UploadFile_Resume(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	store, err := NewFileStateStore(t.TempDir())
+
+	assert.NoError(t, err)
+
+	body := bytes.Repeat([]byte("a"), 2*MinPartSize+1)
+	path := writeTempFile(t, body)
+
+	attempts := failUploadParts(s3Svc, map[int64]int{2: 1})
+
+	err = s3Svc.S3UploadFile(
+		cfg.S3.Bucket,
+		"some/key",
+		path,
+		WithConcurrency(1),
+		WithPartRetries(0),
+		WithStateStore(store),
+	)
+
+	assert.Error(t, err)
+	assert.Len(t, srv.Uploads(cfg.S3.Bucket), 1)
+
+	state, err := store.Load(cfg.S3.Bucket, "some/key")
+
+	assert.NoError(t, err)
+	assert.Equal(t, srv.Uploads(cfg.S3.Bucket)[0], state.UploadID)
+	assert.Equal(t, []int64{1}, uploadedNumbers(state))
+	assert.Equal(t, 1, attempts(1))
+
+	attempts = failUploadParts(s3Svc, nil)
+
+	err = s3Svc.S3UploadFile(cfg.S3.Bucket, "some/key", path, WithStateStore(store))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, attempts(1))
+	assert.Equal(t, 1, attempts(2))
+	assert.Equal(t, 1, attempts(3))
+	assert.Empty(t, srv.Uploads(cfg.S3.Bucket))
+
+	stored, ok := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, body, stored)
+
+	state, err = store.Load(cfg.S3.Bucket, "some/key")
+
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+}
+
+func TestS3_S3UploadReaderAt_ResumeChangedSource(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	store, err := NewFileStateStore(t.TempDir())
+
+	assert.NoError(t, err)
+
+	body := bytes.Repeat([]byte("a"), MinPartSize+1)
+
+	failUploadParts(s3Svc, map[int64]int{2: 1})
+
+	err = s3Svc.S3UploadReaderAt(cfg.S3.Bucket, "some/key", bytes.NewReader(body), int64(len(body)), WithPartRetries(0), WithStateStore(store))
+
+	assert.Error(t, err)
+	assert.Len(t, srv.Uploads(cfg.S3.Bucket), 1)
+
+	failUploadParts(s3Svc, nil)
+
+	body = append(body, 'b')
+
+	err = s3Svc.S3UploadReaderAt(cfg.S3.Bucket, "some/key", bytes.NewReader(body), int64(len(body)), WithStateStore(store))
+
+	assert.NoError(t, err)
+	assert.Empty(t, srv.Uploads(cfg.S3.Bucket))
+
+	stored, ok := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, body, stored)
+
+}
+
+func TestUploadInput_partSizeOf(t *testing.T) {
+
+	in, err := applyUploadOptions()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(MinPartSize), in.partSizeOf(0))
+	assert.Equal(t, int64(MinPartSize), in.partSizeOf(MinPartSize*MaxParts))
+	assert.Equal(t, int64(MinPartSize+1024*1024), in.partSizeOf(MinPartSize*MaxParts+1))
+
+	// 100 GiB fit in MaxParts parts of 11 MiB, 10.24 MiB rounded up
+	assert.Equal(t, int64(11*1024*1024), in.partSizeOf(100*1024*1024*1024))
+
+	in, err = applyUploadOptions(WithPartSize(MinPartSize))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(MinPartSize), in.partSizeOf(100*1024*1024*1024))
+
+}
+
+func TestS3_S3UploadReaderAt_Validation(t *testing.T) {
+
+	s3Svc := &S3{}
+
+	err := s3Svc.S3UploadReaderAt("some_bucket", "some/key", nil, 0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), Body)
+
+	err = s3Svc.S3UploadReaderAt("some_bucket", "some/key", strings.NewReader("some_body"), -1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), Size)
+
+	err = s3Svc.S3UploadReaderAt("some_bucket", "some/key", strings.NewReader("some_body"), MinPartSize*MaxParts+1, WithPartSize(MinPartSize))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrTooManyParts)
+
+	err = s3Svc.S3UploadReaderAt("some_bucket", "some/key", strings.NewReader("some_body"), MaxPartSize*MaxParts+1)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrTooManyParts)
+
+	err = s3Svc.S3UploadFile("some_bucket", "some/key", "")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), Path)
+
+	for param, opt := range map[string]UploadOption{
+		PartSize:    WithPartSize(MinPartSize - 1),
+		Concurrency: WithConcurrency(0),
+		PartRetries: WithPartRetries(-1),
+		Store:       WithStateStore(nil),
+	} {
+
+		err = s3Svc.S3UploadReaderAt("some_bucket", "some/key", strings.NewReader("some_body"), 9, opt)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), param)
+
+	}
+
+}
+
+// failUploadParts makes the UploadPart requests of svc fail as many times as set in failures
+// for their part number. It returns a function counting the attempts made for a part number
+func failUploadParts(svc *S3, failures map[int64]int) func(number int64) int {
+
+	mu := &sync.Mutex{}
+	attempts := make(map[int64]int)
+
+	svc.Handlers.Send.Remove(request.NamedHandler{Name: "test.FailUploadParts"})
+	svc.Handlers.Send.PushBackNamed(request.NamedHandler{
+		Name: "test.FailUploadParts",
+		Fn: func(r *request.Request) {
+
+			in, ok := r.Params.(*s3.UploadPartInput)
+			if !ok {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			number := aws.Int64Value(in.PartNumber)
+			attempts[number]++
+
+			if attempts[number] <= failures[number] {
+				r.Error = awserr.New("SomeError", "some_error", nil)
+				r.Retryable = aws.Bool(false)
+			}
+
+		},
+	})
+
+	return func(number int64) int {
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		return attempts[number]
+
+	}
+
+}
+
+// uploadedNumbers returns the part numbers of state
+func uploadedNumbers(state *UploadState) []int64 {
+
+	var out []int64
+
+	for _, p := range state.Parts {
+		out = append(out, p.Number)
+	}
+
+	return out
+
+}
+
+// writeTempFile writes body to a new temporary file and returns its path
+func writeTempFile(t *testing.T, body []byte) string {
+
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "some_file")
+
+	assert.NoError(t, ioutil.WriteFile(path, body, 0600))
+
+	return path
+
+}
@@ -0,0 +1,113 @@
+package s3
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+// ObjectInfo contains the metadata of an object returned by S3Stat
+type ObjectInfo struct {
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+	// Metadata is the user metadata of the object, without its x-amz-meta- prefix
+	Metadata map[string]string
+}
+
+// S3Exists reports whether objectName exists in bucketName, without downloading it.
+// A missing bucket is reported like a missing object. Objects encrypted with SSE-C need
+// the WithSSEC key they were written with
+func (svc *S3) S3Exists(bucketName, objectName string, opts ...EncryptionOption) (bool, error) {
+	return svc.S3ExistsWithContext(context.Background(), bucketName, objectName, opts...)
+}
+
+// S3ExistsWithContext is the same as S3Exists with the addition of a context.Context
+func (svc *S3) S3ExistsWithContext(ctx context.Context, bucketName, objectName string, opts ...EncryptionOption) (bool, error) {
+
+	_, err := svc.headObject(ctx, bucketName, objectName, opts...)
+	if reqErr, ok := err.(awserr.RequestFailure); ok && reqErr.StatusCode() == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+
+}
+
+// S3Stat returns the size, content type, ETag, last modification time and user metadata
+// of objectName in bucketName, without downloading it. Objects encrypted with SSE-C need
+// the WithSSEC key they were written with
+func (svc *S3) S3Stat(bucketName, objectName string, opts ...EncryptionOption) (*ObjectInfo, error) {
+	return svc.S3StatWithContext(context.Background(), bucketName, objectName, opts...)
+}
+
+// S3StatWithContext is the same as S3Stat with the addition of a context.Context
+func (svc *S3) S3StatWithContext(ctx context.Context, bucketName, objectName string, opts ...EncryptionOption) (*ObjectInfo, error) {
+
+	out, err := svc.headObject(ctx, bucketName, objectName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &ObjectInfo{
+		Size:         aws.Int64Value(out.ContentLength),
+		ContentType:  aws.StringValue(out.ContentType),
+		ETag:         aws.StringValue(out.ETag),
+		LastModified: aws.TimeValue(out.LastModified),
+		Metadata:     aws.StringValueMap(out.Metadata),
+	}
+
+	return info, nil
+
+}
+
+// headObject validates the parameters of S3Exists and S3Stat and sends their HeadObject
+func (svc *S3) headObject(ctx context.Context, bucketName, objectName string, opts ...EncryptionOption) (*s3.HeadObjectOutput, error) {
+
+	if err := validateObject(bucketName, objectName); err != nil {
+		return nil, err
+	}
+
+	enc, err := newReadEncryption(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &s3.HeadObjectInput{}
+	in = in.SetBucket(bucketName)
+	in = in.SetKey(objectName)
+
+	enc.headObject(in)
+
+	out, err := svc.S3.HeadObjectWithContext(ctx, in)
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	return out, nil
+
+}
+
+// validateObject validates the bucket and object names of the helpers acting on a single object
+func validateObject(bucketName, objectName string) error {
+
+	if bucketName == "" {
+		return intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+	if objectName == "" {
+		return intErr.NewValidationError(ErrEmptyParameter, ObjectName)
+	}
+
+	return nil
+
+}
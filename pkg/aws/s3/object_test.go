@@ -0,0 +1,102 @@
+package s3
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestS3_S3Exists(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+	putTaggedObject(t, s3Svc, cfg.S3.Bucket, "some/key", []byte("some_body"))
+
+	exists, err := s3Svc.S3Exists(cfg.S3.Bucket, "some/key")
+
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = s3Svc.S3Exists(cfg.S3.Bucket, "some_missing_key")
+
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = s3Svc.S3Exists("some_missing_bucket", "some/key")
+
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	for param, f := range map[string]func() error{
+		BucketName: func() error {
+			_, err := s3Svc.S3Exists("", "some/key")
+			return err
+		},
+		ObjectName: func() error {
+			_, err := s3Svc.S3Stat(cfg.S3.Bucket, "")
+			return err
+		},
+		ServerSideEncryption: func() error {
+			_, err := s3Svc.S3Exists(cfg.S3.Bucket, "some/key", WithSSEKMS(""))
+			return err
+		},
+	} {
+
+		err := f()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), param)
+
+	}
+
+}
+
+func TestS3_S3Stat(t *testing.T) {
+
+	srv := fake.NewTLS()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+	putTaggedObject(t, s3Svc, cfg.S3.Bucket, "some/key", []byte("some_body"))
+
+	info, err := s3Svc.S3Stat(cfg.S3.Bucket, "some/key")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), info.Size)
+	assert.Equal(t, "text/plain", info.ContentType)
+	sum := md5.Sum([]byte("some_body"))
+
+	assert.Equal(t, `"`+hex.EncodeToString(sum[:])+`"`, info.ETag)
+	assert.WithinDuration(t, time.Now(), info.LastModified, time.Minute)
+	assert.Equal(t, map[string]string{"Some-Meta": "some_value"}, info.Metadata)
+
+	_, err = s3Svc.S3Stat(cfg.S3.Bucket, "some_missing_key")
+
+	assert.Error(t, err)
+
+	key := bytes.Repeat([]byte("k"), CustomerKeySize)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "sse-c/key", bytes.NewReader([]byte("some_body")), WithEncryption(WithSSEC(key))))
+
+	_, err = s3Svc.S3Exists(cfg.S3.Bucket, "sse-c/key")
+
+	assert.Error(t, err)
+
+	exists, err := s3Svc.S3Exists(cfg.S3.Bucket, "sse-c/key", WithSSEC(key))
+
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+}
@@ -2,6 +2,8 @@ package s3
 
 const (
 
+	// Input represents the parameter named input
+	Input = "input"
 	// InputContentLength represents the parameter named inputContentLength
 	InputContentLength = "inputContentLength"
 	// Body represents the parameter named body
@@ -18,4 +20,86 @@ const (
 	BucketName = "bucketName"
 	// Source represents the parameter named source
 	Source = "source"
+	// ObjectName represents the parameter named objectName
+	ObjectName = "objectName"
+	// CacheControl represents the parameter named cacheControl
+	CacheControl = "cacheControl"
+	// ContentDisposition represents the parameter named contentDisposition
+	ContentDisposition = "contentDisposition"
+	// Metadata represents the parameter named metadata
+	Metadata = "metadata"
+	// PartSize represents the parameter named partSize
+	PartSize = "partSize"
+	// Concurrency represents the parameter named concurrency
+	Concurrency = "concurrency"
+	// PartRetries represents the parameter named partRetries
+	PartRetries = "partRetries"
+	// Store represents the parameter named store
+	Store = "store"
+	// Dir represents the parameter named dir
+	Dir = "dir"
+	// Size represents the parameter named size
+	Size = "size"
+	// Offset represents the parameter named offset
+	Offset = "offset"
+	// Length represents the parameter named length
+	Length = "length"
+	// Writer represents the parameter named writer
+	Writer = "writer"
+	// Delimiter represents the parameter named delimiter
+	Delimiter = "delimiter"
+	// StartAfter represents the parameter named startAfter
+	StartAfter = "startAfter"
+	// MaxEntries represents the parameter named maxEntries
+	MaxEntries = "maxEntries"
+	// PageSize represents the parameter named pageSize
+	PageSize = "pageSize"
+	// Prefix represents the parameter named prefix
+	Prefix = "prefix"
+	// VersionID represents the parameter named versionID
+	VersionID = "versionID"
+	// Tags represents the parameter named tags
+	Tags = "tags"
+	// Expiry represents the parameter named expiry
+	Expiry = "expiry"
+	// ContentLength represents the parameter named contentLength
+	ContentLength = "contentLength"
+	// ETag represents the parameter named eTag
+	ETag = "eTag"
+	// ContentLengthRange represents the parameter named contentLengthRange
+	ContentLengthRange = "contentLengthRange"
+	// ResponseContentType represents the parameter named responseContentType
+	ResponseContentType = "responseContentType"
+	// ResponseContentDisposition represents the parameter named responseContentDisposition
+	ResponseContentDisposition = "responseContentDisposition"
+	// ResponseCacheControl represents the parameter named responseCacheControl
+	ResponseCacheControl = "responseCacheControl"
+	// ServerSideEncryption represents the parameter named serverSideEncryption
+	ServerSideEncryption = "serverSideEncryption"
+	// EncryptionContext represents the parameter named encryptionContext
+	EncryptionContext = "encryptionContext"
+	// CustomerKey represents the parameter named customerKey
+	CustomerKey = "customerKey"
+	// Service represents the parameter named service
+	Service = "service"
+	// Provider represents the parameter named provider
+	Provider = "provider"
+	// MasterKey represents the parameter named masterKey
+	MasterKey = "masterKey"
+	// KeyID represents the parameter named keyID
+	KeyID = "keyID"
+	// Compare represents the parameter named compare
+	Compare = "compare"
+	// Pattern represents the parameter named pattern
+	Pattern = "pattern"
+	// LocationConstraint represents the parameter named locationConstraint
+	LocationConstraint = "locationConstraint"
+	// Versioning represents the parameter named versioning
+	Versioning = "versioning"
+	// LifecycleRules represents the parameter named lifecycleRules
+	LifecycleRules = "lifecycleRules"
+	// CORSRules represents the parameter named corsRules
+	CORSRules = "corsRules"
+	// Policy represents the parameter named policy
+	Policy = "policy"
 )
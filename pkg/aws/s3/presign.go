@@ -0,0 +1,397 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+const (
+	// MaxPresignExpiry is the longest validity of a presigned URL or POST policy
+	MaxPresignExpiry = 7 * 24 * time.Hour
+
+	// postFileName is the placeholder S3 replaces with the name of the uploaded file in the key of a POST upload
+	postFileName = "${filename}"
+
+	// signingAlgorithm is the algorithm of the POST policy signature
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+)
+
+// PresignInput contains the optional parameters of S3PresignGet, S3PresignPut and S3PresignPost
+type PresignInput struct {
+	contentType                string
+	contentLength              int64
+	minContentLength           int64
+	maxContentLength           int64
+	responseContentType        string
+	responseContentDisposition string
+	responseCacheControl       string
+}
+
+// PresignOption sets an optional parameter on a *PresignInput
+type PresignOption func(*PresignInput) error
+
+// PresignedRequest is a presigned request, to be sent to URL with Method and every header of Header
+type PresignedRequest struct {
+	URL    string
+	Method string
+	// Header holds the signed headers the request has to be sent with
+	Header http.Header
+}
+
+// PresignedPost is a presigned POST policy for browser form uploads. The form is posted
+// to URL as multipart/form-data, with Fields followed by the content in a field named "file"
+type PresignedPost struct {
+	URL    string
+	Fields map[string]string
+}
+
+// WithRequiredContentType requires uploads to be sent with contentType. Applies to S3PresignPut and S3PresignPost
+func WithRequiredContentType(contentType string) PresignOption {
+	return func(in *PresignInput) error {
+
+		if contentType == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, ContentType)
+		}
+
+		in.contentType = contentType
+
+		return nil
+
+	}
+}
+
+// WithRequiredContentLength requires the uploaded content to be exactly size bytes long, up to the
+// MaxPartSize a single PutObject accepts. Applies to S3PresignPut
+func WithRequiredContentLength(size int64) PresignOption {
+	return func(in *PresignInput) error {
+
+		if size < 0 || size > MaxPartSize {
+			return intErr.NewValidationError(ErrInvalidParameter, ContentLength)
+		}
+
+		in.contentLength = size
+
+		return nil
+
+	}
+}
+
+// WithContentLengthRange requires the uploaded content to be between min and max bytes long. Applies to S3PresignPost
+func WithContentLengthRange(min, max int64) PresignOption {
+	return func(in *PresignInput) error {
+
+		if min < 0 || max < 1 || min > max {
+			return intErr.NewValidationError(ErrInvalidParameter, ContentLengthRange)
+		}
+
+		in.minContentLength = min
+		in.maxContentLength = max
+
+		return nil
+
+	}
+}
+
+// WithResponseContentType overrides the Content-Type header of the response. Applies to S3PresignGet
+func WithResponseContentType(contentType string) PresignOption {
+	return func(in *PresignInput) error {
+
+		if contentType == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, ResponseContentType)
+		}
+
+		in.responseContentType = contentType
+
+		return nil
+
+	}
+}
+
+// WithResponseContentDisposition overrides the Content-Disposition header of the response,
+// e.g. `attachment; filename="some_file.txt"` to have browsers download the object. Applies to S3PresignGet
+func WithResponseContentDisposition(contentDisposition string) PresignOption {
+	return func(in *PresignInput) error {
+
+		if contentDisposition == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, ResponseContentDisposition)
+		}
+
+		in.responseContentDisposition = contentDisposition
+
+		return nil
+
+	}
+}
+
+// WithResponseCacheControl overrides the Cache-Control header of the response. Applies to S3PresignGet
+func WithResponseCacheControl(cacheControl string) PresignOption {
+	return func(in *PresignInput) error {
+
+		if cacheControl == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, ResponseCacheControl)
+		}
+
+		in.responseCacheControl = cacheControl
+
+		return nil
+
+	}
+}
+
+// S3PresignGet returns a URL to download objectName from bucketName, valid for expiry.
+// Presigning sends no request, so there is no WithContext variant
+func (svc *S3) S3PresignGet(bucketName, objectName string, expiry time.Duration, opts ...PresignOption) (string, error) {
+
+	in, err := newPresignInput(bucketName, objectName, expiry, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	if in.contentType != "" {
+		return "", intErr.NewValidationError(ErrInvalidParameter, ContentType)
+	}
+	if in.contentLength >= 0 {
+		return "", intErr.NewValidationError(ErrInvalidParameter, ContentLength)
+	}
+	if in.maxContentLength > 0 {
+		return "", intErr.NewValidationError(ErrInvalidParameter, ContentLengthRange)
+	}
+
+	get := &s3.GetObjectInput{}
+	get = get.SetBucket(bucketName)
+	get = get.SetKey(objectName)
+
+	if in.responseContentType != "" {
+		get = get.SetResponseContentType(in.responseContentType)
+	}
+	if in.responseContentDisposition != "" {
+		get = get.SetResponseContentDisposition(in.responseContentDisposition)
+	}
+	if in.responseCacheControl != "" {
+		get = get.SetResponseCacheControl(in.responseCacheControl)
+	}
+
+	req, _ := svc.S3.GetObjectRequest(get)
+
+	url, err := req.Presign(expiry)
+	if err != nil {
+		return "", intErr.Wrap(err)
+	}
+
+	return url, nil
+
+}
+
+// S3PresignPut returns a request to upload objectName to bucketName, valid for expiry.
+// The content type and length required with WithRequiredContentType and WithRequiredContentLength
+// are signed, so the request has to be sent with the returned headers.
+// Presigning sends no request, so there is no WithContext variant
+func (svc *S3) S3PresignPut(bucketName, objectName string, expiry time.Duration, opts ...PresignOption) (*PresignedRequest, error) {
+
+	in, err := newPresignInput(bucketName, objectName, expiry, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.maxContentLength > 0 {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, ContentLengthRange)
+	}
+	if err := in.rejectResponseHeaders(); err != nil {
+		return nil, err
+	}
+
+	put := &s3.PutObjectInput{}
+	put = put.SetBucket(bucketName)
+	put = put.SetKey(objectName)
+
+	if in.contentType != "" {
+		put = put.SetContentType(in.contentType)
+	}
+	if in.contentLength >= 0 {
+		put = put.SetContentLength(in.contentLength)
+	}
+
+	req, _ := svc.S3.PutObjectRequest(put)
+
+	url, signed, err := req.PresignRequest(expiry)
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	header := make(http.Header, len(signed))
+	for k, v := range signed {
+		if !strings.EqualFold(k, "Host") {
+			header[http.CanonicalHeaderKey(k)] = v
+		}
+	}
+
+	return &PresignedRequest{
+		URL:    url,
+		Method: http.MethodPut,
+		Header: header,
+	}, nil
+
+}
+
+// S3PresignPost returns a POST policy to upload objectName to bucketName from a browser form,
+// valid for expiry. When objectName ends with "${filename}", S3 replaces it with the name of the
+// uploaded file, and any key starting with the rest of objectName is accepted.
+// Presigning sends no request, so there is no WithContext variant
+func (svc *S3) S3PresignPost(bucketName, objectName string, expiry time.Duration, opts ...PresignOption) (*PresignedPost, error) {
+
+	in, err := newPresignInput(bucketName, objectName, expiry, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.contentLength >= 0 {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, ContentLength)
+	}
+	if err := in.rejectResponseHeaders(); err != nil {
+		return nil, err
+	}
+
+	creds, err := svc.Config.Credentials.Get()
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	head := &s3.HeadBucketInput{}
+	head = head.SetBucket(bucketName)
+
+	req, _ := svc.S3.HeadBucketRequest(head)
+	if err := req.Build(); err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	region := svc.SigningRegion
+	if region == "" {
+		region = aws.StringValue(svc.Config.Region)
+	}
+
+	now := time.Now().UTC()
+	date := now.Format("20060102")
+
+	fields := map[string]string{
+		"key":              objectName,
+		"x-amz-algorithm":  signingAlgorithm,
+		"x-amz-credential": fmt.Sprintf("%s/%s/%s/%s/aws4_request", creds.AccessKeyID, date, region, s3.ServiceName),
+		"x-amz-date":       now.Format("20060102T150405Z"),
+	}
+
+	if creds.SessionToken != "" {
+		fields["x-amz-security-token"] = creds.SessionToken
+	}
+	if in.contentType != "" {
+		fields["Content-Type"] = in.contentType
+	}
+
+	conditions := []interface{}{
+		map[string]string{"bucket": bucketName},
+	}
+
+	if strings.HasSuffix(objectName, postFileName) {
+		conditions = append(conditions, []string{"starts-with", "$key", strings.TrimSuffix(objectName, postFileName)})
+	} else {
+		conditions = append(conditions, map[string]string{"key": objectName})
+	}
+
+	for _, k := range []string{"Content-Type", "x-amz-algorithm", "x-amz-credential", "x-amz-date", "x-amz-security-token"} {
+		if v, ok := fields[k]; ok {
+			conditions = append(conditions, map[string]string{k: v})
+		}
+	}
+
+	if in.maxContentLength > 0 {
+		conditions = append(conditions, []interface{}{"content-length-range", in.minContentLength, in.maxContentLength})
+	}
+
+	policy, err := json.Marshal(map[string]interface{}{
+		"expiration": now.Add(expiry).Format("2006-01-02T15:04:05.000Z"),
+		"conditions": conditions,
+	})
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	fields["policy"] = base64.StdEncoding.EncodeToString(policy)
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, s3.ServiceName)
+	key = hmacSHA256(key, "aws4_request")
+
+	fields["x-amz-signature"] = hex.EncodeToString(hmacSHA256(key, fields["policy"]))
+
+	return &PresignedPost{
+		URL:    req.HTTPRequest.URL.String(),
+		Fields: fields,
+	}, nil
+
+}
+
+// newPresignInput validates the parameters shared by every presigned request and returns a new *PresignInput
+func newPresignInput(bucketName, objectName string, expiry time.Duration, opts ...PresignOption) (*PresignInput, error) {
+
+	if bucketName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+	if objectName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, ObjectName)
+	}
+	if expiry < time.Second || expiry > MaxPresignExpiry {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, Expiry)
+	}
+
+	in := &PresignInput{
+		contentLength: -1,
+	}
+
+	for _, opt := range opts {
+		if err := opt(in); err != nil {
+			return nil, err
+		}
+	}
+
+	return in, nil
+
+}
+
+// rejectResponseHeaders returns an error if a response header, only allowed on downloads, has been set
+func (in *PresignInput) rejectResponseHeaders() error {
+
+	if in.responseContentType != "" {
+		return intErr.NewValidationError(ErrInvalidParameter, ResponseContentType)
+	}
+	if in.responseContentDisposition != "" {
+		return intErr.NewValidationError(ErrInvalidParameter, ResponseContentDisposition)
+	}
+	if in.responseCacheControl != "" {
+		return intErr.NewValidationError(ErrInvalidParameter, ResponseCacheControl)
+	}
+
+	return nil
+
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data with key
+func hmacSHA256(key []byte, data string) []byte {
+
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+
+	return h.Sum(nil)
+
+}
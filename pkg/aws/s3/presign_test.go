@@ -0,0 +1,251 @@
+package s3
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestS3_S3PresignGet(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+	putTaggedObject(t, s3Svc, cfg.S3.Bucket, "some key", []byte("some_body"))
+
+	url, err := s3Svc.S3PresignGet(cfg.S3.Bucket, "some key", time.Hour,
+		WithResponseContentDisposition(`attachment; filename="some_file.txt"`),
+		WithResponseCacheControl("no-cache"),
+	)
+
+	assert.NoError(t, err)
+	assert.Contains(t, url, "X-Amz-Expires=3600")
+	assert.Contains(t, url, "response-content-disposition=")
+
+	resp, err := http.Get(url)
+
+	assert.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "some_body", string(body))
+	assert.Equal(t, `attachment; filename="some_file.txt"`, resp.Header.Get("Content-Disposition"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+	assert.Equal(t, "text/plain", resp.Header.Get("Content-Type"))
+
+}
+
+func TestS3_S3PresignPut(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	presigned, err := s3Svc.S3PresignPut(cfg.S3.Bucket, "some/key", time.Minute,
+		WithRequiredContentType("image/png"),
+		WithRequiredContentLength(9),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, presigned.Method)
+	assert.Equal(t, "image/png", presigned.Header.Get("Content-Type"))
+	assert.Equal(t, "9", presigned.Header.Get("Content-Length"))
+	assert.Contains(t, presigned.URL, "X-Amz-SignedHeaders=content-length%3Bcontent-type%3Bhost")
+
+	req, err := http.NewRequest(presigned.Method, presigned.URL, strings.NewReader("some_body"))
+
+	assert.NoError(t, err)
+
+	req.Header = presigned.Header
+
+	resp, err := http.DefaultClient.Do(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp.Body.Close()
+
+	body, ok := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, "some_body", string(body))
+	assert.Equal(t, "image/png", *headObject(t, s3Svc, cfg.S3.Bucket, "some/key").ContentType)
+
+}
+
+func TestS3_S3PresignPost(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	post, err := s3Svc.S3PresignPost(cfg.S3.Bucket, "uploads/${filename}", time.Minute,
+		WithRequiredContentType("text/plain"),
+		WithContentLengthRange(1, 10),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, srv.URL+"/"+cfg.S3.Bucket, post.URL)
+	assert.Equal(t, signingAlgorithm, post.Fields["x-amz-algorithm"])
+	assert.True(t, strings.HasPrefix(post.Fields["x-amz-credential"], fake.AccessKeyID+"/"))
+	assert.Len(t, post.Fields["x-amz-signature"], 64)
+
+	raw, err := base64.StdEncoding.DecodeString(post.Fields["policy"])
+
+	assert.NoError(t, err)
+
+	policy := struct {
+		Expiration string
+		Conditions []interface{}
+	}{}
+
+	assert.NoError(t, json.Unmarshal(raw, &policy))
+	assert.Contains(t, policy.Conditions, []interface{}{"starts-with", "$key", "uploads/"})
+	assert.Contains(t, policy.Conditions, []interface{}{"content-length-range", float64(1), float64(10)})
+
+	expiration, err := time.Parse(time.RFC3339, policy.Expiration)
+
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expiration, 5*time.Second)
+
+	resp := postForm(t, post, nil, "some_file.txt", "some_body")
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	body, ok := srv.Object(cfg.S3.Bucket, "uploads/some_file.txt")
+
+	assert.True(t, ok)
+	assert.Equal(t, "some_body", string(body))
+	assert.Equal(t, "text/plain", *headObject(t, s3Svc, cfg.S3.Bucket, "uploads/some_file.txt").ContentType)
+
+	// the policy conditions are enforced
+	resp = postForm(t, post, nil, "some_file.txt", "some_too_long_body")
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = postForm(t, post, map[string]string{"Content-Type": "text/html"}, "some_file.txt", "some_body")
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	resp = postForm(t, post, map[string]string{"key": "other/some_file.txt"}, "some_file.txt", "some_body")
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+}
+
+func TestS3_S3Presign_Validation(t *testing.T) {
+
+	s3Svc := &S3{}
+
+	_, err := s3Svc.S3PresignGet("", "some/key", time.Minute)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), BucketName)
+
+	_, err = s3Svc.S3PresignPut("some_bucket", "", time.Minute)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ObjectName)
+
+	for _, expiry := range []time.Duration{0, MaxPresignExpiry + time.Second} {
+
+		_, err = s3Svc.S3PresignPost("some_bucket", "some/key", expiry)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), Expiry)
+
+	}
+
+	for param, opt := range map[string]PresignOption{
+		ContentType:                WithRequiredContentType(""),
+		ContentLength:              WithRequiredContentLength(-1),
+		ContentLengthRange:         WithContentLengthRange(10, 1),
+		ResponseContentType:        WithResponseContentType(""),
+		ResponseContentDisposition: WithResponseContentDisposition(""),
+		ResponseCacheControl:       WithResponseCacheControl(""),
+	} {
+
+		_, err = s3Svc.S3PresignGet("some_bucket", "some/key", time.Minute, opt)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), param)
+
+	}
+
+	// options are rejected by the functions they don't apply to
+	_, err = s3Svc.S3PresignGet("some_bucket", "some/key", time.Minute, WithRequiredContentType("text/plain"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidParameter)
+
+	_, err = s3Svc.S3PresignPut("some_bucket", "some/key", time.Minute, WithContentLengthRange(1, 10))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ContentLengthRange)
+
+	_, err = s3Svc.S3PresignPost("some_bucket", "some/key", time.Minute, WithRequiredContentLength(10))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ContentLength)
+
+	_, err = s3Svc.S3PresignPost("some_bucket", "some/key", time.Minute, WithResponseCacheControl("no-cache"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ResponseCacheControl)
+
+}
+
+// postForm posts body as fileName to post like a browser form would, with the fields of post
+// overridden by fields
+func postForm(t *testing.T, post *PresignedPost, fields map[string]string, fileName, body string) *http.Response {
+
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+
+	for k, v := range post.Fields {
+		if override, ok := fields[k]; ok {
+			v = override
+		}
+		assert.NoError(t, w.WriteField(k, v))
+	}
+
+	file, err := w.CreateFormFile("file", fileName)
+
+	assert.NoError(t, err)
+
+	file.Write([]byte(body))
+
+	assert.NoError(t, w.Close())
+
+	resp, err := http.Post(post.URL, w.FormDataContentType(), buf)
+
+	assert.NoError(t, err)
+
+	resp.Body.Close()
+
+	return resp
+
+}
@@ -1,50 +1,171 @@
 package s3
 
-// S3CreateBucket creates a new bucket given a bucketName
-func (svc *S3) S3CreateBucket(bucketName string) error {
+import (
+	"bytes"
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+// CreateBucketInput contains the optional parameters of S3CreateBucket
+type CreateBucketInput struct {
+	location string
+}
+
+// CreateBucketOption sets an optional parameter on a *CreateBucketInput
+type CreateBucketOption func(*CreateBucketInput) error
+
+// WithLocationConstraint creates the bucket in region rather than in the region of the client
+func WithLocationConstraint(region string) CreateBucketOption {
+	return func(in *CreateBucketInput) error {
+
+		if region == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, LocationConstraint)
+		}
+
+		in.location = region
+
+		return nil
+
+	}
+}
+
+// S3CreateBucket creates a new bucket given a bucketName, in the region of the client unless
+// set with WithLocationConstraint, and waits until it exists. Creating a bucket already owned
+// by the caller succeeds
+func (svc *S3) S3CreateBucket(bucketName string, opts ...CreateBucketOption) error {
+	return svc.S3CreateBucketWithContext(context.Background(), bucketName, opts...)
+}
+
+// S3CreateBucketWithContext is the same as S3CreateBucket with the addition of a context.Context
+func (svc *S3) S3CreateBucketWithContext(ctx context.Context, bucketName string, opts ...CreateBucketOption) error {
 
 	in, err := NewCreateBucketInput(bucketName)
 	if err != nil {
 		return err
 	}
 
-	_, err = svc.S3.CreateBucket(in)
-	if err != nil {
-		return err
+	create := &CreateBucketInput{
+		location: aws.StringValue(svc.S3.Config.Region),
+	}
+
+	for _, opt := range opts {
+		if err := opt(create); err != nil {
+			return err
+		}
+	}
+
+	// us-east-1 is the region of buckets created without location constraint, and refuses it.
+	// The SDK sets the region of the client as location constraint when no configuration is given
+	if create.location == endpoints.UsEast1RegionID {
+		in = in.SetCreateBucketConfiguration(&s3.CreateBucketConfiguration{})
+	} else if create.location != "" {
+		in = in.SetCreateBucketConfiguration((&s3.CreateBucketConfiguration{}).SetLocationConstraint(create.location))
+	}
+
+	_, err = svc.S3.CreateBucketWithContext(ctx, in)
+	if err != nil && !isErrCode(err, s3.ErrCodeBucketAlreadyOwnedByYou) {
+		return intErr.Wrap(err)
+	}
+
+	head := &s3.HeadBucketInput{}
+	head = head.SetBucket(bucketName)
+
+	if err := svc.S3.WaitUntilBucketExistsWithContext(ctx, head); err != nil {
+		return intErr.Wrap(err)
 	}
 
 	return nil
+
+}
+
+// S3GetObject retrieves an object from S3 given a bucket name and a source image.
+// Empty objects are returned as an empty slice. Objects encrypted with SSE-C need the WithSSEC key they were written with
+func (svc *S3) S3GetObject(bucketName, sourceImage string, opts ...EncryptionOption) ([]byte, error) {
+	return svc.S3GetObjectWithContext(context.Background(), bucketName, sourceImage, opts...)
 }
 
-// S3GetObject retrieves an object from S3 given a bucket name and a source image
-func (svc *S3) S3GetObject(bucketName, sourceImage string) ([]byte, error) {
+// S3GetObjectWithContext is the same as S3GetObject with the addition of a context.Context
+func (svc *S3) S3GetObjectWithContext(ctx context.Context, bucketName, sourceImage string, opts ...EncryptionOption) ([]byte, error) {
 
 	s3In, err := NewGetObjectInput(
 		bucketName,
 		sourceImage,
+		opts...,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	getObjectOut, err := svc.GetObject(s3In)
-	if err != nil {
+	buf := &bytes.Buffer{}
+
+	if _, err := svc.download(ctx, s3In, buf); err != nil {
 		return nil, err
 	}
 
-	out, err := UnmarshalGetObjectOutput(getObjectOut)
-	if err != nil {
-		return nil, err
+	return buf.Bytes(), nil
+
+}
+
+// PutInput contains the optional parameters of S3PutObject
+type PutInput struct {
+	contentType string
+	encryption  *Encryption
+}
+
+// PutOption sets an optional parameter on a *PutInput
+type PutOption func(*PutInput) error
+
+// WithPutContentType sets the content type of the put object.
+// When not set, it is resolved from the extension of the file, and otherwise detected from its body
+func WithPutContentType(contentType string) PutOption {
+	return func(in *PutInput) error {
+
+		if contentType == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, ContentType)
+		}
+
+		in.contentType = contentType
+
+		return nil
+
 	}
+}
 
-	return out, nil
+// WithPutEncryption encrypts the put object server-side as set by opts
+func WithPutEncryption(opts ...EncryptionOption) PutOption {
+	return func(in *PutInput) error {
 
+		enc, err := newEncryption(opts...)
+		if err != nil {
+			return err
+		}
+
+		in.encryption = enc
+
+		return nil
+
+	}
 }
 
-// S3PutObject puts a given object on S3
-func (svc *S3) S3PutObject(bucketName, objectName, objectPath string) error {
+// S3PutObject puts a given object on S3, with the optional parameters set by opts
+func (svc *S3) S3PutObject(bucketName, objectName, objectPath string, opts ...PutOption) error {
+	return svc.S3PutObjectWithContext(context.Background(), bucketName, objectName, objectPath, opts...)
+}
+
+// S3PutObjectWithContext is the same as S3PutObject with the addition of a context.Context
+func (svc *S3) S3PutObjectWithContext(ctx context.Context, bucketName, objectName, objectPath string, opts ...PutOption) error {
+
+	put, err := newPutInput(opts...)
+	if err != nil {
+		return err
+	}
 
-	imgMeta, err := ReadImage(objectPath)
+	imgMeta, err := readImage(objectPath, put.contentType)
 	if err != nil {
 		return err
 	}
@@ -60,11 +181,28 @@ func (svc *S3) S3PutObject(bucketName, objectName, objectPath string) error {
 		return err
 	}
 
-	_, err = svc.S3.PutObject(in)
+	put.encryption.putObject(in)
+
+	_, err = svc.S3.PutObjectWithContext(ctx, in, put.encryption.requestOptions()...)
 	if err != nil {
-		return err
+		return intErr.Wrap(err)
 	}
 
 	return nil
 
 }
+
+// newPutInput returns a new *PutInput set by opts
+func newPutInput(opts ...PutOption) (*PutInput, error) {
+
+	in := &PutInput{}
+
+	for _, opt := range opts {
+		if err := opt(in); err != nil {
+			return nil, err
+		}
+	}
+
+	return in, nil
+
+}
@@ -1,18 +1,26 @@
 package s3
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/stretchr/testify/assert"
 
-	"github.com/easynetwork/aws-sdk-go-bindings/internal/configuration"
 	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/config"
 	"github.com/easynetwork/aws-sdk-go-bindings/testdata"
 )
 
 func TestS3_S3CreateBucket(t *testing.T) {
 
-	s3Svc, cfg := newS3Svc(t)
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
 
 	createBucket(cfg, s3Svc, t)
 
@@ -20,7 +28,10 @@ func TestS3_S3CreateBucket(t *testing.T) {
 
 func TestS3_S3GetObject(t *testing.T) {
 
-	s3Svc, cfg := newS3Svc(t)
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
 
 	createBucket(cfg, s3Svc, t)
 	putObject(cfg, s3Svc, t)
@@ -33,24 +44,72 @@ func TestS3_S3GetObject(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, out)
 
+	stored, ok := srv.Object(cfg.S3.Bucket, cfg.S3.SourceImage)
+
+	assert.True(t, ok)
+	assert.Equal(t, stored, out)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some_empty_key", strings.NewReader("")))
+
+	out, err = s3Svc.S3GetObject(cfg.S3.Bucket, "some_empty_key")
+
+	assert.NoError(t, err)
+	assert.Empty(t, out)
+
+	_, err = s3Svc.S3GetObject(cfg.S3.Bucket, "some_missing_key")
+
+	assert.Error(t, err)
+	assert.Equal(t, "NoSuchKey", err.(awserr.Error).Code())
+
 }
 
 func TestS3_S3PutObject(t *testing.T) {
 
-	s3Svc, cfg := newS3Svc(t)
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
 
 	createBucket(cfg, s3Svc, t)
 	putObject(cfg, s3Svc, t)
 
+	head := headObject(t, s3Svc, cfg.S3.Bucket, cfg.S3.SourceImage)
+
+	assert.Equal(t, "image/jpeg", *head.ContentType)
+
+}
+
+func TestS3_S3PutObject_ContentType(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	path := "../../../assets/compare_faces_test-source.jpg"
+
+	assert.NoError(t, s3Svc.S3PutObject(cfg.S3.Bucket, "some/key", path, WithPutContentType("some/type")))
+
+	head := headObject(t, s3Svc, cfg.S3.Bucket, "some/key")
+
+	assert.Equal(t, "some/type", *head.ContentType)
+
+	err := s3Svc.S3PutObject(cfg.S3.Bucket, "some/key", path, WithPutContentType(""))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ContentType)
+
 }
 
-func createBucket(cfg *configuration.Configuration, svc *S3, t *testing.T) {
+func createBucket(cfg *config.Configuration, svc *S3, t *testing.T) {
 
 	svc.S3CreateBucket(cfg.S3.Bucket)
 
 }
 
-func putObject(cfg *configuration.Configuration, svc *S3, t *testing.T) {
+func putObject(cfg *config.Configuration, svc *S3, t *testing.T) {
 
 	err := svc.S3PutObject(
 		cfg.S3.Bucket,
@@ -62,13 +121,13 @@ func putObject(cfg *configuration.Configuration, svc *S3, t *testing.T) {
 
 }
 
-func newS3Svc(t *testing.T) (*S3, *configuration.Configuration) {
+func newS3Svc(t *testing.T, srv *fake.Server) (*S3, *config.Configuration) {
 
 	t.Helper()
 
 	cfg := testdata.MockConfiguration(t)
 
-	svcIn, err := aws.NewSessionInput(cfg.Region)
+	svcIn, err := aws.NewSessionInput(cfg.Region, aws.WithStaticCredentials(fake.AccessKeyID, fake.SecretAccessKey, ""))
 
 	assert.NoError(t, err)
 
@@ -77,7 +136,7 @@ func newS3Svc(t *testing.T) (*S3, *configuration.Configuration) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, awsSvc)
 
-	s3Svc, err := New(awsSvc, cfg.S3.Endpoint)
+	s3Svc, err := New(awsSvc, srv.URL, aws.WithS3ForcePathStyle(true), aws.WithHTTPClient(srv.Client()))
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, s3Svc)
@@ -85,3 +144,29 @@ func newS3Svc(t *testing.T) (*S3, *configuration.Configuration) {
 	return s3Svc, cfg
 
 }
+
+func TestS3_S3GetObjectWithContext(t *testing.T) {
+
+	cfg := testdata.MockConfiguration(t)
+
+	svcIn, err := aws.NewSessionInput(cfg.Region, aws.WithStaticCredentials("some_key", "some_secret", ""))
+
+	assert.NoError(t, err)
+
+	awsSvc, err := aws.New(svcIn)
+
+	assert.NoError(t, err)
+
+	s3Svc, err := New(awsSvc, cfg.S3.Endpoint)
+
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = s3Svc.S3GetObjectWithContext(ctx, cfg.S3.Bucket, cfg.S3.SourceImage)
+
+	assert.Error(t, err)
+	assert.Equal(t, request.CanceledErrorCode, err.(awserr.Error).Code())
+
+}
@@ -1,8 +1,6 @@
 package s3
 
 import (
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 
 	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
@@ -13,17 +11,15 @@ type S3 struct {
 	*s3.S3
 }
 
-// New returns a new *S3 embedding *s3.S3
-func New(svc *pkgAws.Session, endpoint string) (*S3, error) {
+// New returns a new *S3 embedding *s3.S3.
+// endpoint and opts only apply to the returned client, svc is left untouched
+func New(svc *pkgAws.Session, endpoint string, opts ...pkgAws.ClientOption) (*S3, error) {
 
 	if endpoint != "" {
-		svc.Config.Endpoint = aws.String(endpoint)
+		opts = append([]pkgAws.ClientOption{pkgAws.WithEndpoint(endpoint)}, opts...)
 	}
 
-	newSvc, err := session.NewSession(svc.Config)
-	if err != nil {
-		return nil, err
-	}
+	newSvc := svc.ServiceSession(opts...)
 
 	s3Svc := &S3{
 		S3: s3.New(newSvc),
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
 	"github.com/easynetwork/aws-sdk-go-bindings/testdata"
 )
 
@@ -25,5 +26,33 @@ func TestNew(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, snsSvc)
+	assert.Equal(t, cfg.S3.Endpoint, *snsSvc.Config.Endpoint)
+	assert.Nil(t, awsSvc.Config.Endpoint)
+
+}
+
+func TestNew_LocalStack(t *testing.T) {
+
+	cfg := testdata.MockConfiguration(t)
+
+	srv := fake.New()
+	defer srv.Close()
+
+	in, err := aws.NewSessionInput(cfg.Region, aws.WithLocalStack(srv.URL, nil))
+
+	assert.NoError(t, err)
+
+	awsSvc, err := aws.New(in)
+
+	assert.NoError(t, err)
+
+	s3Svc, err := New(awsSvc, "")
+
+	assert.NoError(t, err)
+
+	err = s3Svc.S3CreateBucket(cfg.S3.Bucket)
+
+	assert.NoError(t, err)
+	assert.Equal(t, srv.URL, s3Svc.Endpoint)
 
 }
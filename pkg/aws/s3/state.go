@@ -0,0 +1,135 @@
+package s3
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+// UploadState is the persisted state of a multipart upload, used to resume it
+// after an interruption
+type UploadState struct {
+	Bucket   string         `json:"bucket"`
+	Key      string         `json:"key"`
+	UploadID string         `json:"upload_id"`
+	Size     int64          `json:"size"`
+	PartSize int64          `json:"part_size"`
+	ModTime  time.Time      `json:"mod_time,omitempty"`
+	Parts    []UploadedPart `json:"parts"`
+}
+
+// UploadedPart is a part of a multipart upload already accepted by S3
+type UploadedPart struct {
+	Number int64  `json:"number"`
+	ETag   string `json:"etag"`
+}
+
+// StateStore persists the state of multipart uploads, keyed by bucket and object name
+type StateStore interface {
+	// Load returns the state saved for bucketName and objectName, or nil if there is none
+	Load(bucketName, objectName string) (*UploadState, error)
+	// Save saves state, replacing any state saved for the same bucket and object name
+	Save(state *UploadState) error
+	// Delete deletes the state saved for bucketName and objectName, if any
+	Delete(bucketName, objectName string) error
+}
+
+// FileStateStore is a StateStore saving every upload state as a json file in a directory
+type FileStateStore struct {
+	dir string
+}
+
+var _ StateStore = (*FileStateStore)(nil)
+
+// NewFileStateStore returns a new *FileStateStore saving states in dir, which is
+// created if it does not exist
+func NewFileStateStore(dir string) (*FileStateStore, error) {
+
+	if dir == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Dir)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &FileStateStore{
+		dir: dir,
+	}, nil
+
+}
+
+// Load implements StateStore
+func (s *FileStateStore) Load(bucketName, objectName string) (*UploadState, error) {
+
+	b, err := ioutil.ReadFile(s.path(bucketName, objectName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := &UploadState{}
+	if err := json.Unmarshal(b, state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+
+}
+
+// Save implements StateStore. The file is replaced atomically, so that an interruption
+// while saving never leaves a truncated state behind
+func (s *FileStateStore) Save(state *UploadState) error {
+
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(s.dir, ".upload-")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(state.Bucket, state.Key))
+
+}
+
+// Delete implements StateStore
+func (s *FileStateStore) Delete(bucketName, objectName string) error {
+
+	err := os.Remove(s.path(bucketName, objectName))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+
+}
+
+// path returns the file the state of bucketName and objectName is saved to
+func (s *FileStateStore) path(bucketName, objectName string) string {
+
+	sum := sha256.Sum256([]byte(bucketName + "/" + objectName))
+
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+
+}
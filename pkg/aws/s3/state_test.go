@@ -0,0 +1,56 @@
+package s3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStateStore(t *testing.T) {
+
+	store, err := NewFileStateStore(t.TempDir())
+
+	assert.NoError(t, err)
+
+	state, err := store.Load("some_bucket", "some/key")
+
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	saved := &UploadState{
+		Bucket:   "some_bucket",
+		Key:      "some/key",
+		UploadID: "some_upload_id",
+		Size:     MinPartSize + 1,
+		PartSize: MinPartSize,
+		ModTime:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Parts:    []UploadedPart{{Number: 1, ETag: `"some_etag"`}},
+	}
+
+	assert.NoError(t, store.Save(saved))
+
+	state, err = store.Load("some_bucket", "some/key")
+
+	assert.NoError(t, err)
+	assert.Equal(t, saved, state)
+
+	state, err = store.Load("some_bucket", "some/other_key")
+
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	assert.NoError(t, store.Delete("some_bucket", "some/key"))
+	assert.NoError(t, store.Delete("some_bucket", "some/key"))
+
+	state, err = store.Load("some_bucket", "some/key")
+
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	_, err = NewFileStateStore("")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), Dir)
+
+}
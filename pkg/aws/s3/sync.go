@@ -0,0 +1,734 @@
+package s3
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+// SyncCompare is how S3SyncUp and S3SyncDown decide whether a file and an object of the same size differ
+type SyncCompare int
+
+const (
+
+	// CompareModTime syncs files whose source has been modified after its destination. It is the default
+	CompareModTime SyncCompare = iota
+
+	// CompareSize only syncs files whose size differs
+	CompareSize
+
+	// CompareChecksum syncs files whose MD5 differs from the ETag of their object. The ETags of multipart
+	// uploads are computed with the part size of the sync uploads, set with WithSyncUploadOptions.
+	// Objects whose ETag cannot be computed that way, like the ones encrypted with SSE-KMS or SSE-C,
+	// are compared with CompareModTime, at the cost of a HeadObject when their ETag differs
+	CompareChecksum
+)
+
+// SyncAction is what a sync did, or would do with WithDryRun, for a file
+type SyncAction string
+
+const (
+
+	// SyncUpload uploads a local file missing from the bucket or differing from its object
+	SyncUpload SyncAction = "upload"
+
+	// SyncDownload downloads an object missing from the local tree or differing from its file
+	SyncDownload SyncAction = "download"
+
+	// SyncDelete deletes a file or an object missing from the source, with WithSyncDelete
+	SyncDelete SyncAction = "delete"
+
+	// SyncSkip leaves a file and an object which are the same untouched
+	SyncSkip SyncAction = "skip"
+)
+
+// SyncReport describes the outcome of a sync, file by file in the order of their keys
+type SyncReport struct {
+	Files []SyncedFile
+}
+
+// SyncedFile is a file of a sync, present in the local tree, the bucket or both
+type SyncedFile struct {
+	Key  string
+	Path string
+	Size int64
+	// Action is the action decided for the file. It is empty when the file failed before being compared
+	Action SyncAction
+	// Err is the error which made the file fail, if any
+	Err error
+}
+
+// Failed returns the files of r which failed
+func (r *SyncReport) Failed() []SyncedFile {
+
+	var failed []SyncedFile
+	for _, f := range r.Files {
+		if f.Err != nil {
+			failed = append(failed, f)
+		}
+	}
+
+	return failed
+
+}
+
+// SyncInput contains the optional parameters of S3SyncUp and S3SyncDown
+type SyncInput struct {
+	compare      SyncCompare
+	delete       bool
+	include      []string
+	exclude      []string
+	dryRun       bool
+	concurrency  int
+	uploadOpts   []UploadOption
+	downloadOpts []DownloadOption
+	upload       *UploadInput
+}
+
+// SyncOption sets an optional parameter on a *SyncInput
+type SyncOption func(*SyncInput) error
+
+// syncPair is a file of a sync along with its local and remote versions, nil when missing
+type syncPair struct {
+	file   *SyncedFile
+	rel    string
+	local  os.FileInfo
+	remote *Entry
+}
+
+// WithSyncCompare sets how files and objects of the same size are compared, CompareModTime by default
+func WithSyncCompare(compare SyncCompare) SyncOption {
+	return func(in *SyncInput) error {
+
+		if compare < CompareModTime || compare > CompareChecksum {
+			return intErr.NewValidationError(ErrInvalidParameter, Compare)
+		}
+
+		in.compare = compare
+
+		return nil
+
+	}
+}
+
+// WithSyncDelete deletes the files or objects of the destination missing from the source.
+// Files excluded by WithInclude and WithExclude are never deleted
+func WithSyncDelete() SyncOption {
+	return func(in *SyncInput) error {
+
+		in.delete = true
+
+		return nil
+
+	}
+}
+
+// WithInclude only syncs the files matching at least one of patterns. Patterns are matched
+// with path.Match against the slash separated path of files relative to the synced directory,
+// or against their base name when they contain no slash. It can be passed more than once
+func WithInclude(patterns ...string) SyncOption {
+	return func(in *SyncInput) error {
+
+		if err := validatePatterns(patterns); err != nil {
+			return err
+		}
+
+		in.include = append(in.include, patterns...)
+
+		return nil
+
+	}
+}
+
+// WithExclude never syncs the files matching one of patterns, even if included by WithInclude.
+// Patterns are matched like with WithInclude. It can be passed more than once
+func WithExclude(patterns ...string) SyncOption {
+	return func(in *SyncInput) error {
+
+		if err := validatePatterns(patterns); err != nil {
+			return err
+		}
+
+		in.exclude = append(in.exclude, patterns...)
+
+		return nil
+
+	}
+}
+
+// WithDryRun compares the files and reports what would be done, without uploading,
+// downloading or deleting anything
+func WithDryRun() SyncOption {
+	return func(in *SyncInput) error {
+
+		in.dryRun = true
+
+		return nil
+
+	}
+}
+
+// WithSyncConcurrency sets how many files are compared and transferred at the same time, DefaultConcurrency by default
+func WithSyncConcurrency(concurrency int) SyncOption {
+	return func(in *SyncInput) error {
+
+		if concurrency < 1 {
+			return intErr.NewValidationError(ErrInvalidParameter, Concurrency)
+		}
+
+		in.concurrency = concurrency
+
+		return nil
+
+	}
+}
+
+// WithSyncUploadOptions sets the options of every upload of S3SyncUp
+func WithSyncUploadOptions(opts ...UploadOption) SyncOption {
+	return func(in *SyncInput) error {
+
+		in.uploadOpts = opts
+
+		return nil
+
+	}
+}
+
+// WithSyncDownloadOptions sets the options of every download of S3SyncDown. WithRange cannot be used
+func WithSyncDownloadOptions(opts ...DownloadOption) SyncOption {
+	return func(in *SyncInput) error {
+
+		in.downloadOpts = opts
+
+		return nil
+
+	}
+}
+
+// S3SyncUp uploads the files of localDir to prefix in bucketName when their object is missing
+// or differs from them, as compared by WithSyncCompare. Files are uploaded under their path
+// relative to localDir, with slashes, and prefix is followed by a slash when not empty.
+// Failing files are reported in the returned *SyncReport rather than stopping the sync
+func (svc *S3) S3SyncUp(localDir, bucketName, prefix string, opts ...SyncOption) (*SyncReport, error) {
+	return svc.S3SyncUpWithContext(context.Background(), localDir, bucketName, prefix, opts...)
+}
+
+// S3SyncUpWithContext is the same as S3SyncUp with the addition of a context.Context
+func (svc *S3) S3SyncUpWithContext(ctx context.Context, localDir, bucketName, prefix string, opts ...SyncOption) (*SyncReport, error) {
+
+	in, err := newSyncInput(localDir, bucketName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(localDir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, Dir)
+	}
+
+	pairs, err := svc.syncPairs(ctx, in, localDir, bucketName, prefix, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.runSync(ctx, in, bucketName, pairs, true, func(ctx context.Context, p *syncPair) error {
+
+		switch p.file.Action {
+		case SyncUpload:
+			return svc.S3UploadFileWithContext(ctx, bucketName, p.file.Key, p.file.Path, in.uploadOpts...)
+		case SyncDelete:
+			return svc.S3DeleteObjectWithContext(ctx, bucketName, p.file.Key)
+		}
+
+		return nil
+
+	}), nil
+
+}
+
+// S3SyncDown downloads the objects under prefix in bucketName to localDir when their file is missing
+// or differs from them, as compared by WithSyncCompare, creating localDir if needed. Objects are
+// downloaded under their key relative to prefix, and get their last modification time as mtime.
+// Failing files are reported in the returned *SyncReport rather than stopping the sync
+func (svc *S3) S3SyncDown(bucketName, prefix, localDir string, opts ...SyncOption) (*SyncReport, error) {
+	return svc.S3SyncDownWithContext(context.Background(), bucketName, prefix, localDir, opts...)
+}
+
+// S3SyncDownWithContext is the same as S3SyncDown with the addition of a context.Context
+func (svc *S3) S3SyncDownWithContext(ctx context.Context, bucketName, prefix, localDir string, opts ...SyncOption) (*SyncReport, error) {
+
+	in, err := newSyncInput(localDir, bucketName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if !in.dryRun {
+		if err := os.MkdirAll(localDir, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	pairs, err := svc.syncPairs(ctx, in, localDir, bucketName, prefix, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.runSync(ctx, in, bucketName, pairs, false, func(ctx context.Context, p *syncPair) error {
+
+		switch p.file.Action {
+		case SyncDownload:
+			return svc.downloadFile(ctx, in, bucketName, p)
+		case SyncDelete:
+			return os.Remove(p.file.Path)
+		}
+
+		return nil
+
+	}), nil
+
+}
+
+// newSyncInput validates the parameters shared by every sync and returns a new *SyncInput
+func newSyncInput(localDir, bucketName string, opts ...SyncOption) (*SyncInput, error) {
+
+	if localDir == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Dir)
+	}
+	if bucketName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+
+	in := &SyncInput{
+		concurrency: DefaultConcurrency,
+	}
+
+	for _, opt := range opts {
+		if err := opt(in); err != nil {
+			return nil, err
+		}
+	}
+
+	upload, err := applyUploadOptions(in.uploadOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	in.upload = upload
+
+	download, err := applyDownloadOptions(in.downloadOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if download.offset > 0 || download.length > 0 {
+		return nil, intErr.NewValidationError(ErrInvalidParameter, Offset)
+	}
+
+	return in, nil
+
+}
+
+// syncPairs lists the local files and the objects of a sync and pairs them by relative path.
+// Files of the destination missing from the source are only kept with WithSyncDelete
+func (svc *S3) syncPairs(ctx context.Context, in *SyncInput, localDir, bucketName, prefix string, up bool) ([]*syncPair, error) {
+
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	local, err := in.localFiles(localDir)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := svc.remoteObjects(ctx, in, bucketName, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make(map[string]*syncPair, len(local))
+
+	pair := func(rel string) *syncPair {
+		p, ok := pairs[rel]
+		if !ok {
+			p = &syncPair{
+				file: &SyncedFile{Key: prefix + rel, Path: filepath.Join(localDir, filepath.FromSlash(rel))},
+				rel:  rel,
+			}
+			pairs[rel] = p
+		}
+		return p
+	}
+
+	for rel, info := range local {
+		if _, ok := remote[rel]; ok || up || in.delete {
+			pair(rel).local = info
+		}
+	}
+	for rel, entry := range remote {
+		if _, ok := local[rel]; ok || !up || in.delete {
+			pair(rel).remote = entry
+		}
+	}
+
+	out := make([]*syncPair, 0, len(pairs))
+	for _, p := range pairs {
+		out = append(out, p)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].file.Key < out[j].file.Key })
+
+	return out, nil
+
+}
+
+// localFiles returns the regular files of localDir matching the patterns of in, by slash separated relative path.
+// A missing localDir has no files
+func (in *SyncInput) localFiles(localDir string) (map[string]os.FileInfo, error) {
+
+	out := make(map[string]os.FileInfo)
+
+	err := filepath.Walk(localDir, func(p string, info os.FileInfo, err error) error {
+
+		if os.IsNotExist(err) && p == localDir {
+			return filepath.SkipDir
+		}
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(localDir, p)
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.ToSlash(rel)
+		if in.match(rel) {
+			out[rel] = info
+		}
+
+		return nil
+
+	})
+
+	return out, err
+
+}
+
+// remoteObjects returns the objects under prefix matching the patterns of in, by key relative to prefix.
+// Keys ending with a slash, created by consoles as directories, are left out
+func (svc *S3) remoteObjects(ctx context.Context, in *SyncInput, bucketName, prefix string) (map[string]*Entry, error) {
+
+	out := make(map[string]*Entry)
+
+	it := svc.S3ListObjectsWithContext(ctx, bucketName, WithPrefix(prefix))
+
+	for it.Next() {
+
+		entry := it.Entry()
+		rel := strings.TrimPrefix(entry.Key, prefix)
+
+		if rel != "" && !strings.HasSuffix(rel, "/") && in.match(rel) {
+			out[rel] = &entry
+		}
+
+	}
+
+	return out, it.Err()
+
+}
+
+// runSync decides the action of every pair, then runs do on them with in.concurrency workers unless in.dryRun.
+// Pairs left when ctx is done fail with its error
+func (svc *S3) runSync(ctx context.Context, in *SyncInput, bucketName string, pairs []*syncPair, up bool, do func(context.Context, *syncPair) error) *SyncReport {
+
+	work := make(chan *syncPair)
+	wg := &sync.WaitGroup{}
+
+	for i := 0; i < in.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+
+			defer wg.Done()
+
+			for p := range work {
+
+				if err := ctx.Err(); err != nil {
+					p.file.Err = err
+					continue
+				}
+
+				action, err := svc.syncAction(ctx, in, bucketName, p, up)
+				if err != nil {
+					p.file.Err = err
+					continue
+				}
+
+				p.file.Action = action
+
+				if !in.dryRun {
+					p.file.Err = do(ctx, p)
+				}
+
+			}
+
+		}()
+	}
+
+	for _, p := range pairs {
+		work <- p
+	}
+
+	close(work)
+	wg.Wait()
+
+	report := &SyncReport{Files: make([]SyncedFile, 0, len(pairs))}
+	for _, p := range pairs {
+		report.Files = append(report.Files, *p.file)
+	}
+
+	return report
+
+}
+
+// syncAction returns the action of the sync of p
+func (svc *S3) syncAction(ctx context.Context, in *SyncInput, bucketName string, p *syncPair, up bool) (SyncAction, error) {
+
+	transfer := SyncDownload
+	if up {
+		transfer = SyncUpload
+	}
+
+	p.file.Size = sizeOf(p, up)
+
+	switch {
+	case up && p.local == nil, !up && p.remote == nil:
+		return SyncDelete, nil
+	case p.local == nil, p.remote == nil:
+		return transfer, nil
+	}
+
+	if p.local.Size() != p.remote.Size {
+		return transfer, nil
+	}
+
+	if in.compare == CompareSize {
+		return SyncSkip, nil
+	}
+
+	if in.compare == CompareChecksum {
+
+		etag, ok, err := localETag(p.file.Path, p.local.Size(), p.remote.ETag, in.upload.partSizeOf(p.local.Size()))
+		if err != nil {
+			return "", err
+		}
+		if ok && etag == strings.Trim(p.remote.ETag, `"`) {
+			return SyncSkip, nil
+		}
+		if ok {
+			// a mismatch is only meaningful when the ETag is the MD5 of the object
+			md5ETag, err := svc.isMD5ETag(ctx, bucketName, p.file.Key)
+			if err != nil {
+				return "", err
+			}
+			if md5ETag {
+				return transfer, nil
+			}
+		}
+
+	}
+
+	// listings only have a precision of a second
+	modTime := p.local.ModTime().Truncate(time.Second)
+
+	if up && modTime.After(p.remote.LastModified) || !up && p.remote.LastModified.After(modTime) {
+		return transfer, nil
+	}
+
+	return SyncSkip, nil
+
+}
+
+// match reports whether the relative path rel is included and not excluded
+func (in *SyncInput) match(rel string) bool {
+
+	if len(in.include) > 0 && !matchAny(in.include, rel) {
+		return false
+	}
+
+	return !matchAny(in.exclude, rel)
+
+}
+
+// downloadFile downloads the object of p to a temporary file renamed to its path once complete,
+// so that an interrupted download never leaves a partial file behind
+func (svc *S3) downloadFile(ctx context.Context, in *SyncInput, bucketName string, p *syncPair) error {
+
+	// keys with . or .. segments could be written outside of the synced directory
+	if path.Clean("/"+p.rel) != "/"+p.rel {
+		return intErr.NewValidationError(ErrInvalidParameter, ObjectName)
+	}
+
+	dir := filepath.Dir(p.file.Path)
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(dir, "."+filepath.Base(p.file.Path)+".")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = svc.S3DownloadWithContext(ctx, bucketName, p.file.Key, tmp, in.downloadOpts...)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chtimes(tmp.Name(), p.remote.LastModified, p.remote.LastModified); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), p.file.Path)
+
+}
+
+// sizeOf returns the size of the source of p, or of its destination when it has no source
+func sizeOf(p *syncPair, up bool) int64 {
+
+	if up && p.local != nil || p.remote == nil {
+		return p.local.Size()
+	}
+
+	return p.remote.Size
+
+}
+
+// isMD5ETag reports whether the ETag of objectName in bucketName is computed from its content as in
+// localETag. It is not for objects encrypted with SSE-KMS or SSE-C, whose HeadObject is refused without their key
+func (svc *S3) isMD5ETag(ctx context.Context, bucketName, objectName string) (bool, error) {
+
+	in := &s3.HeadObjectInput{}
+	in = in.SetBucket(bucketName)
+	in = in.SetKey(objectName)
+
+	out, err := svc.S3.HeadObjectWithContext(ctx, in)
+	if reqErr, ok := err.(awserr.RequestFailure); ok && reqErr.StatusCode() == http.StatusBadRequest {
+		return false, nil
+	}
+	if err != nil {
+		return false, intErr.Wrap(err)
+	}
+
+	return aws.StringValue(out.ServerSideEncryption) != s3.ServerSideEncryptionAwsKms && out.SSECustomerAlgorithm == nil, nil
+
+}
+
+// localETag returns the ETag S3 would give to the file at path, uploaded in parts of partSize when
+// etag is the one of a multipart upload. It is false when etag has another number of parts
+func localETag(path string, size int64, etag string, partSize int64) (string, bool, error) {
+
+	parts := int64(0)
+
+	if i := strings.LastIndex(etag, "-"); i >= 0 {
+		n, err := strconv.ParseInt(strings.Trim(etag[i+1:], `"`), 10, 64)
+		if err != nil || n != (size+partSize-1)/partSize {
+			return "", false, nil
+		}
+		parts = n
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", false, err
+	}
+
+	defer file.Close()
+
+	if parts == 0 {
+
+		h := md5.New()
+		if _, err := io.Copy(h, file); err != nil {
+			return "", false, err
+		}
+
+		return hex.EncodeToString(h.Sum(nil)), true, nil
+
+	}
+
+	sums := md5.New()
+
+	for i := int64(0); i < parts; i++ {
+
+		h := md5.New()
+		if _, err := io.CopyN(h, file, partSize); err != nil && err != io.EOF {
+			return "", false, err
+		}
+
+		sums.Write(h.Sum(nil))
+
+	}
+
+	return hex.EncodeToString(sums.Sum(nil)) + "-" + strconv.FormatInt(parts, 10), true, nil
+
+}
+
+// matchAny reports whether rel, or its base name for patterns without a slash, matches one of patterns
+func matchAny(patterns []string, rel string) bool {
+
+	for _, pattern := range patterns {
+
+		name := rel
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(rel)
+		}
+
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+
+	}
+
+	return false
+
+}
+
+// validatePatterns checks that patterns are valid path.Match patterns
+func validatePatterns(patterns []string) error {
+
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); pattern == "" || err != nil {
+			return intErr.NewValidationError(ErrInvalidParameter, Pattern)
+		}
+	}
+
+	return nil
+
+}
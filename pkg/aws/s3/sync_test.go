@@ -0,0 +1,417 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestS3_S3SyncUp(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	dir := t.TempDir()
+	past := time.Now().Add(-time.Hour)
+
+	writeSyncFile(t, dir, "a.txt", "some_body", past)
+	writeSyncFile(t, dir, "sub/b.txt", "other_body", past)
+	writeSyncFile(t, dir, "sub/c.log", "some_log", past)
+
+	report, err := s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "some/prefix", WithExclude("*.log"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncedFile{
+		{Key: "some/prefix/a.txt", Path: filepath.Join(dir, "a.txt"), Size: 9, Action: SyncUpload},
+		{Key: "some/prefix/sub/b.txt", Path: filepath.Join(dir, "sub", "b.txt"), Size: 10, Action: SyncUpload},
+	}, report.Files)
+
+	body, _ := srv.Object(cfg.S3.Bucket, "some/prefix/sub/b.txt")
+
+	assert.Equal(t, "other_body", string(body))
+
+	_, ok := srv.Object(cfg.S3.Bucket, "some/prefix/sub/c.log")
+
+	assert.False(t, ok)
+
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "some/prefix/", WithExclude("*.log"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncSkip, SyncSkip}, syncActions(report))
+
+	// same size, modified after the upload
+	writeSyncFile(t, dir, "a.txt", "some_BODY", time.Now().Add(time.Hour))
+
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "some/prefix", WithExclude("*.log"), WithSyncCompare(CompareSize))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncSkip, SyncSkip}, syncActions(report))
+
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "some/prefix", WithExclude("*.log"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncUpload, SyncSkip}, syncActions(report))
+
+	body, _ = srv.Object(cfg.S3.Bucket, "some/prefix/a.txt")
+
+	assert.Equal(t, "some_BODY", string(body))
+
+	writeSyncFile(t, dir, "a.txt", "some_BODY", past)
+
+	// same size, modified before the upload
+	writeSyncFile(t, dir, "sub/b.txt", "other_BODY", past)
+
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "some/prefix", WithExclude("*.log"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncSkip, SyncSkip}, syncActions(report))
+
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "some/prefix", WithExclude("*.log"), WithSyncCompare(CompareChecksum))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncSkip, SyncUpload}, syncActions(report))
+
+	// extra objects, with a directory marker never synced
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/prefix/extra.txt", strings.NewReader("some_extra")))
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/prefix/sub/", strings.NewReader("")))
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/prefix/sub/d.log", strings.NewReader("some_log")))
+
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "some/prefix", WithExclude("*.log"))
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Files, 2)
+
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "some/prefix", WithExclude("*.log"), WithSyncDelete(), WithDryRun())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncSkip, SyncDelete, SyncSkip}, syncActions(report))
+	assert.Equal(t, "some/prefix/extra.txt", report.Files[1].Key)
+	assert.Equal(t, int64(10), report.Files[1].Size)
+
+	_, ok = srv.Object(cfg.S3.Bucket, "some/prefix/extra.txt")
+
+	assert.True(t, ok)
+
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "some/prefix", WithExclude("*.log"), WithSyncDelete())
+
+	assert.NoError(t, err)
+	assert.Empty(t, report.Failed())
+
+	_, ok = srv.Object(cfg.S3.Bucket, "some/prefix/extra.txt")
+
+	assert.False(t, ok)
+
+	_, ok = srv.Object(cfg.S3.Bucket, "some/prefix/sub/d.log")
+
+	assert.True(t, ok)
+
+	_, err = s3Svc.S3SyncUp(dir, "some_missing_bucket", "")
+
+	assert.Error(t, err)
+
+}
+
+func TestS3_S3SyncUp_Multipart(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	dir := t.TempDir()
+	body := bytes.Repeat([]byte("a"), MinPartSize+1)
+
+	writeSyncFile(t, dir, "big", string(body), time.Now().Add(-time.Hour))
+
+	report, err := s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "", WithSyncCompare(CompareChecksum))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncUpload}, syncActions(report))
+
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "", WithSyncCompare(CompareChecksum))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncSkip}, syncActions(report))
+
+	body[0] = 'b'
+	writeSyncFile(t, dir, "big", string(body), time.Now().Add(-time.Hour))
+
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "", WithSyncCompare(CompareChecksum))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncUpload}, syncActions(report))
+
+	// the ETag has another number of parts, so that the modification times are compared
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "",
+		WithSyncCompare(CompareChecksum),
+		WithSyncUploadOptions(WithPartSize(MinPartSize+2)),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncSkip}, syncActions(report))
+
+}
+
+func TestS3_S3SyncUp_ChecksumEncrypted(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	dir := t.TempDir()
+
+	writeSyncFile(t, dir, "a.txt", "some_body", time.Now().Add(-time.Hour))
+
+	opts := []SyncOption{
+		WithSyncCompare(CompareChecksum),
+		WithSyncUploadOptions(WithEncryption(WithSSEKMS("alias/some-key"))),
+	}
+
+	report, err := s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "", opts...)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncUpload}, syncActions(report))
+
+	// the ETag of SSE-KMS objects is not their MD5, so that the modification times are compared
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "", opts...)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncSkip}, syncActions(report))
+
+	writeSyncFile(t, dir, "a.txt", "some_bodx", time.Now().Add(time.Hour))
+
+	report, err = s3Svc.S3SyncUp(dir, cfg.S3.Bucket, "", append(opts, WithDryRun())...)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncUpload}, syncActions(report))
+
+}
+
+func TestS3_S3SyncDown(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/prefix/a.txt", strings.NewReader("some_body")))
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/prefix/sub/b.txt", strings.NewReader("other_body")))
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/prefix/sub/c.log", strings.NewReader("some_log")))
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/other.txt", strings.NewReader("some_other")))
+
+	dir := filepath.Join(t.TempDir(), "some_dir")
+
+	report, err := s3Svc.S3SyncDown(cfg.S3.Bucket, "some/prefix", dir, WithDryRun())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncDownload, SyncDownload, SyncDownload}, syncActions(report))
+
+	_, err = os.Stat(dir)
+
+	assert.True(t, os.IsNotExist(err))
+
+	report, err = s3Svc.S3SyncDown(cfg.S3.Bucket, "some/prefix", dir, WithInclude("*.txt"), WithSyncConcurrency(1))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncedFile{
+		{Key: "some/prefix/a.txt", Path: filepath.Join(dir, "a.txt"), Size: 9, Action: SyncDownload},
+		{Key: "some/prefix/sub/b.txt", Path: filepath.Join(dir, "sub", "b.txt"), Size: 10, Action: SyncDownload},
+	}, report.Files)
+
+	body, err := ioutil.ReadFile(filepath.Join(dir, "sub", "b.txt"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "other_body", string(body))
+
+	_, err = os.Stat(filepath.Join(dir, "sub", "c.log"))
+
+	assert.True(t, os.IsNotExist(err))
+
+	report, err = s3Svc.S3SyncDown(cfg.S3.Bucket, "some/prefix", dir, WithInclude("*.txt"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncSkip, SyncSkip}, syncActions(report))
+
+	report, err = s3Svc.S3SyncDown(cfg.S3.Bucket, "some/prefix", dir, WithInclude("*.txt"), WithSyncCompare(CompareChecksum))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncSkip, SyncSkip}, syncActions(report))
+
+	// local changes older than the objects are only seen by checksums
+	writeSyncFile(t, dir, "a.txt", "some_BODY", time.Now().Add(-time.Hour))
+	writeSyncFile(t, dir, "extra.txt", "some_extra", time.Now())
+	writeSyncFile(t, dir, "extra.log", "some_extra", time.Now())
+
+	report, err = s3Svc.S3SyncDown(cfg.S3.Bucket, "some/prefix", dir, WithInclude("*.txt"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncDownload, SyncSkip}, syncActions(report))
+
+	body, _ = ioutil.ReadFile(filepath.Join(dir, "a.txt"))
+
+	assert.Equal(t, "some_body", string(body))
+
+	report, err = s3Svc.S3SyncDown(cfg.S3.Bucket, "some/prefix", dir, WithInclude("*.txt"), WithSyncDelete())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []SyncAction{SyncSkip, SyncDelete, SyncSkip}, syncActions(report))
+
+	_, err = os.Stat(filepath.Join(dir, "extra.txt"))
+
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = os.Stat(filepath.Join(dir, "extra.log"))
+
+	assert.NoError(t, err)
+
+	// keys escaping the directory are never written
+	_, err = s3Svc.S3.PutObjectWithContext(context.Background(), &s3.PutObjectInput{
+		Bucket: aws.String(cfg.S3.Bucket),
+		Key:    aws.String("some/prefix/../escaped.txt"),
+		Body:   strings.NewReader("some_body"),
+	}, func(r *request.Request) {
+		r.Config.DisableRestProtocolURICleaning = aws.Bool(true)
+	})
+
+	assert.NoError(t, err)
+
+	report, err = s3Svc.S3SyncDown(cfg.S3.Bucket, "some/prefix", dir, WithInclude("*.txt"))
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Failed(), 1)
+	assert.True(t, errors.Is(report.Failed()[0].Err, intErr.NewValidationError(ErrInvalidParameter, ObjectName)))
+
+	_, err = os.Stat(filepath.Join(dir, "..", "escaped.txt"))
+
+	assert.True(t, os.IsNotExist(err))
+
+	// no temporary file is left behind
+	files, _ := ioutil.ReadDir(dir)
+
+	for _, f := range files {
+		assert.False(t, strings.HasPrefix(f.Name(), "."), f.Name())
+	}
+
+}
+
+func TestS3_S3SyncDown_Canceled(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	assert.NoError(t, s3Svc.S3Upload(cfg.S3.Bucket, "some/key", strings.NewReader("some_body")))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s3Svc.S3SyncDownWithContext(ctx, cfg.S3.Bucket, "", t.TempDir())
+
+	assert.Error(t, err)
+
+}
+
+func TestS3_SyncValidation(t *testing.T) {
+
+	file := writeTempFile(t, []byte("some_body"))
+
+	for param, f := range map[string]func() error{
+		Dir: func() error {
+			_, err := (&S3{}).S3SyncUp("", "some_bucket", "")
+			return err
+		},
+		BucketName: func() error {
+			_, err := (&S3{}).S3SyncDown("", "", "some_dir")
+			return err
+		},
+		Compare: func() error {
+			_, err := (&S3{}).S3SyncUp("some_dir", "some_bucket", "", WithSyncCompare(SyncCompare(9)))
+			return err
+		},
+		Pattern: func() error {
+			_, err := (&S3{}).S3SyncUp("some_dir", "some_bucket", "", WithInclude("["))
+			return err
+		},
+		Concurrency: func() error {
+			_, err := (&S3{}).S3SyncUp("some_dir", "some_bucket", "", WithSyncConcurrency(0))
+			return err
+		},
+		PartSize: func() error {
+			_, err := (&S3{}).S3SyncUp("some_dir", "some_bucket", "", WithSyncUploadOptions(WithPartSize(1)))
+			return err
+		},
+		Offset: func() error {
+			_, err := (&S3{}).S3SyncDown("some_bucket", "", "some_dir", WithSyncDownloadOptions(WithRange(1, 0)))
+			return err
+		},
+	} {
+
+		err := f()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), param)
+
+	}
+
+	_, err := (&S3{}).S3SyncUp(file, "some_bucket", "")
+
+	assert.True(t, errors.Is(err, intErr.NewValidationError(ErrInvalidParameter, Dir)))
+
+	_, err = (&S3{}).S3SyncUp(filepath.Join(file, "some_missing_dir"), "some_bucket", "")
+
+	assert.Error(t, err)
+
+}
+
+// writeSyncFile writes body to name in dir, creating its parent directories, and sets its modification time
+func writeSyncFile(t *testing.T, dir, name, body string, modTime time.Time) {
+
+	t.Helper()
+
+	path := filepath.Join(dir, filepath.FromSlash(name))
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, ioutil.WriteFile(path, []byte(body), 0600))
+	assert.NoError(t, os.Chtimes(path, modTime, modTime))
+
+}
+
+// syncActions returns the actions of the files of report
+func syncActions(report *SyncReport) []SyncAction {
+
+	actions := make([]SyncAction, 0, len(report.Files))
+	for _, f := range report.Files {
+		actions = append(actions, f.Action)
+	}
+
+	return actions
+
+}
@@ -0,0 +1,112 @@
+package s3
+
+import (
+	"context"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+// MaxTags is the largest number of tags an object can have
+const MaxTags = 10
+
+// S3GetObjectTags returns the tag set of objectName in bucketName, empty when it has no tags
+func (svc *S3) S3GetObjectTags(bucketName, objectName string) (map[string]string, error) {
+	return svc.S3GetObjectTagsWithContext(context.Background(), bucketName, objectName)
+}
+
+// S3GetObjectTagsWithContext is the same as S3GetObjectTags with the addition of a context.Context
+func (svc *S3) S3GetObjectTagsWithContext(ctx context.Context, bucketName, objectName string) (map[string]string, error) {
+
+	if err := validateObject(bucketName, objectName); err != nil {
+		return nil, err
+	}
+
+	in := &s3.GetObjectTaggingInput{}
+	in = in.SetBucket(bucketName)
+	in = in.SetKey(objectName)
+
+	out, err := svc.S3.GetObjectTaggingWithContext(ctx, in)
+	if err != nil {
+		return nil, intErr.Wrap(err)
+	}
+
+	tags := make(map[string]string, len(out.TagSet))
+	for _, t := range out.TagSet {
+		tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+
+	return tags, nil
+
+}
+
+// S3PutObjectTags replaces the whole tag set of objectName in bucketName with tags,
+// which has at most MaxTags tags. An empty map removes every tag
+func (svc *S3) S3PutObjectTags(bucketName, objectName string, tags map[string]string) error {
+	return svc.S3PutObjectTagsWithContext(context.Background(), bucketName, objectName, tags)
+}
+
+// S3PutObjectTagsWithContext is the same as S3PutObjectTags with the addition of a context.Context
+func (svc *S3) S3PutObjectTagsWithContext(ctx context.Context, bucketName, objectName string, tags map[string]string) error {
+
+	if err := validateObject(bucketName, objectName); err != nil {
+		return err
+	}
+	if len(tags) > MaxTags {
+		return intErr.NewValidationError(ErrInvalidParameter, Tags)
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		if k == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, Tags)
+		}
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	tagSet := make([]*s3.Tag, 0, len(keys))
+	for _, k := range keys {
+		tagSet = append(tagSet, (&s3.Tag{}).SetKey(k).SetValue(tags[k]))
+	}
+
+	in := &s3.PutObjectTaggingInput{}
+	in = in.SetBucket(bucketName)
+	in = in.SetKey(objectName)
+	in = in.SetTagging((&s3.Tagging{}).SetTagSet(tagSet))
+
+	if _, err := svc.S3.PutObjectTaggingWithContext(ctx, in); err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
+
+// S3DeleteObjectTags removes every tag of objectName in bucketName
+func (svc *S3) S3DeleteObjectTags(bucketName, objectName string) error {
+	return svc.S3DeleteObjectTagsWithContext(context.Background(), bucketName, objectName)
+}
+
+// S3DeleteObjectTagsWithContext is the same as S3DeleteObjectTags with the addition of a context.Context
+func (svc *S3) S3DeleteObjectTagsWithContext(ctx context.Context, bucketName, objectName string) error {
+
+	if err := validateObject(bucketName, objectName); err != nil {
+		return err
+	}
+
+	in := &s3.DeleteObjectTaggingInput{}
+	in = in.SetBucket(bucketName)
+	in = in.SetKey(objectName)
+
+	if _, err := svc.S3.DeleteObjectTaggingWithContext(ctx, in); err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
@@ -0,0 +1,89 @@
+package s3
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestS3_S3ObjectTags(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+	putTaggedObject(t, s3Svc, cfg.S3.Bucket, "some/key", []byte("some_body"))
+
+	tags, err := s3Svc.S3GetObjectTags(cfg.S3.Bucket, "some/key")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"some_tag": "some value"}, tags)
+
+	// the whole tag set is replaced
+	assert.NoError(t, s3Svc.S3PutObjectTags(cfg.S3.Bucket, "some/key", map[string]string{"other_tag": "other value", "empty_tag": ""}))
+
+	tags, err = s3Svc.S3GetObjectTags(cfg.S3.Bucket, "some/key")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"other_tag": "other value", "empty_tag": ""}, tags)
+
+	assert.NoError(t, s3Svc.S3DeleteObjectTags(cfg.S3.Bucket, "some/key"))
+
+	tags, err = s3Svc.S3GetObjectTags(cfg.S3.Bucket, "some/key")
+
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	assert.NoError(t, s3Svc.S3PutObjectTags(cfg.S3.Bucket, "some/key", map[string]string{"some_tag": "some value"}))
+	assert.NoError(t, s3Svc.S3PutObjectTags(cfg.S3.Bucket, "some/key", nil))
+
+	tags, _ = srv.Tags(cfg.S3.Bucket, "some/key")
+
+	assert.Empty(t, tags)
+
+	err = s3Svc.S3PutObjectTags(cfg.S3.Bucket, "some_missing_key", map[string]string{"some_tag": "some value"})
+
+	assert.Error(t, err)
+	assert.Equal(t, "NoSuchKey", err.(awserr.Error).Code())
+
+}
+
+func TestS3_S3ObjectTags_Validation(t *testing.T) {
+
+	s3Svc := &S3{}
+
+	tooMany := make(map[string]string, MaxTags+1)
+	for i := 0; i <= MaxTags; i++ {
+		tooMany[fmt.Sprintf("tag_%d", i)] = "some_value"
+	}
+
+	for param, f := range map[string]func() error{
+		BucketName: func() error {
+			_, err := s3Svc.S3GetObjectTags("", "some/key")
+			return err
+		},
+		ObjectName: func() error {
+			return s3Svc.S3DeleteObjectTags("some_bucket", "")
+		},
+		Tags: func() error {
+			return s3Svc.S3PutObjectTags("some_bucket", "some/key", map[string]string{"": "some_value"})
+		},
+		ErrInvalidParameter: func() error {
+			return s3Svc.S3PutObjectTags("some_bucket", "some/key", tooMany)
+		},
+	} {
+
+		err := f()
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), param)
+
+	}
+
+}
@@ -0,0 +1,240 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/contenttype"
+)
+
+// MinPartSize is the minimum size of every part of a multipart upload but the last one
+const MinPartSize = 5 * 1024 * 1024
+
+// UploadInput contains the optional parameters of S3Upload, S3UploadFile and S3UploadReaderAt
+type UploadInput struct {
+	contentType          string
+	cacheControl         string
+	contentDisposition   string
+	contentEncoding      string
+	contentLanguage      string
+	metadata             map[string]string
+	tags                 map[string]string
+	partSize             int64
+	partSizeSet          bool
+	concurrency          int
+	partRetries          int
+	stateStore           StateStore
+	encryption           *Encryption
+	copySourceEncryption *Encryption
+}
+
+// UploadOption sets an optional parameter on an *UploadInput
+type UploadOption func(*UploadInput) error
+
+// WithContentType sets the content type of the uploaded object.
+// When not set, it is resolved from the extension of the object name, or of the
+// uploaded file, and otherwise detected from the first bytes of the body
+func WithContentType(contentType string) UploadOption {
+	return func(in *UploadInput) error {
+
+		if contentType == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, ContentType)
+		}
+
+		in.contentType = contentType
+
+		return nil
+
+	}
+}
+
+// WithCacheControl sets the Cache-Control header of the uploaded object
+func WithCacheControl(cacheControl string) UploadOption {
+	return func(in *UploadInput) error {
+
+		if cacheControl == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, CacheControl)
+		}
+
+		in.cacheControl = cacheControl
+
+		return nil
+
+	}
+}
+
+// WithContentDisposition sets the Content-Disposition header of the uploaded object
+func WithContentDisposition(contentDisposition string) UploadOption {
+	return func(in *UploadInput) error {
+
+		if contentDisposition == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, ContentDisposition)
+		}
+
+		in.contentDisposition = contentDisposition
+
+		return nil
+
+	}
+}
+
+// WithMetadata adds metadata to the user metadata of the uploaded object.
+// It can be passed more than once
+func WithMetadata(metadata map[string]string) UploadOption {
+	return func(in *UploadInput) error {
+
+		if in.metadata == nil {
+			in.metadata = make(map[string]string, len(metadata))
+		}
+
+		for k, v := range metadata {
+			if k == "" {
+				return intErr.NewValidationError(ErrEmptyParameter, Metadata)
+			}
+			in.metadata[k] = v
+		}
+
+		return nil
+
+	}
+}
+
+// WithEncryption encrypts the uploaded object as set by opts. Resumed uploads
+// encrypted with WithSSEC need the same key as when they were started
+func WithEncryption(opts ...EncryptionOption) UploadOption {
+	return func(in *UploadInput) error {
+
+		enc, err := newEncryption(opts...)
+		if err != nil {
+			return err
+		}
+
+		in.encryption = enc
+
+		return nil
+
+	}
+}
+
+// S3Upload streams body to objectName in bucketName. body can be of unknown length:
+// it is read one part at a time and sent with a multipart upload when it does not fit
+// a single part, so that it is never buffered as a whole. Streamed uploads cannot be
+// resumed and are aborted on failure
+func (svc *S3) S3Upload(bucketName, objectName string, body io.Reader, opts ...UploadOption) error {
+	return svc.S3UploadWithContext(context.Background(), bucketName, objectName, body, opts...)
+}
+
+// S3UploadWithContext is the same as S3Upload with the addition of a context.Context
+func (svc *S3) S3UploadWithContext(ctx context.Context, bucketName, objectName string, body io.Reader, opts ...UploadOption) error {
+
+	if body == nil {
+		return intErr.NewValidationError(ErrEmptyParameter, Body)
+	}
+
+	in, err := newUploadInput(bucketName, objectName, opts...)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, in.partSize)
+
+	n, err := io.ReadFull(body, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return err
+	}
+
+	if in.contentType == "" && n > 0 {
+		in.contentType = contenttype.Resolve("", buf[:n], objectName)
+	}
+
+	if err != nil {
+		return svc.putObject(ctx, in, bucketName, objectName, bytes.NewReader(buf[:n]), int64(n))
+	}
+
+	u := &multipartUpload{
+		svc: svc,
+		in:  in,
+		state: &UploadState{
+			Bucket:   bucketName,
+			Key:      objectName,
+			PartSize: in.partSize,
+		},
+	}
+
+	if err := u.create(ctx); err != nil {
+		return err
+	}
+
+	return u.upload(ctx, streamParts(in, buf, body))
+
+}
+
+// newUploadInput validates the parameters shared by every upload and returns a new *UploadInput
+func newUploadInput(bucketName, objectName string, opts ...UploadOption) (*UploadInput, error) {
+
+	if bucketName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
+	}
+	if objectName == "" {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, ObjectName)
+	}
+
+	return applyUploadOptions(opts...)
+
+}
+
+// applyUploadOptions returns a new *UploadInput with its defaults, set by opts
+func applyUploadOptions(opts ...UploadOption) (*UploadInput, error) {
+
+	in := &UploadInput{
+		partSize:    MinPartSize,
+		concurrency: DefaultConcurrency,
+		partRetries: DefaultPartRetries,
+	}
+
+	for _, opt := range opts {
+		if err := opt(in); err != nil {
+			return nil, err
+		}
+	}
+
+	return in, nil
+
+}
+
+// putObject uploads body with a single PutObject
+func (svc *S3) putObject(ctx context.Context, in *UploadInput, bucketName, objectName string, body io.ReadSeeker, size int64) error {
+
+	out := &s3.PutObjectInput{}
+	out = out.SetBucket(bucketName)
+	out = out.SetKey(objectName)
+	out = out.SetBody(body)
+	out = out.SetContentLength(size)
+
+	if in.contentType != "" {
+		out = out.SetContentType(in.contentType)
+	}
+	if in.cacheControl != "" {
+		out = out.SetCacheControl(in.cacheControl)
+	}
+	if in.contentDisposition != "" {
+		out = out.SetContentDisposition(in.contentDisposition)
+	}
+	if len(in.metadata) > 0 {
+		out = out.SetMetadata(aws.StringMap(in.metadata))
+	}
+
+	in.encryption.putObject(out)
+
+	if _, err := svc.S3.PutObjectWithContext(ctx, out, in.encryption.requestOptions()...); err != nil {
+		return intErr.Wrap(err)
+	}
+
+	return nil
+
+}
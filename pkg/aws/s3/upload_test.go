@@ -0,0 +1,183 @@
+package s3
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+)
+
+func TestS3_S3Upload(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	err := s3Svc.S3Upload(
+		cfg.S3.Bucket,
+		"some/key",
+		strings.NewReader("some_body"),
+		WithContentType("text/csv"),
+		WithCacheControl("max-age=60"),
+		WithContentDisposition(`attachment; filename="some.csv"`),
+		WithMetadata(map[string]string{"Some-Meta": "some_value"}),
+	)
+
+	assert.NoError(t, err)
+
+	out, err := s3Svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(cfg.S3.Bucket),
+		Key:    aws.String("some/key"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "text/csv", *out.ContentType)
+	assert.Equal(t, "max-age=60", *out.CacheControl)
+	assert.Equal(t, `attachment; filename="some.csv"`, *out.ContentDisposition)
+	assert.Equal(t, "some_value", *out.Metadata["Some-Meta"])
+
+	err = s3Svc.S3Upload(cfg.S3.Bucket, "some/page", strings.NewReader("<html><body></body></html>"))
+
+	assert.NoError(t, err)
+
+	out, err = s3Svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(cfg.S3.Bucket),
+		Key:    aws.String("some/page"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "text/html; charset=utf-8", *out.ContentType)
+
+	err = s3Svc.S3Upload(cfg.S3.Bucket, "some/data.csv", strings.NewReader("some_body"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "text/csv", *headObject(t, s3Svc, cfg.S3.Bucket, "some/data.csv").ContentType)
+
+	err = s3Svc.S3Upload(cfg.S3.Bucket, "some/image", strings.NewReader("RIFF\x24\x00\x00\x00WEBPVP8 "))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "image/webp", *headObject(t, s3Svc, cfg.S3.Bucket, "some/image").ContentType)
+
+	err = s3Svc.S3Upload(cfg.S3.Bucket, "some/empty", strings.NewReader(""))
+
+	assert.NoError(t, err)
+
+	stored, ok := srv.Object(cfg.S3.Bucket, "some/empty")
+
+	assert.True(t, ok)
+	assert.Empty(t, stored)
+
+}
+
+func TestS3_S3Upload_Multipart(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	body := bytes.Repeat([]byte("some_body"), 2*MinPartSize/9+10)
+
+	err := s3Svc.S3Upload(
+		cfg.S3.Bucket,
+		"some/key",
+		io.MultiReader(bytes.NewReader(body)),
+		WithMetadata(map[string]string{"Some-Meta": "some_value"}),
+	)
+
+	assert.NoError(t, err)
+
+	stored, ok := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.True(t, ok)
+	assert.Equal(t, body, stored)
+
+	out, err := s3Svc.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(cfg.S3.Bucket),
+		Key:    aws.String("some/key"),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(*out.ETag, `-3"`))
+	assert.Equal(t, "some_value", *out.Metadata["Some-Meta"])
+	assert.Empty(t, srv.Uploads(cfg.S3.Bucket))
+
+}
+
+func TestS3_S3Upload_Abort(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	s3Svc, cfg := newS3Svc(t, srv)
+
+	createBucket(cfg, s3Svc, t)
+
+	body := io.MultiReader(
+		bytes.NewReader(make([]byte, MinPartSize+1)),
+		&failingReader{err: errors.New("some_error")},
+	)
+
+	err := s3Svc.S3Upload(cfg.S3.Bucket, "some/key", body)
+
+	assert.Error(t, err)
+	assert.Equal(t, "some_error", err.Error())
+	assert.Empty(t, srv.Uploads(cfg.S3.Bucket))
+
+	_, ok := srv.Object(cfg.S3.Bucket, "some/key")
+
+	assert.False(t, ok)
+
+}
+
+func TestS3_S3Upload_Validation(t *testing.T) {
+
+	s3Svc := &S3{}
+
+	err := s3Svc.S3Upload("", "some/key", strings.NewReader("some_body"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), BucketName)
+
+	err = s3Svc.S3Upload("some_bucket", "", strings.NewReader("some_body"))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ObjectName)
+
+	err = s3Svc.S3Upload("some_bucket", "some/key", nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), Body)
+
+	err = s3Svc.S3Upload("some_bucket", "some/key", strings.NewReader("some_body"), WithContentType(""))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ContentType)
+
+	err = s3Svc.S3Upload("some_bucket", "some/key", strings.NewReader("some_body"), WithMetadata(map[string]string{"": "some_value"}))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), Metadata)
+
+}
+
+// failingReader is an io.Reader always failing with err
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
@@ -4,12 +4,12 @@ import (
 	"bytes"
 	"io"
 	"io/ioutil"
-	"net/http"
 	"os"
 
 	"github.com/aws/aws-sdk-go/service/s3"
 
 	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/contenttype"
 )
 
 // ReadImageOutput embeds the result of opening an image and getting its metadata
@@ -40,37 +40,33 @@ func (img *ReadImageOutput) SetContentSize(contentSize int64) *ReadImageOutput {
 	return img
 }
 
-// UnmarshalGetObjectOutput extracts bytes from *s3.GetObjectOutput
+// UnmarshalGetObjectOutput extracts bytes from *s3.GetObjectOutput.
+// Empty objects are returned as an empty slice
 func UnmarshalGetObjectOutput(input *s3.GetObjectOutput) ([]byte, error) {
 
-	if *input.ContentLength == 0 {
-		return nil, intErr.Format(InputContentLength, ErrEmptyContentLength)
+	if input == nil {
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Input)
 	}
 
-	body, err := ioutil.ReadAll(input.Body)
-	if err != nil {
-		return nil, err
-	}
-	if len(body) == 0 {
-		return nil, intErr.Format(Body, ErrEmptyBody)
-	}
-
-	input.Body = ioutil.NopCloser(bytes.NewReader(body))
-
-	b, err := UnmarshalIOReadCloser(input.Body)
-	if err != nil {
-		return nil, err
+	if input.Body == nil {
+		return []byte{}, nil
 	}
 
-	return b, nil
+	return ioutil.ReadAll(input.Body)
 
 }
 
-// ReadImage reads an image given its path and returns a *ReadImageOutput containing its body and metadata
+// ReadImage reads an image given its path and returns a *ReadImageOutput containing its body and metadata.
+// Its content type is resolved from the extension of path, or detected from its body
 func ReadImage(path string) (*ReadImageOutput, error) {
+	return readImage(path, "")
+}
+
+// readImage is the same as ReadImage, with contentType used as is when not empty
+func readImage(path, contentType string) (*ReadImageOutput, error) {
 
 	if path == "" {
-		return nil, intErr.Format(Path, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Path)
 	}
 
 	file, err := os.Open(path)
@@ -88,8 +84,11 @@ func ReadImage(path string) (*ReadImageOutput, error) {
 	contentSize := fileInfo.Size()
 	buffer := make([]byte, contentSize)
 
-	file.Read(buffer)
-	contentType := http.DetectContentType(buffer)
+	if _, err := io.ReadFull(file, buffer); err != nil {
+		return nil, err
+	}
+
+	contentType = contenttype.Resolve(contentType, buffer, path)
 
 	out := &ReadImageOutput{}
 	out = out.SetBody(buffer)
@@ -104,7 +103,7 @@ func ReadImage(path string) (*ReadImageOutput, error) {
 func NewCreateBucketInput(bucketName string) (*s3.CreateBucketInput, error) {
 
 	if bucketName == "" {
-		return nil, intErr.Format(BucketName, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
 	}
 
 	out := &s3.CreateBucketInput{}
@@ -114,38 +113,53 @@ func NewCreateBucketInput(bucketName string) (*s3.CreateBucketInput, error) {
 
 }
 
-// NewGetObjectInput returns a new *s3.GetObjectInput given a bucket and a source image
-func NewGetObjectInput(bucketName, source string) (*s3.GetObjectInput, error) {
+// NewGetObjectInput returns a new *s3.GetObjectInput given a bucket and a source image.
+// Objects encrypted with SSE-C need the WithSSEC key they were written with
+func NewGetObjectInput(bucketName, source string, opts ...EncryptionOption) (*s3.GetObjectInput, error) {
 
 	if bucketName == "" {
-		return nil, intErr.Format(BucketName, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
 	}
 	if source == "" {
-		return nil, intErr.Format(Source, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Source)
+	}
+
+	enc, err := newReadEncryption(opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	out := &s3.GetObjectInput{}
 	out = out.SetBucket(bucketName)
 	out = out.SetKey(source)
 
+	enc.getObject(out)
+
 	return out, nil
 
 }
 
-// NewPutObjectInput returns a new *s3.PutObjectInput
-func NewPutObjectInput(bucketName, fileName, contentType string, image []byte, size int64) (*s3.PutObjectInput, error) {
+// NewPutObjectInput returns a new *s3.PutObjectInput, encrypted as set by opts.
+// *s3.PutObjectInput has no field for WithKMSEncryptionContext and WithBucketKey,
+// which are only sent by the helpers of *S3
+func NewPutObjectInput(bucketName, fileName, contentType string, image []byte, size int64, opts ...EncryptionOption) (*s3.PutObjectInput, error) {
 
 	if bucketName == "" {
-		return nil, intErr.Format(BucketName, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, BucketName)
 	}
 	if fileName == "" {
-		return nil, intErr.Format(FileName, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, FileName)
 	}
 	if contentType == "" {
-		return nil, intErr.Format(ContentType, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, ContentType)
 	}
 	if len(image) == 0 {
-		return nil, intErr.Format(Image, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Image)
+	}
+
+	enc, err := newEncryption(opts...)
+	if err != nil {
+		return nil, err
 	}
 
 	out := &s3.PutObjectInput{}
@@ -155,6 +169,8 @@ func NewPutObjectInput(bucketName, fileName, contentType string, image []byte, s
 	out = out.SetBody(bytes.NewReader(image))
 	out = out.SetContentLength(size)
 
+	enc.putObject(out)
+
 	return out, nil
 
 }
@@ -3,6 +3,7 @@ package s3
 import (
 	"bytes"
 	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -58,27 +59,28 @@ func TestUnmarshalGetObjectOutput(t *testing.T) {
 	out, err := UnmarshalGetObjectOutput(getObjectOutputMock)
 
 	assert.NoError(t, err)
-	assert.NotEmpty(t, out)
+	assert.Equal(t, body, out)
 
-	_, err = UnmarshalGetObjectOutput(
+	out, err = UnmarshalGetObjectOutput(
 		&s3.GetObjectOutput{
-			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			Body:          ioutil.NopCloser(bytes.NewReader([]byte{})),
 			ContentLength: aws.Int64(0),
 		},
 	)
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), ErrEmptyContentLength)
+	assert.NoError(t, err)
+	assert.NotNil(t, out)
+	assert.Empty(t, out)
 
-	_, err = UnmarshalGetObjectOutput(
-		&s3.GetObjectOutput{
-			Body:          ioutil.NopCloser(bytes.NewReader([]byte{})),
-			ContentLength: aws.Int64(40),
-		},
-	)
+	out, err = UnmarshalGetObjectOutput(&s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(body))})
+
+	assert.NoError(t, err)
+	assert.Equal(t, body, out)
+
+	_, err = UnmarshalGetObjectOutput(nil)
 
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), ErrEmptyBody)
+	assert.Contains(t, err.Error(), ErrEmptyParameter)
 
 }
 
@@ -90,6 +92,16 @@ func TestReadImage(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, out)
+	assert.Equal(t, "image/jpeg", out.ContentType)
+
+	svgPath := filepath.Join(t.TempDir(), "some.svg")
+
+	assert.NoError(t, ioutil.WriteFile(svgPath, []byte(`<?xml version="1.0"?><svg></svg>`), 0600))
+
+	out, err = ReadImage(svgPath)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "image/svg+xml", out.ContentType)
 
 }
 
@@ -124,6 +136,18 @@ func TestNewGetObjectInput(t *testing.T) {
 	_, err = NewGetObjectInput(cfg.S3.Bucket, "")
 	assert.Contains(t, err.Error(), ErrEmptyParameter)
 
+	key := bytes.Repeat([]byte("k"), CustomerKeySize)
+
+	out, err = NewGetObjectInput(cfg.S3.Bucket, cfg.S3.SourceImage, WithSSEC(key))
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(key), *out.SSECustomerKey)
+	assert.Equal(t, "AES256", *out.SSECustomerAlgorithm)
+	assert.NotEmpty(t, *out.SSECustomerKeyMD5)
+
+	_, err = NewGetObjectInput(cfg.S3.Bucket, cfg.S3.SourceImage, WithSSEKMS(""))
+	assert.Contains(t, err.Error(), ServerSideEncryption)
+
 }
 
 func TestNewPutObjectInput(t *testing.T) {
@@ -153,6 +177,15 @@ func TestNewPutObjectInput(t *testing.T) {
 	_, err = NewPutObjectInput(cfg.S3.Bucket, cfg.S3.SourceImage, contentType, []byte(""), contentSize)
 	assert.Contains(t, err.Error(), ErrEmptyParameter)
 
+	putObjectInput, err = NewPutObjectInput(cfg.S3.Bucket, cfg.S3.SourceImage, contentType, []byte(cfg.S3.SourceImage), contentSize, WithSSEKMS("some_key"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, s3.ServerSideEncryptionAwsKms, *putObjectInput.ServerSideEncryption)
+	assert.Equal(t, "some_key", *putObjectInput.SSEKMSKeyId)
+
+	_, err = NewPutObjectInput(cfg.S3.Bucket, cfg.S3.SourceImage, contentType, []byte(cfg.S3.SourceImage), contentSize, WithSSES3(), WithSSEKMS(""))
+	assert.Contains(t, err.Error(), ServerSideEncryption)
+
 }
 
 func TestUnmarshalIOReadCloser(t *testing.T) {
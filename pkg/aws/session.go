@@ -1,10 +1,9 @@
 package aws
 
 import (
-	"errors"
-
-	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
 )
 
 // Session embeds session.Session
@@ -16,21 +15,29 @@ type Session struct {
 func New(input *SessionInput) (*Session, error) {
 
 	if input.region == "" {
-		return nil, errors.New(ErrNoRegionProvided)
+		return nil, intErr.NewValidationError(ErrNoRegionProvided, "")
 	}
 
-	cfg := &aws.Config{
-		Region: aws.String(input.region),
+	opts := newSessionOptions(input)
+
+	if input.localStack != nil {
+		input.localStack.apply(&opts.Config, input.credentialSources() == 0)
 	}
 
-	awsSession, err := session.NewSession(cfg)
+	awsSession, err := session.NewSessionWithOptions(opts)
 	if err != nil {
 		return nil, err
 	}
 
+	if input.retryPolicy != nil {
+		input.retryPolicy.apply(awsSession.Config)
+	}
+
 	svc := &Session{}
 
-	svc.Session = awsSession
+	svc.Session = withRoleCredentials(awsSession, input)
+
+	svc.Use(input.middlewares...)
 
 	return svc, nil
 
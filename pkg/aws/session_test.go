@@ -30,3 +30,27 @@ func TestNew(t *testing.T) {
 	assert.Equal(t, ErrNoRegionProvided, err.Error())
 
 }
+
+func TestNew_StaticCredentials(t *testing.T) {
+
+	cfg := testdata.MockConfiguration(t)
+
+	in, err := NewSessionInput(
+		cfg.Region,
+		WithStaticCredentials("some_key", "some_secret", "some_token"),
+	)
+
+	assert.NoError(t, err)
+
+	svc, err := New(in)
+
+	assert.NoError(t, err)
+
+	creds, err := svc.Config.Credentials.Get()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_key", creds.AccessKeyID)
+	assert.Equal(t, "some_secret", creds.SecretAccessKey)
+	assert.Equal(t, "some_token", creds.SessionToken)
+
+}
@@ -0,0 +1,14 @@
+package sns
+
+import (
+	"context"
+)
+
+// SNSAPI contains the helper methods of *SNS.
+// Depend on it instead of *SNS to swap the real client with a mock in unit tests
+type SNSAPI interface {
+	SnsPublish(input interface{}, messageAttributes map[string]interface{}, targetArn string) error
+	SnsPublishWithContext(ctx context.Context, input interface{}, messageAttributes map[string]interface{}, targetArn string) error
+}
+
+var _ SNSAPI = (*SNS)(nil)
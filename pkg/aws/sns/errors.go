@@ -10,4 +10,10 @@ const (
 
 	// ErrPointerParameterNotAllowed is used when a parameter is expected to be not a pointer but it wasn't
 	ErrPointerParameterNotAllowed = "PointerParameterNotAllowed"
+
+	// ErrUnsupportedType is used when a parameter has a type that cannot be sent to SNS
+	ErrUnsupportedType = "UnsupportedType"
+
+	// ErrInvalidParameter is used when a parameter is refused by the validation of aws-sdk-go
+	ErrInvalidParameter = "InvalidParameter"
 )
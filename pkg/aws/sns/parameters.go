@@ -10,4 +10,6 @@ const (
 	Input = "input"
 	// Message represents the parameter named message
 	Message = "message"
+	// MessageAttributes represents the parameter named messageAttributes
+	MessageAttributes = "messageAttributes"
 )
@@ -1,7 +1,18 @@
 package sns
 
+import (
+	"context"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
 // SnsPublish publishes an input on a given SNS targetArn
 func (svc *SNS) SnsPublish(input interface{}, messageAttributes map[string]interface{}, targetArn string) (err error) {
+	return svc.SnsPublishWithContext(context.Background(), input, messageAttributes, targetArn)
+}
+
+// SnsPublishWithContext is the same as SnsPublish with the addition of a context.Context
+func (svc *SNS) SnsPublishWithContext(ctx context.Context, input interface{}, messageAttributes map[string]interface{}, targetArn string) (err error) {
 
 	in, err := NewPublishInput(
 		input,
@@ -12,9 +23,9 @@ func (svc *SNS) SnsPublish(input interface{}, messageAttributes map[string]inter
 		return err
 	}
 
-	_, err = svc.SNS.Publish(in)
+	_, err = svc.SNS.PublishWithContext(ctx, in)
 	if err != nil {
-		return err
+		return intErr.Wrap(err)
 	}
 
 	return nil
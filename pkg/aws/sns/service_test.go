@@ -1,11 +1,15 @@
 package sns
 
 import (
+	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/stretchr/testify/assert"
 
 	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
 	"github.com/easynetwork/aws-sdk-go-bindings/testdata"
 )
 
@@ -19,7 +23,10 @@ func TestSession_SnsPublish(t *testing.T) {
 
 	cfg := testdata.MockConfiguration(t)
 
-	svcIn, err := pkgAws.NewSessionInput(cfg.Region)
+	srv := fake.New()
+	defer srv.Close()
+
+	svcIn, err := pkgAws.NewSessionInput(cfg.Region, pkgAws.WithStaticCredentials(fake.AccessKeyID, fake.SecretAccessKey, ""))
 
 	assert.NoError(t, err)
 	awsSvc, err := pkgAws.New(svcIn)
@@ -27,7 +34,7 @@ func TestSession_SnsPublish(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, awsSvc)
 
-	snsSvc, err := New(awsSvc, cfg.SNS.Endpoint)
+	snsSvc, err := New(awsSvc, srv.URL)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, snsSvc)
@@ -40,6 +47,12 @@ func TestSession_SnsPublish(t *testing.T) {
 
 	assert.NoError(t, err)
 
+	publications := srv.Publications()
+
+	assert.Len(t, publications, 1)
+	assert.Equal(t, cfg.SNS.TargetArn, publications[0].TargetArn)
+	assert.Equal(t, "message_value", publications[0].MessageAttributes["message_attribute"].StringValue)
+
 	err = snsSvc.SnsPublish(
 		body,
 		nil,
@@ -59,3 +72,34 @@ func TestSession_SnsPublish(t *testing.T) {
 	assert.Contains(t, err.Error(), ErrPointerParameterNotAllowed)
 
 }
+
+func TestSession_SnsPublishWithContext(t *testing.T) {
+
+	cfg := testdata.MockConfiguration(t)
+
+	svcIn, err := pkgAws.NewSessionInput(cfg.Region, pkgAws.WithStaticCredentials("some_key", "some_secret", ""))
+
+	assert.NoError(t, err)
+
+	awsSvc, err := pkgAws.New(svcIn)
+
+	assert.NoError(t, err)
+
+	snsSvc, err := New(awsSvc, cfg.SNS.Endpoint)
+
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = snsSvc.SnsPublishWithContext(
+		ctx,
+		`{"default":"{\"par1\":\"pr1\"}"}`,
+		nil,
+		cfg.SNS.TargetArn,
+	)
+
+	assert.Error(t, err)
+	assert.Equal(t, request.CanceledErrorCode, err.(awserr.Error).Code())
+
+}
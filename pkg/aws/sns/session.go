@@ -1,8 +1,6 @@
 package sns
 
 import (
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sns"
 
 	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
@@ -13,17 +11,15 @@ type SNS struct {
 	*sns.SNS
 }
 
-// New returns a new *SNS embedding *sns.SNS
-func New(svc *pkgAws.Session, endpoint string) (*SNS, error) {
+// New returns a new *SNS embedding *sns.SNS.
+// endpoint and opts only apply to the returned client, svc is left untouched
+func New(svc *pkgAws.Session, endpoint string, opts ...pkgAws.ClientOption) (*SNS, error) {
 
 	if endpoint != "" {
-		svc.Config.Endpoint = aws.String(endpoint)
+		opts = append([]pkgAws.ClientOption{pkgAws.WithEndpoint(endpoint)}, opts...)
 	}
 
-	newSvc, err := session.NewSession(svc.Config)
-	if err != nil {
-		return nil, err
-	}
+	newSvc := svc.ServiceSession(opts...)
 
 	snsSvc := &SNS{
 		SNS: sns.New(newSvc),
@@ -2,7 +2,6 @@ package sns
 
 import (
 	"encoding/json"
-	"fmt"
 	"reflect"
 	"strings"
 
@@ -28,11 +27,11 @@ type Body struct {
 func NewPublishInput(input interface{}, messageAttributes map[string]interface{}, endpoint string) (*sns.PublishInput, error) {
 
 	if endpoint == "" {
-		return nil, intErr.Format(Endpoint, ErrEmptyParameter)
+		return nil, intErr.NewValidationError(ErrEmptyParameter, Endpoint)
 	}
 
 	if reflect.ValueOf(input).Kind() == reflect.Ptr {
-		return nil, intErr.Format(Input, ErrPointerParameterNotAllowed)
+		return nil, intErr.NewValidationError(ErrPointerParameterNotAllowed, Input)
 	}
 
 	inBytes, err := json.Marshal(input)
@@ -78,11 +77,11 @@ func NewPublishInput(input interface{}, messageAttributes map[string]interface{}
 func UnmarshalMessage(message string, input interface{}) error {
 
 	if message == "" {
-		return intErr.Format(Message, ErrEmptyParameter)
+		return intErr.NewValidationError(ErrEmptyParameter, Message)
 	}
 
 	if reflect.ValueOf(input).Kind() != reflect.Ptr {
-		return intErr.Format(Input, ErrNoPointerParameter)
+		return intErr.NewValidationError(ErrNoPointerParameter, Input)
 	}
 
 	uS := unescapeMessageString(message)
@@ -133,11 +132,11 @@ func getAsSNSMessageAttributes(messageAttributes map[string]interface{}) (map[st
 				SetBinaryValue(vBytes).
 				SetDataType(messageAttributesBinary)
 		default:
-			return nil, fmt.Errorf("%v is not a supported type", t)
+			return nil, intErr.NewValidationError(ErrUnsupportedType, MessageAttributes)
 		}
 		// Checking message attributes validity
 		if err := output[k].Validate(); err != nil {
-			return nil, err
+			return nil, intErr.NewValidationError(ErrInvalidParameter, MessageAttributes)
 		}
 	}
 
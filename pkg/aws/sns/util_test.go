@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
 )
 
 type TestBuildPublishInputType struct {
@@ -49,6 +51,10 @@ func TestBuildPublishInput(t *testing.T) {
 	assert.Error(t, errEmptyParameter)
 	assert.Contains(t, errEmptyParameter.Error(), ErrEmptyParameter)
 
+	_, err = NewPublishInput(testB, map[string]interface{}{"message_attribute": nil}, "edp")
+
+	assert.Equal(t, &intErr.ValidationError{Code: ErrUnsupportedType, Parameter: MessageAttributes}, err)
+
 }
 
 func TestUnmarshalMessage(t *testing.T) {
@@ -0,0 +1,22 @@
+package sqs
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+// SQSAPI contains the helper methods of *SQS.
+// Depend on it instead of *SQS to swap the real client with a mock in unit tests
+type SQSAPI interface {
+	SQSCreateQueue(queue string) error
+	SQSCreateQueueWithContext(ctx context.Context, queue string) error
+	SQSGetQueueAttributes(queueUrl string) (*sqs.GetQueueAttributesOutput, error)
+	SQSGetQueueAttributesWithContext(ctx context.Context, queueUrl string) (*sqs.GetQueueAttributesOutput, error)
+	SQSSendMessage(input interface{}, queueName string, base64Encode bool) error
+	SQSSendMessageWithContext(ctx context.Context, input interface{}, queueName string, base64Encode bool) error
+	SQSGetQueueUrl(queueName string) (string, error)
+	SQSGetQueueUrlWithContext(ctx context.Context, queueName string) (string, error)
+}
+
+var _ SQSAPI = (*SQS)(nil)
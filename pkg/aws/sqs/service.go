@@ -1,7 +1,11 @@
 package sqs
 
 import (
+	"context"
+
 	"github.com/aws/aws-sdk-go/service/sqs"
+
+	intError "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
 )
 
 // GetQueueUrlInput embeds *sqs.GetQueueUrlInput
@@ -11,14 +15,19 @@ type GetQueueUrlInput struct {
 
 // SQSCreateQueue creates an sns queue given a queue name
 func (svc *SQS) SQSCreateQueue(queue string) error {
+	return svc.SQSCreateQueueWithContext(context.Background(), queue)
+}
+
+// SQSCreateQueueWithContext is the same as SQSCreateQueue with the addition of a context.Context
+func (svc *SQS) SQSCreateQueueWithContext(ctx context.Context, queue string) error {
 
 	input, err := NewCreateQueueInput(queue)
 	if err != nil {
 		return err
 	}
 
-	if _, err := svc.CreateQueue(input); err != nil {
-		return err
+	if _, err := svc.CreateQueueWithContext(ctx, input); err != nil {
+		return intError.Wrap(err)
 	}
 
 	return nil
@@ -27,15 +36,20 @@ func (svc *SQS) SQSCreateQueue(queue string) error {
 
 // SQSGetQueueAttributes returns error if queue does not exist, get queue attributes otherwise
 func (svc *SQS) SQSGetQueueAttributes(queueUrl string) (*sqs.GetQueueAttributesOutput, error) {
+	return svc.SQSGetQueueAttributesWithContext(context.Background(), queueUrl)
+}
+
+// SQSGetQueueAttributesWithContext is the same as SQSGetQueueAttributes with the addition of a context.Context
+func (svc *SQS) SQSGetQueueAttributesWithContext(ctx context.Context, queueUrl string) (*sqs.GetQueueAttributesOutput, error) {
 
 	input, err := NewGetQueueAttributesInput(queueUrl)
 	if err != nil {
 		return nil, err
 	}
 
-	out, err := svc.GetQueueAttributes(input)
+	out, err := svc.GetQueueAttributesWithContext(ctx, input)
 	if err != nil {
-		return nil, err
+		return nil, intError.Wrap(err)
 	}
 
 	return out, nil
@@ -44,8 +58,13 @@ func (svc *SQS) SQSGetQueueAttributes(queueUrl string) (*sqs.GetQueueAttributesO
 
 // SQSSendMessage sends a message on SQS
 func (svc *SQS) SQSSendMessage(input interface{}, queueName string, base64Encode bool) error {
+	return svc.SQSSendMessageWithContext(context.Background(), input, queueName, base64Encode)
+}
 
-	queueUrl, err := svc.SQSGetQueueUrl(queueName)
+// SQSSendMessageWithContext is the same as SQSSendMessage with the addition of a context.Context
+func (svc *SQS) SQSSendMessageWithContext(ctx context.Context, input interface{}, queueName string, base64Encode bool) error {
+
+	queueUrl, err := svc.SQSGetQueueUrlWithContext(ctx, queueName)
 	if err != nil {
 		return err
 	}
@@ -59,8 +78,8 @@ func (svc *SQS) SQSSendMessage(input interface{}, queueName string, base64Encode
 		return err
 	}
 
-	if _, err := svc.SendMessage(sendMsgInput); err != nil {
-		return err
+	if _, err := svc.SendMessageWithContext(ctx, sendMsgInput); err != nil {
+		return intError.Wrap(err)
 	}
 
 	return nil
@@ -69,15 +88,20 @@ func (svc *SQS) SQSSendMessage(input interface{}, queueName string, base64Encode
 
 // SQSGetQueueUrl gets a queue's url given its name
 func (svc *SQS) SQSGetQueueUrl(queueUrl string) (string, error) {
+	return svc.SQSGetQueueUrlWithContext(context.Background(), queueUrl)
+}
+
+// SQSGetQueueUrlWithContext is the same as SQSGetQueueUrl with the addition of a context.Context
+func (svc *SQS) SQSGetQueueUrlWithContext(ctx context.Context, queueUrl string) (string, error) {
 
 	input, err := NewGetQueueUrlInput(queueUrl)
 	if err != nil {
 		return "", err
 	}
 
-	out, err := svc.GetQueueUrl(input)
+	out, err := svc.GetQueueUrlWithContext(ctx, input)
 	if err != nil {
-		return "", err
+		return "", intError.Wrap(err)
 	}
 
 	return *out.QueueUrl, nil
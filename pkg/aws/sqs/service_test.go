@@ -1,11 +1,15 @@
 package sqs
 
 import (
+	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/stretchr/testify/assert"
 
 	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
 	"github.com/easynetwork/aws-sdk-go-bindings/testdata"
 )
 
@@ -23,7 +27,10 @@ func TestSQS_SQSCreateQueue(t *testing.T) {
 
 	cfg := testdata.MockConfiguration(t)
 
-	svc := newSQSSvc(t)
+	srv := fake.New()
+	defer srv.Close()
+
+	svc := newSQSSvc(t, srv)
 
 	createSQSQueue(t, svc, cfg.SQS.QueueName)
 
@@ -33,7 +40,10 @@ func TestSQS_SQSGetQueueAttributes(t *testing.T) {
 
 	cfg := testdata.MockConfiguration(t)
 
-	svc := newSQSSvc(t)
+	srv := fake.New()
+	defer srv.Close()
+
+	svc := newSQSSvc(t, srv)
 
 	createSQSQueue(t, svc, cfg.SQS.QueueName)
 
@@ -58,7 +68,10 @@ func TestSQS_SQSSendMessage(t *testing.T) {
 
 	cfg := testdata.MockConfiguration(t)
 
-	svc := newSQSSvc(t)
+	srv := fake.New()
+	defer srv.Close()
+
+	svc := newSQSSvc(t, srv)
 
 	createSQSQueue(t, svc, cfg.SQS.QueueName)
 
@@ -75,13 +88,26 @@ func TestSQS_SQSSendMessage(t *testing.T) {
 
 	assert.NoError(t, err)
 
+	messages := srv.Messages(cfg.SQS.QueueName)
+
+	assert.Len(t, messages, 1)
+	assert.NotEmpty(t, messages[0].Body)
+
+	err = svc.SQSSendMessage(m, "some_missing_queue", true)
+
+	assert.Error(t, err)
+	assert.Equal(t, "AWS.SimpleQueueService.NonExistentQueue", err.(awserr.Error).Code())
+
 }
 
 func TestSQS_SQSGetQueueUrl(t *testing.T) {
 
 	cfg := testdata.MockConfiguration(t)
 
-	svc := newSQSSvc(t)
+	srv := fake.New()
+	defer srv.Close()
+
+	svc := newSQSSvc(t, srv)
 
 	createSQSQueue(t, svc, cfg.SQS.QueueName)
 
@@ -89,7 +115,7 @@ func TestSQS_SQSGetQueueUrl(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, url)
-	assert.Equal(t, cfg.SQS.QueueUrl, url)
+	assert.Equal(t, srv.URL+"/"+fake.AccountID+"/"+cfg.SQS.QueueName, url)
 
 	_, err = svc.SQSGetQueueUrl("")
 
@@ -98,6 +124,40 @@ func TestSQS_SQSGetQueueUrl(t *testing.T) {
 
 }
 
+func TestSQS_SQSSendMessageWithContext(t *testing.T) {
+
+	cfg := testdata.MockConfiguration(t)
+
+	svcIn, err := pkgAws.NewSessionInput(cfg.Region, pkgAws.WithStaticCredentials("some_key", "some_secret", ""))
+
+	assert.NoError(t, err)
+
+	awsSvc, err := pkgAws.New(svcIn)
+
+	assert.NoError(t, err)
+
+	svc, err := New(awsSvc, cfg.SQS.Endpoint)
+
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = svc.SQSSendMessageWithContext(
+		ctx,
+		TestSQSUtilType{
+			SomeParam1: val1,
+			SomeParam2: val2,
+		},
+		cfg.SQS.QueueName,
+		true,
+	)
+
+	assert.Error(t, err)
+	assert.Equal(t, request.CanceledErrorCode, err.(awserr.Error).Code())
+
+}
+
 func createSQSQueue(t *testing.T, svc *SQS, queueName string) {
 
 	t.Helper()
@@ -113,13 +173,13 @@ func createSQSQueue(t *testing.T, svc *SQS, queueName string) {
 
 }
 
-func newSQSSvc(t *testing.T) *SQS {
+func newSQSSvc(t *testing.T, srv *fake.Server) *SQS {
 
 	t.Helper()
 
 	cfg := testdata.MockConfiguration(t)
 
-	svcIn, err := pkgAws.NewSessionInput(cfg.Region)
+	svcIn, err := pkgAws.NewSessionInput(cfg.Region, pkgAws.WithStaticCredentials(fake.AccessKeyID, fake.SecretAccessKey, ""))
 
 	assert.NoError(t, err)
 
@@ -128,7 +188,7 @@ func newSQSSvc(t *testing.T) *SQS {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, awsSvc)
 
-	sqsSvc, err := New(awsSvc, cfg.SQS.Endpoint)
+	sqsSvc, err := New(awsSvc, srv.URL)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, sqsSvc)
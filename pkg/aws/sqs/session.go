@@ -1,8 +1,6 @@
 package sqs
 
 import (
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sqs"
 
 	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
@@ -13,17 +11,15 @@ type SQS struct {
 	*sqs.SQS
 }
 
-// New returns a new *SQS
-func New(svc *pkgAws.Session, endpoint string) (*SQS, error) {
+// New returns a new *SQS.
+// endpoint and opts only apply to the returned client, svc is left untouched
+func New(svc *pkgAws.Session, endpoint string, opts ...pkgAws.ClientOption) (*SQS, error) {
 
 	if endpoint != "" {
-		svc.Config.Endpoint = aws.String(endpoint)
+		opts = append([]pkgAws.ClientOption{pkgAws.WithEndpoint(endpoint)}, opts...)
 	}
 
-	newSvc, err := session.NewSession(svc.Config)
-	if err != nil {
-		return nil, err
-	}
+	newSvc := svc.ServiceSession(opts...)
 
 	sqsSvc := &SQS{
 		SQS: sqs.New(newSvc),
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
 	"github.com/easynetwork/aws-sdk-go-bindings/testdata"
 )
 
@@ -26,5 +27,43 @@ func TestNew(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, snsSvc)
+	assert.Equal(t, cfg.SQS.Endpoint, *snsSvc.Config.Endpoint)
+	assert.Nil(t, awsSvc.Config.Endpoint)
+
+}
+
+func TestNew_Middleware(t *testing.T) {
+
+	cfg := testdata.MockConfiguration(t)
+
+	srv := fake.New()
+	defer srv.Close()
+
+	var calls []aws.Call
+
+	in, err := aws.NewSessionInput(
+		cfg.Region,
+		aws.WithStaticCredentials(fake.AccessKeyID, fake.SecretAccessKey, ""),
+		aws.WithMiddleware(aws.CompleteMiddleware(func(c aws.Call) {
+			calls = append(calls, c)
+		})),
+	)
+
+	assert.NoError(t, err)
+
+	awsSvc, err := aws.New(in)
+
+	assert.NoError(t, err)
+
+	sqsSvc, err := New(awsSvc, srv.URL)
+
+	assert.NoError(t, err)
+
+	err = sqsSvc.SQSCreateQueue(cfg.SQS.QueueName)
+
+	assert.NoError(t, err)
+	assert.Len(t, calls, 1)
+	assert.Equal(t, "CreateQueue", calls[0].Operation)
+	assert.NotEmpty(t, calls[0].RequestID)
 
 }
@@ -3,7 +3,6 @@ package sqs
 import (
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"reflect"
 
 	"github.com/aws/aws-sdk-go/service/sqs"
@@ -15,7 +14,7 @@ import (
 func NewCreateQueueInput(queueName string) (*sqs.CreateQueueInput, error) {
 
 	if queueName == "" {
-		return nil, intError.Format(QueueName, ErrEmptyParameter)
+		return nil, intError.NewValidationError(ErrEmptyParameter, QueueName)
 	}
 
 	out := &sqs.CreateQueueInput{}
@@ -29,7 +28,7 @@ func NewCreateQueueInput(queueName string) (*sqs.CreateQueueInput, error) {
 func NewGetQueueAttributesInput(queueUrl string) (*sqs.GetQueueAttributesInput, error) {
 
 	if queueUrl == "" {
-		return nil, intError.Format(QueueUrl, ErrEmptyParameter)
+		return nil, intError.NewValidationError(ErrEmptyParameter, QueueUrl)
 	}
 
 	out := &sqs.GetQueueAttributesInput{}
@@ -44,11 +43,11 @@ func NewGetQueueAttributesInput(queueUrl string) (*sqs.GetQueueAttributesInput,
 func NewSendMessageInput(input interface{}, queueUrl string, base64Encode bool) (*sqs.SendMessageInput, error) {
 
 	if reflect.DeepEqual(reflect.TypeOf(input).Kind(), reflect.Ptr) {
-		return nil, intError.Format(Input, ErrNoPointerParameterAllowed)
+		return nil, intError.NewValidationError(ErrNoPointerParameterAllowed, Input)
 	}
 
 	if queueUrl == "" {
-		return nil, intError.Format(QueueUrl, ErrEmptyParameter)
+		return nil, intError.NewValidationError(ErrEmptyParameter, QueueUrl)
 	}
 
 	out := &sqs.SendMessageInput{}
@@ -75,7 +74,7 @@ func NewSendMessageInput(input interface{}, queueUrl string, base64Encode bool)
 func NewGetQueueUrlInput(queueName string) (*sqs.GetQueueUrlInput, error) {
 
 	if queueName == "" {
-		return nil, intError.Format(QueueName, ErrEmptyParameter)
+		return nil, intError.NewValidationError(ErrEmptyParameter, QueueName)
 	}
 
 	out := &sqs.GetQueueUrlInput{}
@@ -89,7 +88,7 @@ func NewGetQueueUrlInput(queueName string) (*sqs.GetQueueUrlInput, error) {
 func marshalStructToJson(input interface{}) ([]byte, error) {
 
 	if reflect.DeepEqual(reflect.TypeOf(input).Kind(), reflect.Ptr) {
-		return nil, errors.New(ErrNoPointerParameterAllowed)
+		return nil, intError.NewValidationError(ErrNoPointerParameterAllowed, Input)
 	}
 
 	b, err := json.Marshal(input)
@@ -0,0 +1,20 @@
+// Package tracing creates OpenTelemetry spans around the helper calls of the bindings
+// and around every aws-sdk-go request attempt they make.
+//
+// A *Tracer is registered on the session as a middleware, so that every request attempt
+// gets its own span, and wraps the service helpers, so that every helper call gets a
+// parent span:
+//
+//	tracer := tracing.New()
+//
+//	in, err := aws.NewSessionInput(region, aws.WithMiddleware(tracer.Middleware()))
+//	...
+//	s3Svc, err := s3.New(awsSvc, endpoint)
+//	...
+//	var svc s3.S3API = tracer.S3(s3Svc)
+//
+// The middleware also injects the trace context into the message attributes of every
+// SQS SendMessage, SendMessageBatch and SNS Publish request, as long as the message stays
+// within the limit of 10 attributes. ExtractSQSMessage, ExtractSQSEvent and ExtractSNSEvent
+// extract it on the receiving side
+package tracing
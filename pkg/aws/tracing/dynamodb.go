@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/dynamodb"
+)
+
+// DynamoDB wraps a dynamodb.DynamoDBAPI creating a span for every helper call
+type DynamoDB struct {
+	next   dynamodb.DynamoDBAPI
+	tracer *Tracer
+}
+
+var _ dynamodb.DynamoDBAPI = (*DynamoDB)(nil)
+
+// DynamoDB returns next wrapped in a *DynamoDB
+func (t *Tracer) DynamoDB(next dynamodb.DynamoDBAPI) *DynamoDB {
+	return &DynamoDB{
+		next:   next,
+		tracer: t,
+	}
+}
+
+// DynamoPutItem calls DynamoPutItem on the wrapped dynamodb.DynamoDBAPI within a span
+func (svc *DynamoDB) DynamoPutItem(input interface{}, table string) error {
+	return svc.DynamoPutItemWithContext(context.Background(), input, table)
+}
+
+// DynamoPutItemWithContext calls DynamoPutItemWithContext on the wrapped dynamodb.DynamoDBAPI within a span
+func (svc *DynamoDB) DynamoPutItemWithContext(ctx context.Context, input interface{}, table string) (err error) {
+
+	ctx, span := svc.tracer.start(ctx, "dynamodb.DynamoPutItem",
+		attribute.StringSlice(TableAttribute, []string{table}),
+	)
+	defer func() { end(span, err) }()
+
+	return svc.next.DynamoPutItemWithContext(ctx, input, table)
+
+}
+
+// DynamoGetItem calls DynamoGetItem on the wrapped dynamodb.DynamoDBAPI within a span
+func (svc *DynamoDB) DynamoGetItem(table, keyName, keyValue string) (*dynamodb.GetItemOutput, error) {
+	return svc.DynamoGetItemWithContext(context.Background(), table, keyName, keyValue)
+}
+
+// DynamoGetItemWithContext calls DynamoGetItemWithContext on the wrapped dynamodb.DynamoDBAPI within a span
+func (svc *DynamoDB) DynamoGetItemWithContext(ctx context.Context, table, keyName, keyValue string) (out *dynamodb.GetItemOutput, err error) {
+
+	ctx, span := svc.tracer.start(ctx, "dynamodb.DynamoGetItem",
+		attribute.StringSlice(TableAttribute, []string{table}),
+	)
+	defer func() { end(span, err) }()
+
+	return svc.next.DynamoGetItemWithContext(ctx, table, keyName, keyValue)
+
+}
+
+// DynamoScan calls DynamoScan on the wrapped dynamodb.DynamoDBAPI within a span
+func (svc *DynamoDB) DynamoScan(table, keyName string, keyValue interface{}) (*dynamodb.ScanOutput, error) {
+	return svc.DynamoScanWithContext(context.Background(), table, keyName, keyValue)
+}
+
+// DynamoScanWithContext calls DynamoScanWithContext on the wrapped dynamodb.DynamoDBAPI within a span
+func (svc *DynamoDB) DynamoScanWithContext(ctx context.Context, table, keyName string, keyValue interface{}) (out *dynamodb.ScanOutput, err error) {
+
+	ctx, span := svc.tracer.start(ctx, "dynamodb.DynamoScan",
+		attribute.StringSlice(TableAttribute, []string{table}),
+	)
+	defer func() { end(span, err) }()
+
+	return svc.next.DynamoScanWithContext(ctx, table, keyName, keyValue)
+
+}
@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/mock"
+)
+
+func TestDynamoDB(t *testing.T) {
+
+	tracer, recorder := newTestTracer()
+
+	m := &mock.DynamoDB{}
+
+	svc := tracer.DynamoDB(m)
+
+	assert.NoError(t, svc.DynamoPutItem("some_input", "some_table"))
+
+	_, err := svc.DynamoGetItem("some_table", "some_key", "some_value")
+
+	assert.NoError(t, err)
+
+	_, err = svc.DynamoScan("some_table", "some_key", "some_value")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(m.Calls()))
+
+	spans := recorder.Ended()
+
+	assert.Len(t, spans, 3)
+
+	for i, name := range []string{"dynamodb.DynamoPutItem", "dynamodb.DynamoGetItem", "dynamodb.DynamoScan"} {
+		assert.Equal(t, name, spans[i].Name())
+		assert.Contains(t, spans[i].Attributes(), attribute.StringSlice(TableAttribute, []string{"some_table"}))
+	}
+
+}
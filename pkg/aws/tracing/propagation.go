@@ -0,0 +1,261 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sqsCarrier adapts SQS message attributes to propagation.TextMapCarrier
+type sqsCarrier map[string]*sqs.MessageAttributeValue
+
+func (c sqsCarrier) Get(key string) string {
+
+	if v, ok := c[key]; ok && v != nil {
+		return aws.StringValue(v.StringValue)
+	}
+
+	return ""
+
+}
+
+func (c sqsCarrier) Set(key, value string) {
+	c[key] = &sqs.MessageAttributeValue{
+		DataType:    aws.String(stringAttributeType),
+		StringValue: aws.String(value),
+	}
+}
+
+func (c sqsCarrier) Keys() []string {
+
+	out := make([]string, 0, len(c))
+	for k := range c {
+		out = append(out, k)
+	}
+
+	return out
+
+}
+
+// snsCarrier adapts SNS message attributes to propagation.TextMapCarrier
+type snsCarrier map[string]*sns.MessageAttributeValue
+
+func (c snsCarrier) Get(key string) string {
+
+	if v, ok := c[key]; ok && v != nil {
+		return aws.StringValue(v.StringValue)
+	}
+
+	return ""
+
+}
+
+func (c snsCarrier) Set(key, value string) {
+	c[key] = &sns.MessageAttributeValue{
+		DataType:    aws.String(stringAttributeType),
+		StringValue: aws.String(value),
+	}
+}
+
+func (c snsCarrier) Keys() []string {
+
+	out := make([]string, 0, len(c))
+	for k := range c {
+		out = append(out, k)
+	}
+
+	return out
+
+}
+
+// sqsEventCarrier adapts the message attributes of an SQS lambda event to propagation.TextMapCarrier
+type sqsEventCarrier map[string]events.SQSMessageAttribute
+
+func (c sqsEventCarrier) Get(key string) string {
+
+	if v, ok := c[key]; ok {
+		return aws.StringValue(v.StringValue)
+	}
+
+	return ""
+
+}
+
+func (c sqsEventCarrier) Set(key, value string) {
+	c[key] = events.SQSMessageAttribute{
+		DataType:    stringAttributeType,
+		StringValue: aws.String(value),
+	}
+}
+
+func (c sqsEventCarrier) Keys() []string {
+
+	out := make([]string, 0, len(c))
+	for k := range c {
+		out = append(out, k)
+	}
+
+	return out
+
+}
+
+// snsEventCarrier adapts the message attributes of an SNS notification, as delivered to
+// lambda or to a non raw SQS subscription, to propagation.TextMapCarrier
+type snsEventCarrier map[string]interface{}
+
+func (c snsEventCarrier) Get(key string) string {
+
+	attr, ok := c[key].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	v, _ := attr["Value"].(string)
+
+	return v
+
+}
+
+func (c snsEventCarrier) Set(key, value string) {
+	c[key] = map[string]interface{}{
+		"Type":  stringAttributeType,
+		"Value": value,
+	}
+}
+
+func (c snsEventCarrier) Keys() []string {
+
+	out := make([]string, 0, len(c))
+	for k := range c {
+		out = append(out, k)
+	}
+
+	return out
+
+}
+
+// snsNotification is the body of an SQS message delivered by a non raw SNS subscription
+type snsNotification struct {
+	Type              string          `json:"Type"`
+	MessageAttributes snsEventCarrier `json:"MessageAttributes"`
+}
+
+// ExtractSQSMessage returns ctx with the trace context carried by msg, if any.
+// msg has to be received with MessageAttributeNames containing All or the propagator fields.
+// Messages delivered by a non raw SNS subscription are supported as well
+func (t *Tracer) ExtractSQSMessage(ctx context.Context, msg *sqs.Message) context.Context {
+
+	if msg == nil {
+		return ctx
+	}
+
+	out := t.propagator.Extract(ctx, sqsCarrier(msg.MessageAttributes))
+	if trace.SpanContextFromContext(out).IsValid() {
+		return out
+	}
+
+	return t.extractSNSNotification(ctx, aws.StringValue(msg.Body))
+
+}
+
+// ExtractSQSEvent returns ctx with the trace context carried by an SQS message received by lambda
+func (t *Tracer) ExtractSQSEvent(ctx context.Context, msg events.SQSMessage) context.Context {
+
+	out := t.propagator.Extract(ctx, sqsEventCarrier(msg.MessageAttributes))
+	if trace.SpanContextFromContext(out).IsValid() {
+		return out
+	}
+
+	return t.extractSNSNotification(ctx, msg.Body)
+
+}
+
+// ExtractSNSEvent returns ctx with the trace context carried by an SNS notification received by lambda
+func (t *Tracer) ExtractSNSEvent(ctx context.Context, entity events.SNSEntity) context.Context {
+	return t.propagator.Extract(ctx, snsEventCarrier(entity.MessageAttributes))
+}
+
+// extractSNSNotification returns ctx with the trace context carried by an SNS notification body
+func (t *Tracer) extractSNSNotification(ctx context.Context, body string) context.Context {
+
+	var n snsNotification
+
+	if err := json.Unmarshal([]byte(body), &n); err != nil || n.Type != "Notification" {
+		return ctx
+	}
+
+	return t.propagator.Extract(ctx, n.MessageAttributes)
+
+}
+
+// inject adds the trace context of r to the message attributes of SQS and SNS requests
+func (t *Tracer) inject(r *request.Request) {
+
+	ctx := r.Context()
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+
+	switch in := r.Params.(type) {
+	case *sqs.SendMessageInput:
+		in.MessageAttributes = t.injectSQS(ctx, in.MessageAttributes)
+	case *sqs.SendMessageBatchInput:
+		for _, entry := range in.Entries {
+			entry.MessageAttributes = t.injectSQS(ctx, entry.MessageAttributes)
+		}
+	case *sns.PublishInput:
+		in.MessageAttributes = t.injectSNS(ctx, in.MessageAttributes)
+	}
+
+}
+
+func (t *Tracer) injectSQS(ctx context.Context, attributes map[string]*sqs.MessageAttributeValue) map[string]*sqs.MessageAttributeValue {
+
+	if attributes == nil {
+		attributes = make(map[string]*sqs.MessageAttributeValue)
+	}
+
+	if fits(t.propagator, sqsCarrier(attributes)) {
+		t.propagator.Inject(ctx, sqsCarrier(attributes))
+	}
+
+	return attributes
+
+}
+
+func (t *Tracer) injectSNS(ctx context.Context, attributes map[string]*sns.MessageAttributeValue) map[string]*sns.MessageAttributeValue {
+
+	if attributes == nil {
+		attributes = make(map[string]*sns.MessageAttributeValue)
+	}
+
+	if fits(t.propagator, snsCarrier(attributes)) {
+		t.propagator.Inject(ctx, snsCarrier(attributes))
+	}
+
+	return attributes
+
+}
+
+// fits reports whether the fields of p can be added to carrier without exceeding
+// the maximum number of message attributes
+func fits(p propagation.TextMapPropagator, carrier propagation.TextMapCarrier) bool {
+
+	n := len(carrier.Keys())
+
+	for _, f := range p.Fields() {
+		if carrier.Get(f) == "" {
+			n++
+		}
+	}
+
+	return n <= maxMessageAttributes
+
+}
@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	awsSqs "github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/sns"
+)
+
+const traceParentField = "traceparent"
+
+func TestTracer_ExtractSQSMessage(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	tracer, _ := newTestTracer()
+
+	sqsSvc := newSQSSvc(t, srv, tracer)
+
+	err := sqsSvc.SQSCreateQueue("some_queue")
+
+	assert.NoError(t, err)
+
+	ctx, span := tracer.start(context.Background(), "some_parent")
+
+	err = sqsSvc.SQSSendMessageWithContext(ctx, map[string]string{"some_key": "some_value"}, "some_queue", false)
+
+	span.End()
+
+	assert.NoError(t, err)
+
+	messages := srv.Messages("some_queue")
+
+	assert.Len(t, messages, 1)
+	assert.Contains(t, messages[0].MessageAttributes, traceParentField)
+
+	url, err := sqsSvc.SQSGetQueueUrl("some_queue")
+
+	assert.NoError(t, err)
+
+	out, err := sqsSvc.ReceiveMessage(&awsSqs.ReceiveMessageInput{
+		QueueUrl:              aws.String(url),
+		MessageAttributeNames: []*string{aws.String("All")},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, out.Messages, 1)
+
+	extracted := trace.SpanContextFromContext(tracer.ExtractSQSMessage(context.Background(), out.Messages[0]))
+
+	assert.True(t, extracted.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+
+	extracted = trace.SpanContextFromContext(tracer.ExtractSQSMessage(context.Background(), &awsSqs.Message{Body: aws.String("some_body")}))
+
+	assert.False(t, extracted.IsValid())
+
+}
+
+func TestTracer_Inject_SNS(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	tracer, _ := newTestTracer()
+
+	snsSvc, err := sns.New(newTestSession(t, tracer), srv.URL)
+
+	assert.NoError(t, err)
+
+	ctx, span := tracer.start(context.Background(), "some_parent")
+
+	err = snsSvc.SnsPublishWithContext(ctx, `{"default":"some_message"}`, nil, "some_target_arn")
+
+	span.End()
+
+	assert.NoError(t, err)
+
+	publications := srv.Publications()
+
+	assert.Len(t, publications, 1)
+	assert.Contains(t, publications[0].MessageAttributes, traceParentField)
+
+	err = snsSvc.SnsPublish(`{"default":"some_message"}`, nil, "some_target_arn")
+
+	assert.NoError(t, err)
+
+	publications = srv.Publications()
+
+	assert.Len(t, publications, 2)
+	assert.NotContains(t, publications[1].MessageAttributes, traceParentField)
+
+}
+
+func TestTracer_ExtractEvents(t *testing.T) {
+
+	tracer, _ := newTestTracer()
+
+	ctx, span := tracer.start(context.Background(), "some_parent")
+	span.End()
+
+	sqsAttributes := make(map[string]events.SQSMessageAttribute)
+	tracer.propagator.Inject(ctx, sqsEventCarrier(sqsAttributes))
+
+	extracted := trace.SpanContextFromContext(tracer.ExtractSQSEvent(context.Background(), events.SQSMessage{
+		MessageAttributes: sqsAttributes,
+	}))
+
+	assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+
+	snsAttributes := make(map[string]interface{})
+	tracer.propagator.Inject(ctx, snsEventCarrier(snsAttributes))
+
+	extracted = trace.SpanContextFromContext(tracer.ExtractSNSEvent(context.Background(), events.SNSEntity{
+		MessageAttributes: snsAttributes,
+	}))
+
+	assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+
+	body, err := json.Marshal(map[string]interface{}{
+		"Type":              "Notification",
+		"MessageAttributes": snsAttributes,
+	})
+
+	assert.NoError(t, err)
+
+	extracted = trace.SpanContextFromContext(tracer.ExtractSQSEvent(context.Background(), events.SQSMessage{
+		Body: string(body),
+	}))
+
+	assert.Equal(t, span.SpanContext().TraceID(), extracted.TraceID())
+
+}
+
+func TestFits(t *testing.T) {
+
+	tracer, _ := newTestTracer()
+
+	carrier := make(sqsCarrier)
+
+	assert.True(t, fits(tracer.propagator, carrier))
+
+	for _, k := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i"} {
+		carrier.Set(k, "some_value")
+	}
+
+	assert.False(t, fits(tracer.propagator, carrier))
+
+}
@@ -0,0 +1,68 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/rekognition"
+)
+
+// Rekognition wraps a rekognition.RekognitionAPI creating a span for every helper call
+type Rekognition struct {
+	next   rekognition.RekognitionAPI
+	tracer *Tracer
+}
+
+var _ rekognition.RekognitionAPI = (*Rekognition)(nil)
+
+// Rekognition returns next wrapped in a *Rekognition
+func (t *Tracer) Rekognition(next rekognition.RekognitionAPI) *Rekognition {
+	return &Rekognition{
+		next:   next,
+		tracer: t,
+	}
+}
+
+// RekognitionCompareFaces calls RekognitionCompareFaces on the wrapped rekognition.RekognitionAPI within a span
+func (svc *Rekognition) RekognitionCompareFaces(sourceImage, targetImage []byte, similarity float64) (*rekognition.CompareFacesOutput, error) {
+	return svc.RekognitionCompareFacesWithContext(context.Background(), sourceImage, targetImage, similarity)
+}
+
+// RekognitionCompareFacesWithContext calls RekognitionCompareFacesWithContext on the wrapped rekognition.RekognitionAPI within a span
+func (svc *Rekognition) RekognitionCompareFacesWithContext(ctx context.Context, sourceImage, targetImage []byte, similarity float64) (out *rekognition.CompareFacesOutput, err error) {
+
+	ctx, span := svc.tracer.start(ctx, "rekognition.RekognitionCompareFaces")
+	defer func() { end(span, err) }()
+
+	return svc.next.RekognitionCompareFacesWithContext(ctx, sourceImage, targetImage, similarity)
+
+}
+
+// RekognitionDetectFaces calls RekognitionDetectFaces on the wrapped rekognition.RekognitionAPI within a span
+func (svc *Rekognition) RekognitionDetectFaces(sourceImage []byte) (*rekognition.DetectFacesOutput, error) {
+	return svc.RekognitionDetectFacesWithContext(context.Background(), sourceImage)
+}
+
+// RekognitionDetectFacesWithContext calls RekognitionDetectFacesWithContext on the wrapped rekognition.RekognitionAPI within a span
+func (svc *Rekognition) RekognitionDetectFacesWithContext(ctx context.Context, sourceImage []byte) (out *rekognition.DetectFacesOutput, err error) {
+
+	ctx, span := svc.tracer.start(ctx, "rekognition.RekognitionDetectFaces")
+	defer func() { end(span, err) }()
+
+	return svc.next.RekognitionDetectFacesWithContext(ctx, sourceImage)
+
+}
+
+// RekognitionDetectText calls RekognitionDetectText on the wrapped rekognition.RekognitionAPI within a span
+func (svc *Rekognition) RekognitionDetectText(sourceImage []byte) (*rekognition.DetectTextOutput, error) {
+	return svc.RekognitionDetectTextWithContext(context.Background(), sourceImage)
+}
+
+// RekognitionDetectTextWithContext calls RekognitionDetectTextWithContext on the wrapped rekognition.RekognitionAPI within a span
+func (svc *Rekognition) RekognitionDetectTextWithContext(ctx context.Context, sourceImage []byte) (out *rekognition.DetectTextOutput, err error) {
+
+	ctx, span := svc.tracer.start(ctx, "rekognition.RekognitionDetectText")
+	defer func() { end(span, err) }()
+
+	return svc.next.RekognitionDetectTextWithContext(ctx, sourceImage)
+
+}
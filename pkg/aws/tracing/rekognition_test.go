@@ -0,0 +1,39 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/mock"
+)
+
+func TestRekognition(t *testing.T) {
+
+	tracer, recorder := newTestTracer()
+
+	m := &mock.Rekognition{}
+
+	svc := tracer.Rekognition(m)
+
+	_, err := svc.RekognitionCompareFaces([]byte("some_source"), []byte("some_target"), 70)
+
+	assert.NoError(t, err)
+
+	_, err = svc.RekognitionDetectFaces([]byte("some_source"))
+
+	assert.NoError(t, err)
+
+	_, err = svc.RekognitionDetectText([]byte("some_source"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(m.Calls()))
+
+	spans := recorder.Ended()
+
+	assert.Len(t, spans, 3)
+	assert.Equal(t, "rekognition.RekognitionCompareFaces", spans[0].Name())
+	assert.Equal(t, "rekognition.RekognitionDetectFaces", spans[1].Name())
+	assert.Equal(t, "rekognition.RekognitionDetectText", spans[2].Name())
+
+}
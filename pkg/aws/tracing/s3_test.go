@@ -0,0 +1,233 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/mock"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/s3"
+)
+
+func TestS3(t *testing.T) {
+
+	tracer, recorder := newTestTracer()
+
+	var parent trace.SpanContext
+
+	m := &mock.S3{
+		S3PutObjectFunc: func(ctx context.Context, bucketName, objectName, objectPath string, opts ...s3.PutOption) error {
+			parent = trace.SpanContextFromContext(ctx)
+			return errors.New("some_error")
+		},
+	}
+
+	svc := tracer.S3(m)
+
+	assert.NoError(t, svc.S3CreateBucket("some_bucket"))
+
+	_, err := svc.S3GetObject("some_bucket", "some_key")
+
+	assert.NoError(t, err)
+	assert.Error(t, svc.S3PutObject("some_bucket", "some_key", "some_path"))
+	assert.NoError(t, svc.S3Upload("some_bucket", "some_key", strings.NewReader("some_body")))
+	assert.NoError(t, svc.S3UploadFile("some_bucket", "some_key", "some_path"))
+	assert.NoError(t, svc.S3UploadReaderAt("some_bucket", "some_key", strings.NewReader("some_body"), 9))
+	m.S3DownloadFunc = func(ctx context.Context, bucketName, objectName string, w io.Writer, opts ...s3.DownloadOption) (int64, error) {
+		return 9, nil
+	}
+
+	_, err = svc.S3Download("some_bucket", "some_key", ioutil.Discard)
+
+	assert.NoError(t, err)
+
+	_, err = svc.S3DownloadAt("some_bucket", "some_key", nil)
+
+	assert.NoError(t, err)
+
+	m.S3ListObjectsFunc = func(ctx context.Context, bucketName string, opts ...s3.ListOption) *s3.ObjectIterator {
+		return s3.NewObjectIterator(nil, errors.New("some_error"))
+	}
+
+	it := svc.S3ListObjects("some_bucket")
+
+	assert.False(t, it.Next())
+	assert.Error(t, it.Err())
+	assert.NoError(t, svc.S3DeleteObject("some_bucket", "some_key"))
+
+	m.S3DeleteObjectsFunc = func(ctx context.Context, bucketName string, objectNames []string, opts ...s3.DeleteOption) (*s3.DeleteResult, error) {
+		return &s3.DeleteResult{
+			Deleted: []s3.DeletedObject{{Key: "a"}, {Key: "b"}},
+			Errors:  []s3.DeleteError{{Key: "c", Err: errors.New("some_error")}},
+		}, nil
+	}
+
+	_, err = svc.S3DeleteObjects("some_bucket", []string{"a", "b", "c"})
+
+	assert.NoError(t, err)
+
+	_, err = svc.S3DeletePrefix("some_bucket", "some/")
+
+	assert.NoError(t, err)
+	assert.NoError(t, svc.S3Copy("some_bucket", "some_key", "other_bucket", "other_key"))
+	assert.NoError(t, svc.S3Move("some_bucket", "some_key", "other_bucket", "other_key"))
+
+	assert.NoError(t, svc.S3ReplaceMetadata("some_bucket", "some_key", nil))
+
+	m.S3StatFunc = func(ctx context.Context, bucketName, objectName string, opts ...s3.EncryptionOption) (*s3.ObjectInfo, error) {
+		return &s3.ObjectInfo{Size: 9}, nil
+	}
+
+	_, err = svc.S3Exists("some_bucket", "some_key")
+
+	assert.NoError(t, err)
+
+	_, err = svc.S3Stat("some_bucket", "some_key")
+
+	assert.NoError(t, err)
+
+	_, err = svc.S3GetObjectTags("some_bucket", "some_key")
+
+	assert.NoError(t, err)
+	assert.NoError(t, svc.S3PutObjectTags("some_bucket", "some_key", map[string]string{"some": "tag"}))
+	assert.NoError(t, svc.S3DeleteObjectTags("some_bucket", "some_key"))
+
+	_, err = svc.S3PresignGet("some_bucket", "some_key", time.Minute)
+
+	assert.NoError(t, err)
+
+	_, err = svc.S3PresignPut("some_bucket", "some_key", time.Minute)
+
+	assert.NoError(t, err)
+
+	m.S3PresignPostFunc = func(bucketName, objectName string, expiry time.Duration, opts ...s3.PresignOption) (*s3.PresignedPost, error) {
+		return nil, errors.New("some_error")
+	}
+
+	_, err = svc.S3PresignPost("some_bucket", "some_key", time.Minute)
+
+	assert.Error(t, err)
+
+	m.S3SyncUpFunc = func(ctx context.Context, localDir, bucketName, prefix string, opts ...s3.SyncOption) (*s3.SyncReport, error) {
+		return &s3.SyncReport{Files: []s3.SyncedFile{
+			{Key: "some/a", Action: s3.SyncUpload},
+			{Key: "some/b", Action: s3.SyncSkip},
+			{Key: "some/c", Action: s3.SyncDelete, Err: errors.New("some_error")},
+		}}, nil
+	}
+
+	_, err = svc.S3SyncUp("some_dir", "some_bucket", "some/")
+
+	assert.NoError(t, err)
+
+	_, err = svc.S3SyncDown("some_bucket", "some/", "some_dir")
+
+	assert.NoError(t, err)
+
+	_, err = svc.S3GetBucketVersioning("some_bucket")
+
+	assert.NoError(t, err)
+	assert.NoError(t, svc.S3PutBucketVersioning("some_bucket", s3.VersioningEnabled))
+
+	_, err = svc.S3GetBucketLifecycle("some_bucket")
+
+	assert.NoError(t, err)
+	assert.NoError(t, svc.S3PutBucketLifecycle("some_bucket", nil))
+
+	_, err = svc.S3GetBucketCORS("some_bucket")
+
+	assert.NoError(t, err)
+	assert.NoError(t, svc.S3PutBucketCORS("some_bucket", nil))
+
+	_, err = svc.S3GetPublicAccessBlock("some_bucket")
+
+	assert.NoError(t, err)
+	assert.NoError(t, svc.S3PutPublicAccessBlock("some_bucket", nil))
+
+	_, err = svc.S3GetBucketPolicy("some_bucket")
+
+	assert.NoError(t, err)
+
+	m.S3PutBucketPolicyFunc = func(ctx context.Context, bucketName, policy string) error {
+		return errors.New("some_error")
+	}
+
+	assert.Error(t, svc.S3PutBucketPolicy("some_bucket", "some_policy"))
+
+	_, err = svc.S3GetBucketEncryption("some_bucket")
+
+	assert.NoError(t, err)
+	assert.NoError(t, svc.S3PutBucketEncryption("some_bucket", nil))
+	assert.Equal(t, 37, len(m.Calls()))
+
+	spans := recorder.Ended()
+
+	assert.Len(t, spans, 37)
+	assert.Equal(t, "s3.S3CreateBucket", spans[0].Name())
+	assert.Equal(t, "s3.S3GetObject", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), attribute.String(KeyAttribute, "some_key"))
+	assert.Equal(t, "s3.S3PutObject", spans[2].Name())
+	assert.Contains(t, spans[2].Attributes(), attribute.String(BucketAttribute, "some_bucket"))
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+	assert.Equal(t, spans[2].SpanContext().SpanID(), parent.SpanID())
+	assert.Equal(t, "s3.S3Upload", spans[3].Name())
+	assert.Equal(t, "s3.S3UploadFile", spans[4].Name())
+	assert.Equal(t, "s3.S3UploadReaderAt", spans[5].Name())
+	assert.Contains(t, spans[5].Attributes(), attribute.Int64(SizeAttribute, 9))
+	assert.Equal(t, "s3.S3Download", spans[6].Name())
+	assert.Contains(t, spans[6].Attributes(), attribute.Int64(SizeAttribute, 9))
+	assert.Equal(t, "s3.S3DownloadAt", spans[7].Name())
+	assert.Equal(t, "s3.S3ListObjects", spans[8].Name())
+	assert.Equal(t, codes.Unset, spans[8].Status().Code)
+	assert.Equal(t, "s3.S3DeleteObject", spans[9].Name())
+	assert.Equal(t, "s3.S3DeleteObjects", spans[10].Name())
+	assert.Contains(t, spans[10].Attributes(), attribute.Int(DeletedAttribute, 2))
+	assert.Contains(t, spans[10].Attributes(), attribute.Int(DeleteErrorsAttribute, 1))
+	assert.Equal(t, "s3.S3DeletePrefix", spans[11].Name())
+	assert.Contains(t, spans[11].Attributes(), attribute.String(KeyAttribute, "some/"))
+	assert.Equal(t, "s3.S3Copy", spans[12].Name())
+	assert.Contains(t, spans[12].Attributes(), attribute.String(DestinationBucketAttribute, "other_bucket"))
+	assert.Equal(t, "s3.S3Move", spans[13].Name())
+	assert.Contains(t, spans[13].Attributes(), attribute.String(DestinationKeyAttribute, "other_key"))
+	assert.Equal(t, "s3.S3ReplaceMetadata", spans[14].Name())
+	assert.Equal(t, "s3.S3Exists", spans[15].Name())
+	assert.Equal(t, "s3.S3Stat", spans[16].Name())
+	assert.Contains(t, spans[16].Attributes(), attribute.Int64(SizeAttribute, 9))
+	assert.Equal(t, "s3.S3GetObjectTags", spans[17].Name())
+	assert.Equal(t, "s3.S3PutObjectTags", spans[18].Name())
+	assert.Equal(t, "s3.S3DeleteObjectTags", spans[19].Name())
+	assert.Equal(t, "s3.S3PresignGet", spans[20].Name())
+	assert.Contains(t, spans[20].Attributes(), attribute.Int64(ExpiryAttribute, 60))
+	assert.Equal(t, "s3.S3PresignPut", spans[21].Name())
+	assert.Equal(t, "s3.S3PresignPost", spans[22].Name())
+	assert.Equal(t, codes.Error, spans[22].Status().Code)
+	assert.Equal(t, "s3.S3SyncUp", spans[23].Name())
+	assert.Contains(t, spans[23].Attributes(), attribute.Int(SyncedAttribute, 1))
+	assert.Contains(t, spans[23].Attributes(), attribute.Int(SyncErrorsAttribute, 1))
+	assert.Equal(t, "s3.S3SyncDown", spans[24].Name())
+	assert.Contains(t, spans[24].Attributes(), attribute.String(KeyAttribute, "some/"))
+	assert.Equal(t, "s3.S3GetBucketVersioning", spans[25].Name())
+	assert.Contains(t, spans[25].Attributes(), attribute.String(BucketAttribute, "some_bucket"))
+	assert.Equal(t, "s3.S3PutBucketVersioning", spans[26].Name())
+	assert.Equal(t, "s3.S3GetBucketLifecycle", spans[27].Name())
+	assert.Equal(t, "s3.S3PutBucketLifecycle", spans[28].Name())
+	assert.Equal(t, "s3.S3GetBucketCORS", spans[29].Name())
+	assert.Equal(t, "s3.S3PutBucketCORS", spans[30].Name())
+	assert.Equal(t, "s3.S3GetPublicAccessBlock", spans[31].Name())
+	assert.Equal(t, "s3.S3PutPublicAccessBlock", spans[32].Name())
+	assert.Equal(t, "s3.S3GetBucketPolicy", spans[33].Name())
+	assert.Equal(t, "s3.S3PutBucketPolicy", spans[34].Name())
+	assert.Equal(t, codes.Error, spans[34].Status().Code)
+	assert.Equal(t, "s3.S3GetBucketEncryption", spans[35].Name())
+	assert.Equal(t, "s3.S3PutBucketEncryption", spans[36].Name())
+
+}
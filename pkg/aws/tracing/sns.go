@@ -0,0 +1,43 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/sns"
+)
+
+// SNS wraps an sns.SNSAPI creating a span for every helper call
+type SNS struct {
+	next   sns.SNSAPI
+	tracer *Tracer
+}
+
+var _ sns.SNSAPI = (*SNS)(nil)
+
+// SNS returns next wrapped in a *SNS
+func (t *Tracer) SNS(next sns.SNSAPI) *SNS {
+	return &SNS{
+		next:   next,
+		tracer: t,
+	}
+}
+
+// SnsPublish calls SnsPublish on the wrapped sns.SNSAPI within a span
+func (svc *SNS) SnsPublish(input interface{}, messageAttributes map[string]interface{}, targetArn string) error {
+	return svc.SnsPublishWithContext(context.Background(), input, messageAttributes, targetArn)
+}
+
+// SnsPublishWithContext calls SnsPublishWithContext on the wrapped sns.SNSAPI within a span
+func (svc *SNS) SnsPublishWithContext(ctx context.Context, input interface{}, messageAttributes map[string]interface{}, targetArn string) (err error) {
+
+	ctx, span := svc.tracer.start(ctx, "sns.SnsPublish",
+		attribute.String(MessagingSystemAttribute, snsMessagingSystem),
+		attribute.String(DestinationAttribute, targetArn),
+	)
+	defer func() { end(span, err) }()
+
+	return svc.next.SnsPublishWithContext(ctx, input, messageAttributes, targetArn)
+
+}
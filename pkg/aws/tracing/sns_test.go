@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/mock"
+)
+
+func TestSNS(t *testing.T) {
+
+	tracer, recorder := newTestTracer()
+
+	m := &mock.SNS{}
+
+	svc := tracer.SNS(m)
+
+	assert.NoError(t, svc.SnsPublish("some_input", nil, "some_target_arn"))
+	assert.Equal(t, 1, m.CallCount("SnsPublish"))
+
+	spans := recorder.Ended()
+
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "sns.SnsPublish", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String(DestinationAttribute, "some_target_arn"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String(MessagingSystemAttribute, snsMessagingSystem))
+
+}
@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"context"
+
+	awsSqs "github.com/aws/aws-sdk-go/service/sqs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/sqs"
+)
+
+// SQS wraps an sqs.SQSAPI creating a span for every helper call
+type SQS struct {
+	next   sqs.SQSAPI
+	tracer *Tracer
+}
+
+var _ sqs.SQSAPI = (*SQS)(nil)
+
+// SQS returns next wrapped in a *SQS
+func (t *Tracer) SQS(next sqs.SQSAPI) *SQS {
+	return &SQS{
+		next:   next,
+		tracer: t,
+	}
+}
+
+// SQSCreateQueue calls SQSCreateQueue on the wrapped sqs.SQSAPI within a span
+func (svc *SQS) SQSCreateQueue(queue string) error {
+	return svc.SQSCreateQueueWithContext(context.Background(), queue)
+}
+
+// SQSCreateQueueWithContext calls SQSCreateQueueWithContext on the wrapped sqs.SQSAPI within a span
+func (svc *SQS) SQSCreateQueueWithContext(ctx context.Context, queue string) (err error) {
+
+	ctx, span := svc.start(ctx, "sqs.SQSCreateQueue", queue)
+	defer func() { end(span, err) }()
+
+	return svc.next.SQSCreateQueueWithContext(ctx, queue)
+
+}
+
+// SQSGetQueueAttributes calls SQSGetQueueAttributes on the wrapped sqs.SQSAPI within a span
+func (svc *SQS) SQSGetQueueAttributes(queueUrl string) (*awsSqs.GetQueueAttributesOutput, error) {
+	return svc.SQSGetQueueAttributesWithContext(context.Background(), queueUrl)
+}
+
+// SQSGetQueueAttributesWithContext calls SQSGetQueueAttributesWithContext on the wrapped sqs.SQSAPI within a span
+func (svc *SQS) SQSGetQueueAttributesWithContext(ctx context.Context, queueUrl string) (out *awsSqs.GetQueueAttributesOutput, err error) {
+
+	ctx, span := svc.start(ctx, "sqs.SQSGetQueueAttributes", queueUrl)
+	defer func() { end(span, err) }()
+
+	return svc.next.SQSGetQueueAttributesWithContext(ctx, queueUrl)
+
+}
+
+// SQSSendMessage calls SQSSendMessage on the wrapped sqs.SQSAPI within a span
+func (svc *SQS) SQSSendMessage(input interface{}, queueName string, base64Encode bool) error {
+	return svc.SQSSendMessageWithContext(context.Background(), input, queueName, base64Encode)
+}
+
+// SQSSendMessageWithContext calls SQSSendMessageWithContext on the wrapped sqs.SQSAPI within a span
+func (svc *SQS) SQSSendMessageWithContext(ctx context.Context, input interface{}, queueName string, base64Encode bool) (err error) {
+
+	ctx, span := svc.start(ctx, "sqs.SQSSendMessage", queueName)
+	defer func() { end(span, err) }()
+
+	return svc.next.SQSSendMessageWithContext(ctx, input, queueName, base64Encode)
+
+}
+
+// SQSGetQueueUrl calls SQSGetQueueUrl on the wrapped sqs.SQSAPI within a span
+func (svc *SQS) SQSGetQueueUrl(queueName string) (string, error) {
+	return svc.SQSGetQueueUrlWithContext(context.Background(), queueName)
+}
+
+// SQSGetQueueUrlWithContext calls SQSGetQueueUrlWithContext on the wrapped sqs.SQSAPI within a span
+func (svc *SQS) SQSGetQueueUrlWithContext(ctx context.Context, queueName string) (out string, err error) {
+
+	ctx, span := svc.start(ctx, "sqs.SQSGetQueueUrl", queueName)
+	defer func() { end(span, err) }()
+
+	return svc.next.SQSGetQueueUrlWithContext(ctx, queueName)
+
+}
+
+// start starts the span of an SQS helper call on destination
+func (svc *SQS) start(ctx context.Context, name, destination string) (context.Context, trace.Span) {
+	return svc.tracer.start(ctx, name,
+		attribute.String(MessagingSystemAttribute, sqsMessagingSystem),
+		attribute.String(DestinationAttribute, destination),
+	)
+}
@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/mock"
+)
+
+func TestSQS(t *testing.T) {
+
+	tracer, recorder := newTestTracer()
+
+	m := &mock.SQS{}
+
+	svc := tracer.SQS(m)
+
+	assert.NoError(t, svc.SQSCreateQueue("some_queue"))
+
+	_, err := svc.SQSGetQueueAttributes("some_queue_url")
+
+	assert.NoError(t, err)
+	assert.NoError(t, svc.SQSSendMessage("some_input", "some_queue", false))
+
+	_, err = svc.SQSGetQueueUrl("some_queue")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, len(m.Calls()))
+
+	spans := recorder.Ended()
+
+	assert.Len(t, spans, 4)
+	assert.Equal(t, "sqs.SQSCreateQueue", spans[0].Name())
+	assert.Equal(t, "sqs.SQSGetQueueAttributes", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), attribute.String(DestinationAttribute, "some_queue_url"))
+	assert.Equal(t, "sqs.SQSSendMessage", spans[2].Name())
+	assert.Contains(t, spans[2].Attributes(), attribute.String(MessagingSystemAttribute, sqsMessagingSystem))
+	assert.Equal(t, "sqs.SQSGetQueueUrl", spans[3].Name())
+
+}
@@ -0,0 +1,205 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+)
+
+const (
+
+	// InstrumentationName is the name of the tracer used by New
+	InstrumentationName = "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/tracing"
+
+	// RPCSystemAttribute, RPCServiceAttribute and RPCMethodAttribute describe the aws api called by an attempt
+	RPCSystemAttribute  = "rpc.system"
+	RPCServiceAttribute = "rpc.service"
+	RPCMethodAttribute  = "rpc.method"
+	// RequestIDAttribute is the aws request id of an attempt
+	RequestIDAttribute = "aws.request_id"
+	// RetryCountAttribute is the number of attempts preceding an attempt
+	RetryCountAttribute = "aws.retry_count"
+	// StatusCodeAttribute is the http status code of an attempt
+	StatusCodeAttribute = "http.status_code"
+	// ErrorCodeAttribute is the aws error code of a failed attempt
+	ErrorCodeAttribute = "aws.error_code"
+
+	// BucketAttribute is the S3 bucket of a helper call
+	BucketAttribute = "aws.s3.bucket"
+	// KeyAttribute is the S3 key of a helper call
+	KeyAttribute = "aws.s3.key"
+	// DestinationBucketAttribute and DestinationKeyAttribute are the destination of an S3 copy or move,
+	// whose source is described by BucketAttribute and KeyAttribute
+	DestinationBucketAttribute = "aws.s3.destination_bucket"
+	DestinationKeyAttribute    = "aws.s3.destination_key"
+	// SizeAttribute is the size in bytes of the body uploaded or downloaded by an S3 helper call
+	SizeAttribute = "aws.s3.size"
+	// DeletedAttribute and DeleteErrorsAttribute are the number of objects deleted and failed by an S3 batch deletion
+	DeletedAttribute      = "aws.s3.deleted"
+	DeleteErrorsAttribute = "aws.s3.delete_errors"
+	// SyncedAttribute and SyncErrorsAttribute are the number of files transferred or deleted and failed by an S3 sync
+	SyncedAttribute     = "aws.s3.synced"
+	SyncErrorsAttribute = "aws.s3.sync_errors"
+	// ExpiryAttribute is the validity in seconds of a presigned S3 URL or POST policy
+	ExpiryAttribute = "aws.s3.expiry"
+	// TableAttribute is the DynamoDB table of a helper call
+	TableAttribute = "aws.dynamodb.table_names"
+	// MessagingSystemAttribute is the messaging system of an SQS or SNS helper call
+	MessagingSystemAttribute = "messaging.system"
+	// DestinationAttribute is the queue name, queue url or target arn of an SQS or SNS helper call
+	DestinationAttribute = "messaging.destination.name"
+
+	rpcSystem = "aws-api"
+
+	injectMiddlewareName  = "awsb.tracing.Inject"
+	startMiddlewareName   = "awsb.tracing.StartAttempt"
+	endMiddlewareName     = "awsb.tracing.EndAttempt"
+	maxMessageAttributes  = 10
+	stringAttributeType   = "String"
+	sqsMessagingSystem    = "aws_sqs"
+	snsMessagingSystem    = "aws_sns"
+	unknownOperationLabel = "Unknown"
+)
+
+// Tracer creates the spans of helper calls and request attempts
+type Tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+
+	mu       sync.Mutex
+	attempts map[*request.Request]trace.Span
+}
+
+// Option sets an optional parameter on a *Tracer
+type Option func(*Tracer)
+
+// New returns a new *Tracer using the global otel TracerProvider and TextMapPropagator,
+// unless overridden by opts
+func New(opts ...Option) *Tracer {
+
+	t := &Tracer{
+		tracer:     otel.GetTracerProvider().Tracer(InstrumentationName),
+		propagator: otel.GetTextMapPropagator(),
+		attempts:   make(map[*request.Request]trace.Span),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+
+}
+
+// WithTracerProvider makes the *Tracer create its spans from tp
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(t *Tracer) {
+		t.tracer = tp.Tracer(InstrumentationName)
+	}
+}
+
+// WithPropagator makes the *Tracer inject and extract the trace context with p
+func WithPropagator(p propagation.TextMapPropagator) Option {
+	return func(t *Tracer) {
+		t.propagator = p
+	}
+}
+
+// Middleware returns the pkgAws.Middleware creating a span for every request attempt
+// and injecting the trace context into SQS and SNS message attributes
+func (t *Tracer) Middleware() pkgAws.Middleware {
+	return func(h *request.Handlers) {
+
+		h.Build.PushFrontNamed(request.NamedHandler{
+			Name: injectMiddlewareName,
+			Fn:   t.inject,
+		})
+		h.Send.PushFrontNamed(request.NamedHandler{
+			Name: startMiddlewareName,
+			Fn:   t.startAttempt,
+		})
+		h.CompleteAttempt.PushBackNamed(request.NamedHandler{
+			Name: endMiddlewareName,
+			Fn:   t.endAttempt,
+		})
+
+	}
+}
+
+// startAttempt starts the span of a request attempt as a child of the request context
+func (t *Tracer) startAttempt(r *request.Request) {
+
+	operation := unknownOperationLabel
+	if r.Operation != nil {
+		operation = r.Operation.Name
+	}
+
+	_, span := t.tracer.Start(
+		r.Context(),
+		r.ClientInfo.ServiceName+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String(RPCSystemAttribute, rpcSystem),
+			attribute.String(RPCServiceAttribute, r.ClientInfo.ServiceName),
+			attribute.String(RPCMethodAttribute, operation),
+			attribute.Int(RetryCountAttribute, r.RetryCount),
+		),
+	)
+
+	t.mu.Lock()
+	t.attempts[r] = span
+	t.mu.Unlock()
+
+}
+
+// endAttempt ends the span started by startAttempt
+func (t *Tracer) endAttempt(r *request.Request) {
+
+	t.mu.Lock()
+	span, ok := t.attempts[r]
+	delete(t.attempts, r)
+	t.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	if r.RequestID != "" {
+		span.SetAttributes(attribute.String(RequestIDAttribute, r.RequestID))
+	}
+	if r.HTTPResponse != nil {
+		span.SetAttributes(attribute.Int(StatusCodeAttribute, r.HTTPResponse.StatusCode))
+	}
+
+	end(span, r.Error)
+
+}
+
+// start starts the span of a helper call
+func (t *Tracer) start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return t.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// end records err on span, if any, and ends it
+func end(span trace.Span, err error) {
+
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok {
+			span.SetAttributes(attribute.String(ErrorCodeAttribute, awsErr.Code()))
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+
+}
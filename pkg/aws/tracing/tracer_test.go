@@ -0,0 +1,123 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	pkgAws "github.com/easynetwork/aws-sdk-go-bindings/pkg/aws"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/fake"
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/aws/sqs"
+)
+
+func TestTracer_Middleware(t *testing.T) {
+
+	srv := fake.New()
+	defer srv.Close()
+
+	tracer, recorder := newTestTracer()
+
+	sqsSvc := newSQSSvc(t, srv, tracer)
+
+	ctx, parent := tracer.start(context.Background(), "some_parent")
+
+	err := sqsSvc.SQSCreateQueueWithContext(ctx, "some_queue")
+
+	assert.NoError(t, err)
+
+	_, err = sqsSvc.SQSGetQueueUrlWithContext(ctx, "some_missing_queue")
+
+	assert.Error(t, err)
+
+	parent.End()
+
+	spans := recorder.Ended()
+
+	assert.Len(t, spans, 3)
+
+	created := spans[0]
+
+	assert.Equal(t, "sqs.CreateQueue", created.Name())
+	assert.Equal(t, trace.SpanKindClient, created.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), created.Parent().SpanID())
+	assert.Contains(t, created.Attributes(), attribute.String(RPCMethodAttribute, "CreateQueue"))
+	assert.Contains(t, created.Attributes(), attribute.Int(StatusCodeAttribute, 200))
+	assert.Equal(t, codes.Unset, created.Status().Code)
+
+	failed := spans[1]
+
+	assert.Equal(t, "sqs.GetQueueUrl", failed.Name())
+	assert.Equal(t, codes.Error, failed.Status().Code)
+	assert.Contains(t, failed.Attributes(), attribute.String(ErrorCodeAttribute, "AWS.SimpleQueueService.NonExistentQueue"))
+	assert.Len(t, failed.Events(), 1)
+
+}
+
+func TestEnd(t *testing.T) {
+
+	tracer, recorder := newTestTracer()
+
+	_, span := tracer.start(context.Background(), "some_span")
+
+	end(span, awserr.New("some_code", "some_message", nil))
+
+	spans := recorder.Ended()
+
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), attribute.String(ErrorCodeAttribute, "some_code"))
+
+}
+
+func newTestTracer() (*Tracer, *tracetest.SpanRecorder) {
+
+	recorder := tracetest.NewSpanRecorder()
+
+	tracer := New(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+		WithPropagator(propagation.TraceContext{}),
+	)
+
+	return tracer, recorder
+
+}
+
+func newTestSession(t *testing.T, tracer *Tracer) *pkgAws.Session {
+
+	t.Helper()
+
+	in, err := pkgAws.NewSessionInput(
+		fake.Region,
+		pkgAws.WithStaticCredentials(fake.AccessKeyID, fake.SecretAccessKey, ""),
+		pkgAws.WithMiddleware(tracer.Middleware()),
+	)
+
+	assert.NoError(t, err)
+
+	awsSvc, err := pkgAws.New(in)
+
+	assert.NoError(t, err)
+
+	return awsSvc
+
+}
+
+func newSQSSvc(t *testing.T, srv *fake.Server, tracer *Tracer) *sqs.SQS {
+
+	t.Helper()
+
+	sqsSvc, err := sqs.New(newTestSession(t, tracer), srv.URL)
+
+	assert.NoError(t, err)
+
+	return sqsSvc
+
+}
@@ -1,23 +1,212 @@
 package aws
 
-import "errors"
+import (
+	"time"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
 
 // SessionInput contains the input to be passed to New
 type SessionInput struct {
-	region string
+	region            string
+	staticCredentials *staticCredentials
+	profile           string
+	sharedConfigFiles []string
+	webIdentity       *webIdentityRole
+	assumeRole        *assumeRole
+	middlewares       []Middleware
+	retryPolicy       *RetryPolicy
+	localStack        *localStack
+}
+
+// SessionOption sets an optional parameter on a *SessionInput
+type SessionOption func(*SessionInput) error
+
+// staticCredentials contains a fixed set of keys
+type staticCredentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+// webIdentityRole contains the parameters used to exchange a web identity token for a role
+type webIdentityRole struct {
+	roleARN         string
+	tokenFile       string
+	roleSessionName string
+}
+
+// assumeRole contains the parameters used to assume a role on top of the resolved credentials
+type assumeRole struct {
+	roleARN         string
+	roleSessionName string
+	externalID      string
+	duration        time.Duration
 }
 
-// NewSessionInput returns a new *SessionInput
-func NewSessionInput(region string) (*SessionInput, error) {
+// NewSessionInput returns a new *SessionInput.
+// Credentials are taken from the SDK default chain unless one of
+// WithStaticCredentials, WithSharedConfigProfile or WithWebIdentity is passed.
+// WithAssumeRole can be combined with any of them.
+// Local mode is enabled by WithLocalStack or, when it is not passed, by LocalStackEnv.
+func NewSessionInput(region string, opts ...SessionOption) (*SessionInput, error) {
 
 	if region == "" {
-		return nil, errors.New(ErrNoRegionProvided)
+		return nil, intErr.NewValidationError(ErrNoRegionProvided, "")
 	}
 
 	svc := &SessionInput{
 		region: region,
 	}
 
+	for _, opt := range opts {
+		if err := opt(svc); err != nil {
+			return nil, err
+		}
+	}
+
+	if svc.localStack == nil {
+		local, err := localStackFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		svc.localStack = local
+	}
+
+	if svc.credentialSources() > 1 {
+		return nil, intErr.NewValidationError(ErrMultipleCredentialSources, "")
+	}
+
 	return svc, nil
 
 }
+
+// WithStaticCredentials makes the session sign requests with the given keys.
+// sessionToken is optional
+func WithStaticCredentials(accessKeyID, secretAccessKey, sessionToken string) SessionOption {
+	return func(in *SessionInput) error {
+
+		if accessKeyID == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, AccessKeyID)
+		}
+		if secretAccessKey == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, SecretAccessKey)
+		}
+
+		in.staticCredentials = &staticCredentials{
+			accessKeyID:     accessKeyID,
+			secretAccessKey: secretAccessKey,
+			sessionToken:    sessionToken,
+		}
+
+		return nil
+
+	}
+}
+
+// WithSharedConfigProfile makes the session load credentials and settings from a named
+// shared config profile. files optionally overrides the shared config files to be read
+func WithSharedConfigProfile(profile string, files ...string) SessionOption {
+	return func(in *SessionInput) error {
+
+		if profile == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, Profile)
+		}
+
+		in.profile = profile
+		in.sharedConfigFiles = files
+
+		return nil
+
+	}
+}
+
+// WithWebIdentity makes the session exchange the token stored in tokenFile for
+// temporary credentials of roleARN. roleSessionName is optional
+func WithWebIdentity(roleARN, tokenFile, roleSessionName string) SessionOption {
+	return func(in *SessionInput) error {
+
+		if roleARN == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, RoleARN)
+		}
+		if tokenFile == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, TokenFile)
+		}
+
+		in.webIdentity = &webIdentityRole{
+			roleARN:         roleARN,
+			tokenFile:       tokenFile,
+			roleSessionName: roleSessionName,
+		}
+
+		return nil
+
+	}
+}
+
+// WithAssumeRole makes the session assume roleARN using the credentials resolved
+// by the other options. roleSessionName, externalID and duration are optional
+func WithAssumeRole(roleARN, roleSessionName, externalID string, duration time.Duration) SessionOption {
+	return func(in *SessionInput) error {
+
+		if roleARN == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, RoleARN)
+		}
+
+		in.assumeRole = &assumeRole{
+			roleARN:         roleARN,
+			roleSessionName: roleSessionName,
+			externalID:      externalID,
+			duration:        duration,
+		}
+
+		return nil
+
+	}
+}
+
+// WithMiddleware registers m on the session, so that it applies to every client built from it
+func WithMiddleware(m ...Middleware) SessionOption {
+	return func(in *SessionInput) error {
+
+		in.middlewares = append(in.middlewares, m...)
+
+		return nil
+
+	}
+}
+
+// WithDefaultRetryPolicy makes every client built from the session retry failed requests
+// according to policy, unless overridden by the WithRetryPolicy or WithRetryer client options
+func WithDefaultRetryPolicy(policy *RetryPolicy) SessionOption {
+	return func(in *SessionInput) error {
+
+		if policy == nil {
+			return intErr.NewValidationError(ErrEmptyParameter, Policy)
+		}
+
+		in.retryPolicy = policy
+
+		return nil
+
+	}
+}
+
+// credentialSources returns how many mutually exclusive credential sources have been set
+func (in *SessionInput) credentialSources() int {
+
+	n := 0
+
+	if in.staticCredentials != nil {
+		n++
+	}
+	if in.profile != "" {
+		n++
+	}
+	if in.webIdentity != nil {
+		n++
+	}
+
+	return n
+
+}
@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,3 +21,91 @@ func TestNewSessionInput(t *testing.T) {
 	assert.Equal(t, ErrNoRegionProvided, err.Error())
 
 }
+
+func TestNewSessionInput_Options(t *testing.T) {
+
+	region := "some_region"
+
+	out, err := NewSessionInput(
+		region,
+		WithStaticCredentials("some_key", "some_secret", ""),
+		WithAssumeRole("some_role", "some_session", "", 0),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_key", out.staticCredentials.accessKeyID)
+	assert.Equal(t, "some_role", out.assumeRole.roleARN)
+
+	out, err = NewSessionInput(region, WithSharedConfigProfile("some_profile", "some_file"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_profile", out.profile)
+	assert.Equal(t, []string{"some_file"}, out.sharedConfigFiles)
+
+	out, err = NewSessionInput(region, WithWebIdentity("some_role", "some_token_file", ""))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_token_file", out.webIdentity.tokenFile)
+
+	out, err = NewSessionInput(region, WithMiddleware(HeaderMiddleware("k", "v"), HeaderMiddleware("k", "v")))
+
+	assert.NoError(t, err)
+	assert.Len(t, out.middlewares, 2)
+
+	policy, err := NewRetryPolicy(3)
+
+	assert.NoError(t, err)
+
+	out, err = NewSessionInput(region, WithDefaultRetryPolicy(policy))
+
+	assert.NoError(t, err)
+	assert.Equal(t, policy, out.retryPolicy)
+
+	_, err = NewSessionInput(region, WithDefaultRetryPolicy(nil))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrEmptyParameter)
+
+	_, err = NewSessionInput(
+		region,
+		WithStaticCredentials("some_key", "some_secret", ""),
+		WithSharedConfigProfile("some_profile"),
+	)
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrMultipleCredentialSources, err.Error())
+
+	_, err = NewSessionInput(region, WithStaticCredentials("", "some_secret", ""))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrEmptyParameter)
+
+	_, err = NewSessionInput(region, WithSharedConfigProfile(""))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrEmptyParameter)
+
+	_, err = NewSessionInput(region, WithWebIdentity("some_role", "", ""))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrEmptyParameter)
+
+	_, err = NewSessionInput(region, WithAssumeRole("", "", "", 0))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrEmptyParameter)
+
+}
+
+func TestNewSessionInput_ValidationError(t *testing.T) {
+
+	_, err := NewSessionInput("some_region", WithSharedConfigProfile(""))
+
+	var vErr *ValidationError
+
+	assert.True(t, errors.As(err, &vErr))
+	assert.Equal(t, ErrEmptyParameter, vErr.Code)
+	assert.Equal(t, Profile, vErr.Parameter)
+	assert.True(t, errors.Is(err, &ValidationError{Code: ErrEmptyParameter}))
+
+}
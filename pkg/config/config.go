@@ -0,0 +1,144 @@
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+// Format is the encoding of a configuration file
+type Format string
+
+const (
+
+	// JSON is the format of .json files
+	JSON Format = "json"
+
+	// YAML is the format of .yaml and .yml files
+	YAML Format = "yaml"
+)
+
+// Configuration contains parameters used in multiple parts of the code base
+type Configuration struct {
+	Region      string      `json:"region" yaml:"region"`
+	SNS         SNS         `json:"SNS" yaml:"SNS"`
+	DynamoDB    DynamoDB    `json:"DynamoDB" yaml:"DynamoDB"`
+	S3          S3          `json:"S3" yaml:"S3"`
+	Rekognition Rekognition `json:"Rekognition" yaml:"Rekognition"`
+	SQS         SQS         `json:"SQS" yaml:"SQS"`
+}
+
+// DynamoDB contains parameters for DynamoDB
+type DynamoDB struct {
+	Endpoint     string `json:"endpoint" yaml:"endpoint"`
+	PkgTableName string `json:"pkg_table_name" yaml:"pkg_table_name"`
+	CmdTableName string `json:"cmd_table_name" yaml:"cmd_table_name"`
+	PrimaryKey   string `json:"primary_key" yaml:"primary_key"`
+}
+
+// SNS contains parameters for SNS
+type SNS struct {
+	Endpoint  string `json:"endpoint" yaml:"endpoint"`
+	TargetArn string `json:"target_arn" yaml:"target_arn"`
+}
+
+// S3 contains parameters for S3
+type S3 struct {
+	Endpoint    string `json:"endpoint" yaml:"endpoint"`
+	Bucket      string `json:"bucket" yaml:"bucket"`
+	SourceImage string `json:"source_image" yaml:"source_image"`
+}
+
+// Rekognition contains parameters for Rekognition
+type Rekognition struct {
+	Region       string `json:"region" yaml:"region"`
+	CompareFaces struct {
+		Similarity  float64 `json:"similarity" yaml:"similarity"`
+		SourceImage string  `json:"source_image" yaml:"source_image"`
+		TargetImage string  `json:"target_image" yaml:"target_image"`
+	} `json:"compare_faces" yaml:"compare_faces"`
+	DetectFaces struct {
+		SourceImage string `json:"source_image" yaml:"source_image"`
+	} `json:"detect_faces" yaml:"detect_faces"`
+	DetectText struct {
+		SourceImage string `json:"source_image" yaml:"source_image"`
+	} `json:"detect_text" yaml:"detect_text"`
+}
+
+// SQS contains parameters for SQS
+type SQS struct {
+	Endpoint  string `json:"endpoint" yaml:"endpoint"`
+	QueueUrl  string `json:"queue_url" yaml:"queue_url"`
+	QueueName string `json:"queue_name" yaml:"queue_name"`
+}
+
+// Load returns the Configuration read from the file at path, overridden by
+// the AWSB_* environment variables and validated.
+// The file format is inferred from its extension. When path is empty,
+// the configuration is read from the environment only
+func Load(path string) (*Configuration, error) {
+
+	cfg := &Configuration{}
+
+	if path != "" {
+
+		format, err := formatOf(path)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := Unmarshal(data, format, cfg); err != nil {
+			return nil, err
+		}
+
+	}
+
+	if err := cfg.ApplyEnv(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+
+}
+
+// Unmarshal decodes data, encoded in format, in cfg
+func Unmarshal(data []byte, format Format, cfg *Configuration) error {
+
+	switch format {
+	case JSON:
+		return json.Unmarshal(data, cfg)
+	case YAML:
+		return yaml.Unmarshal(data, cfg)
+	}
+
+	return intErr.NewValidationError(ErrUnsupportedFormat, FileFormat)
+
+}
+
+// formatOf returns the Format of the file at path
+func formatOf(path string) (Format, error) {
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return JSON, nil
+	case ".yaml", ".yml":
+		return YAML, nil
+	}
+
+	return "", intErr.NewValidationError(ErrUnsupportedFormat, Path)
+
+}
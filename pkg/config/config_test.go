@@ -0,0 +1,137 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	someJSONConfiguration = `{
+  "region" : "some_region",
+  "S3" : {
+    "endpoint" : "http://localhost:4572",
+    "bucket" : "some_bucket"
+  },
+  "Rekognition" : {
+    "region" : "some_region",
+    "compare_faces" : {
+      "similarity" : 70.0
+    }
+  }
+}`
+	someYAMLConfiguration = `
+region: some_region
+S3:
+  endpoint: http://localhost:4572
+  bucket: some_bucket
+Rekognition:
+  region: some_region
+  compare_faces:
+    similarity: 70.0
+`
+)
+
+func TestLoad(t *testing.T) {
+
+	for name, content := range map[string]string{
+		"configuration.json": someJSONConfiguration,
+		"configuration.yaml": someYAMLConfiguration,
+		"configuration.yml":  someYAMLConfiguration,
+	} {
+
+		cfg, err := Load(writeFile(t, name, content))
+
+		assert.NoError(t, err, name)
+		assert.Equal(t, "some_region", cfg.Region)
+		assert.Equal(t, "http://localhost:4572", cfg.S3.Endpoint)
+		assert.Equal(t, "some_bucket", cfg.S3.Bucket)
+		assert.Equal(t, 70.0, cfg.Rekognition.CompareFaces.Similarity)
+
+	}
+
+}
+
+func TestLoad_Env(t *testing.T) {
+
+	t.Setenv(EnvName("S3", "bucket"), "some_other_bucket")
+	t.Setenv(EnvName("Rekognition", "compare_faces", "similarity"), "90")
+
+	cfg, err := Load(writeFile(t, "configuration.json", someJSONConfiguration))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_other_bucket", cfg.S3.Bucket)
+	assert.Equal(t, 90.0, cfg.Rekognition.CompareFaces.Similarity)
+
+	t.Setenv(EnvName("region"), "some_env_region")
+	t.Setenv(EnvName("Rekognition", "compare_faces", "similarity"), "")
+
+	_, err = Load("")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidParameter)
+
+	t.Setenv(EnvName("Rekognition", "compare_faces", "similarity"), "1")
+	t.Setenv(EnvName("Rekognition", "region"), "some_env_region")
+
+	cfg, err = Load("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_env_region", cfg.Region)
+
+}
+
+func TestLoad_Error(t *testing.T) {
+
+	_, err := Load(writeFile(t, "configuration.toml", "region = \"some_region\""))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrUnsupportedFormat)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+
+	assert.Error(t, err)
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = Load(writeFile(t, "configuration.json", "{"))
+
+	assert.Error(t, err)
+
+	_, err = Load(writeFile(t, "configuration.json", `{"S3":{"bucket":"some_bucket"}}`))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), Region)
+
+}
+
+func TestUnmarshal(t *testing.T) {
+
+	var cfg Configuration
+
+	err := Unmarshal([]byte(someYAMLConfiguration), YAML, &cfg)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_bucket", cfg.S3.Bucket)
+
+	err = Unmarshal([]byte(someYAMLConfiguration), Format("toml"), &cfg)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrUnsupportedFormat)
+	assert.Contains(t, err.Error(), FileFormat)
+
+}
+
+func writeFile(t *testing.T, name, content string) string {
+
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0600))
+
+	return path
+
+}
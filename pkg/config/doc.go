@@ -0,0 +1,11 @@
+// Package config loads the configuration of the bindings in layers:
+// a JSON or YAML file given by its path, then AWSB_* environment variables,
+// and finally validates the required parameters of every configured service.
+//
+// Every field of Configuration can be overridden by an environment variable named
+// after its json path, upper cased and joined by underscores, like:
+//
+//	AWSB_REGION=eu-west-1
+//	AWSB_S3_ENDPOINT=http://localhost:4572
+//	AWSB_REKOGNITION_COMPARE_FACES_SIMILARITY=80
+package config
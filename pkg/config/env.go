@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+// EnvPrefix is the prefix of the environment variables overriding a Configuration
+const EnvPrefix = "AWSB"
+
+// ApplyEnv overrides the fields of cfg with the AWSB_* environment variables that are set
+func (cfg *Configuration) ApplyEnv() error {
+	return applyEnv(reflect.ValueOf(cfg).Elem(), EnvPrefix, os.LookupEnv)
+}
+
+// EnvName returns the name of the environment variable overriding the field with
+// the given json path, like EnvName("S3", "endpoint") == "AWSB_S3_ENDPOINT"
+func EnvName(path ...string) string {
+	return strings.ToUpper(strings.Join(append([]string{EnvPrefix}, path...), "_"))
+}
+
+// applyEnv sets every field of v whose environment variable, named after prefix
+// and its json tag, is returned by lookup
+func applyEnv(v reflect.Value, prefix string, lookup func(string) (string, bool)) error {
+
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+
+		tag := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		name := prefix + "_" + strings.ToUpper(tag)
+		field := v.Field(i)
+
+		if field.Kind() == reflect.Struct {
+			if err := applyEnv(field, name, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, ok := lookup(name)
+		if !ok {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Float64:
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return intErr.NewValidationError(ErrInvalidParameter, name)
+			}
+			field.SetFloat(f)
+		case reflect.Int, reflect.Int64:
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return intErr.NewValidationError(ErrInvalidParameter, name)
+			}
+			field.SetInt(n)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return intErr.NewValidationError(ErrInvalidParameter, name)
+			}
+			field.SetBool(b)
+		}
+
+	}
+
+	return nil
+
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvName(t *testing.T) {
+
+	assert.Equal(t, "AWSB_REGION", EnvName("region"))
+	assert.Equal(t, "AWSB_DYNAMODB_PKG_TABLE_NAME", EnvName("DynamoDB", "pkg_table_name"))
+
+}
+
+func TestApplyEnv(t *testing.T) {
+
+	env := map[string]string{
+		"AWSB_REGION":                               "some_region",
+		"AWSB_SQS_QUEUE_URL":                        "some_queue_url",
+		"AWSB_DYNAMODB_CMD_TABLE_NAME":              "some_table",
+		"AWSB_REKOGNITION_DETECT_TEXT_SOURCE_IMAGE": "some_image",
+		"AWSB_REKOGNITION_COMPARE_FACES_SIMILARITY": "12.5",
+	}
+
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+
+	cfg := &Configuration{
+		Region: "some_other_region",
+		S3:     S3{Bucket: "some_bucket"},
+	}
+
+	err := applyEnv(reflect.ValueOf(cfg).Elem(), EnvPrefix, lookup)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some_region", cfg.Region)
+	assert.Equal(t, "some_bucket", cfg.S3.Bucket)
+	assert.Equal(t, "some_queue_url", cfg.SQS.QueueUrl)
+	assert.Equal(t, "some_table", cfg.DynamoDB.CmdTableName)
+	assert.Equal(t, "some_image", cfg.Rekognition.DetectText.SourceImage)
+	assert.Equal(t, 12.5, cfg.Rekognition.CompareFaces.Similarity)
+
+	env["AWSB_REKOGNITION_COMPARE_FACES_SIMILARITY"] = "some_similarity"
+
+	err = applyEnv(reflect.ValueOf(cfg).Elem(), EnvPrefix, lookup)
+
+	assert.Error(t, err)
+	assert.Equal(t, ErrInvalidParameter+" : AWSB_REKOGNITION_COMPARE_FACES_SIMILARITY", err.Error())
+
+}
@@ -0,0 +1,13 @@
+package config
+
+const (
+
+	// ErrEmptyParameter is used when a required parameter is empty
+	ErrEmptyParameter = "EmptyParameter"
+
+	// ErrInvalidParameter is used when a parameter cannot be parsed
+	ErrInvalidParameter = "InvalidParameter"
+
+	// ErrUnsupportedFormat is used when a configuration file is neither json nor yaml
+	ErrUnsupportedFormat = "UnsupportedFormat"
+)
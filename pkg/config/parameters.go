@@ -0,0 +1,31 @@
+package config
+
+const (
+
+	// Path represents the parameter named path
+	Path = "path"
+	// FileFormat represents the parameter named format
+	FileFormat = "format"
+	// Region represents the parameter named region
+	Region = "region"
+	// SNSEndpoint represents the parameter named SNS.endpoint
+	SNSEndpoint = "SNS.endpoint"
+	// SNSTargetArn represents the parameter named SNS.target_arn
+	SNSTargetArn = "SNS.target_arn"
+	// DynamoDBEndpoint represents the parameter named DynamoDB.endpoint
+	DynamoDBEndpoint = "DynamoDB.endpoint"
+	// DynamoDBPkgTableName represents the parameter named DynamoDB.pkg_table_name
+	DynamoDBPkgTableName = "DynamoDB.pkg_table_name"
+	// DynamoDBPrimaryKey represents the parameter named DynamoDB.primary_key
+	DynamoDBPrimaryKey = "DynamoDB.primary_key"
+	// S3Endpoint represents the parameter named S3.endpoint
+	S3Endpoint = "S3.endpoint"
+	// S3Bucket represents the parameter named S3.bucket
+	S3Bucket = "S3.bucket"
+	// RekognitionRegion represents the parameter named Rekognition.region
+	RekognitionRegion = "Rekognition.region"
+	// SQSEndpoint represents the parameter named SQS.endpoint
+	SQSEndpoint = "SQS.endpoint"
+	// SQSQueueName represents the parameter named SQS.queue_name
+	SQSQueueName = "SQS.queue_name"
+)
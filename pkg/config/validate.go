@@ -0,0 +1,79 @@
+package config
+
+import (
+	"net/url"
+
+	intErr "github.com/easynetwork/aws-sdk-go-bindings/internal/error"
+)
+
+// Validate returns a validation error if the region or a required parameter of a
+// configured service is missing, or if an endpoint is not a valid url.
+// A service is configured when any of its parameters is set
+func (cfg *Configuration) Validate() error {
+
+	if cfg.Region == "" {
+		return intErr.NewValidationError(ErrEmptyParameter, Region)
+	}
+
+	if cfg.SNS != (SNS{}) {
+		if err := validateEndpoint(cfg.SNS.Endpoint, SNSEndpoint); err != nil {
+			return err
+		}
+		if cfg.SNS.TargetArn == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, SNSTargetArn)
+		}
+	}
+
+	if cfg.DynamoDB != (DynamoDB{}) {
+		if err := validateEndpoint(cfg.DynamoDB.Endpoint, DynamoDBEndpoint); err != nil {
+			return err
+		}
+		if cfg.DynamoDB.PkgTableName == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, DynamoDBPkgTableName)
+		}
+		if cfg.DynamoDB.PrimaryKey == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, DynamoDBPrimaryKey)
+		}
+	}
+
+	if cfg.S3 != (S3{}) {
+		if err := validateEndpoint(cfg.S3.Endpoint, S3Endpoint); err != nil {
+			return err
+		}
+		if cfg.S3.Bucket == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, S3Bucket)
+		}
+	}
+
+	if cfg.Rekognition != (Rekognition{}) && cfg.Rekognition.Region == "" {
+		return intErr.NewValidationError(ErrEmptyParameter, RekognitionRegion)
+	}
+
+	if cfg.SQS != (SQS{}) {
+		if err := validateEndpoint(cfg.SQS.Endpoint, SQSEndpoint); err != nil {
+			return err
+		}
+		if cfg.SQS.QueueName == "" {
+			return intErr.NewValidationError(ErrEmptyParameter, SQSQueueName)
+		}
+	}
+
+	return nil
+
+}
+
+// validateEndpoint returns a validation error if endpoint is set and is not an absolute url
+func validateEndpoint(endpoint, parameter string) error {
+
+	if endpoint == "" {
+		return nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return intErr.NewValidationError(ErrInvalidParameter, parameter)
+	}
+
+	return nil
+
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfiguration_Validate(t *testing.T) {
+
+	valid := func() *Configuration {
+
+		cfg := &Configuration{
+			Region:   "some_region",
+			SNS:      SNS{TargetArn: "some_arn"},
+			DynamoDB: DynamoDB{PkgTableName: "some_table", PrimaryKey: "some_key"},
+			S3:       S3{Endpoint: "http://localhost:4572", Bucket: "some_bucket"},
+			SQS:      SQS{QueueName: "some_queue"},
+		}
+		cfg.Rekognition.Region = "some_region"
+
+		return cfg
+
+	}
+
+	assert.NoError(t, valid().Validate())
+	assert.NoError(t, (&Configuration{Region: "some_region"}).Validate())
+
+	cases := map[string]func(*Configuration){
+		Region:               func(cfg *Configuration) { cfg.Region = "" },
+		SNSTargetArn:         func(cfg *Configuration) { cfg.SNS = SNS{Endpoint: "http://localhost:4575"} },
+		SNSEndpoint:          func(cfg *Configuration) { cfg.SNS.Endpoint = "localhost" },
+		DynamoDBPkgTableName: func(cfg *Configuration) { cfg.DynamoDB.PkgTableName = "" },
+		DynamoDBPrimaryKey:   func(cfg *Configuration) { cfg.DynamoDB.PrimaryKey = "" },
+		DynamoDBEndpoint:     func(cfg *Configuration) { cfg.DynamoDB.Endpoint = "://" },
+		S3Bucket:             func(cfg *Configuration) { cfg.S3.Bucket = "" },
+		S3Endpoint:           func(cfg *Configuration) { cfg.S3.Endpoint = "localhost:4572" },
+		RekognitionRegion: func(cfg *Configuration) {
+			cfg.Rekognition = Rekognition{}
+			cfg.Rekognition.CompareFaces.Similarity = 70
+		},
+		SQSQueueName: func(cfg *Configuration) { cfg.SQS = SQS{QueueUrl: "some_queue_url"} },
+		SQSEndpoint:  func(cfg *Configuration) { cfg.SQS.Endpoint = "http://" },
+	}
+
+	for parameter, invalidate := range cases {
+
+		cfg := valid()
+		invalidate(cfg)
+
+		err := cfg.Validate()
+
+		assert.Error(t, err, parameter)
+		assert.Contains(t, err.Error(), parameter)
+
+	}
+
+}
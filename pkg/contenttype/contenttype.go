@@ -0,0 +1,381 @@
+package contenttype
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// SniffLen is the number of leading bytes looked at by Detect, the following ones are ignored
+	SniffLen = 3072
+
+	// Default is the content type of content that could not be resolved
+	Default = "application/octet-stream"
+
+	// JPEG is the content type of JPEG images
+	JPEG = "image/jpeg"
+	// PNG is the content type of PNG images
+	PNG = "image/png"
+	// GIF is the content type of GIF images
+	GIF = "image/gif"
+	// WebP is the content type of WebP images
+	WebP = "image/webp"
+	// HEIC is the content type of HEIF images encoded with HEVC
+	HEIC = "image/heic"
+	// HEIF is the content type of HEIF images
+	HEIF = "image/heif"
+	// AVIF is the content type of HEIF images encoded with AV1
+	AVIF = "image/avif"
+	// SVG is the content type of SVG images
+	SVG = "image/svg+xml"
+	// MP4 is the content type of MPEG-4 videos
+	MP4 = "video/mp4"
+	// QuickTime is the content type of QuickTime videos
+	QuickTime = "video/quicktime"
+	// WebM is the content type of WebM videos
+	WebM = "video/webm"
+	// Matroska is the content type of Matroska videos
+	Matroska = "video/x-matroska"
+	// AVI is the content type of AVI videos
+	AVI = "video/x-msvideo"
+	// PDF is the content type of PDF documents
+	PDF = "application/pdf"
+	// ZIP is the content type of ZIP archives
+	ZIP = "application/zip"
+	// DOCX is the content type of Word documents
+	DOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	// XLSX is the content type of Excel workbooks
+	XLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	// PPTX is the content type of PowerPoint presentations
+	PPTX = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+	// JSON is the content type of JSON documents
+	JSON = "application/json"
+	// CSV is the content type of comma separated values
+	CSV = "text/csv"
+	// Text is the content type of plain text
+	Text = "text/plain; charset=utf-8"
+)
+
+// extensions maps lower case file extensions to their content type
+var extensions = map[string]string{
+	".jpg":  JPEG,
+	".jpeg": JPEG,
+	".png":  PNG,
+	".gif":  GIF,
+	".webp": WebP,
+	".heic": HEIC,
+	".heif": HEIF,
+	".avif": AVIF,
+	".bmp":  "image/bmp",
+	".tif":  "image/tiff",
+	".tiff": "image/tiff",
+	".ico":  "image/x-icon",
+	".svg":  SVG,
+	".mp4":  MP4,
+	".m4v":  "video/x-m4v",
+	".mov":  QuickTime,
+	".webm": WebM,
+	".mkv":  Matroska,
+	".avi":  AVI,
+	".mp3":  "audio/mpeg",
+	".m4a":  "audio/mp4",
+	".wav":  "audio/wav",
+	".ogg":  "audio/ogg",
+	".pdf":  PDF,
+	".zip":  ZIP,
+	".gz":   "application/gzip",
+	".tar":  "application/x-tar",
+	".doc":  "application/msword",
+	".xls":  "application/vnd.ms-excel",
+	".ppt":  "application/vnd.ms-powerpoint",
+	".docx": DOCX,
+	".xlsx": XLSX,
+	".pptx": PPTX,
+	".json": JSON,
+	".csv":  CSV,
+	".txt":  Text,
+	".md":   "text/markdown; charset=utf-8",
+	".html": "text/html; charset=utf-8",
+	".htm":  "text/html; charset=utf-8",
+	".css":  "text/css; charset=utf-8",
+	".js":   "application/javascript",
+	".xml":  "application/xml",
+}
+
+// ftypBrands maps the brands of ISO base media files to their content type, by priority
+var ftypBrands = []struct {
+	brands      []string
+	contentType string
+}{
+	{[]string{"heic", "heix", "hevc", "hevx", "heim", "heis"}, HEIC},
+	{[]string{"avif", "avis"}, AVIF},
+	{[]string{"mif1", "msf1"}, HEIF},
+	{[]string{"qt  "}, QuickTime},
+	{[]string{"M4V ", "M4VH", "M4VP"}, "video/x-m4v"},
+	{[]string{"M4A ", "M4B "}, "audio/mp4"},
+}
+
+// officeDirs maps the directory of the main part of Office Open XML documents to their content type
+var officeDirs = []struct {
+	dir         string
+	contentType string
+}{
+	{"word/", DOCX},
+	{"xl/", XLSX},
+	{"ppt/", PPTX},
+}
+
+// Resolve returns the content type of some content given its first bytes and the names it is known by.
+// A non empty override is returned as is, then the extension of the first name found in the table of
+// known extensions decides, and the content type is otherwise detected from head as Detect does
+func Resolve(override string, head []byte, names ...string) string {
+
+	if override != "" {
+		return override
+	}
+
+	for _, name := range names {
+		if contentType := ByExtension(name); contentType != "" {
+			return contentType
+		}
+	}
+
+	return Detect(head)
+
+}
+
+// ByExtension returns the content type of the extension of name, or an empty string if it is not known
+func ByExtension(name string) string {
+	return extensions[strings.ToLower(path.Ext(name))]
+}
+
+// Detect returns the content type of some content given its first bytes, or Default if it cannot be
+// detected. Besides the signatures known to http.DetectContentType, it recognizes WebP, HEIC, HEIF and
+// AVIF images, MP4, QuickTime, WebM and Matroska videos, Office Open XML documents, SVG, JSON and CSV
+func Detect(head []byte) string {
+
+	if len(head) > SniffLen {
+		head = head[:SniffLen]
+	}
+
+	if len(head) == 0 {
+		return Default
+	}
+
+	if contentType := detectBinary(head); contentType != "" {
+		return contentType
+	}
+
+	if contentType := detectText(head); contentType != "" {
+		return contentType
+	}
+
+	return http.DetectContentType(head)
+
+}
+
+// detectBinary returns the content type of head from the signature of binary formats,
+// or an empty string if none matches
+func detectBinary(head []byte) string {
+
+	switch {
+	case bytes.HasPrefix(head, []byte("\xFF\xD8\xFF")):
+		return JPEG
+	case bytes.HasPrefix(head, []byte("\x89PNG\r\n\x1A\n")):
+		return PNG
+	case bytes.HasPrefix(head, []byte("GIF87a")), bytes.HasPrefix(head, []byte("GIF89a")):
+		return GIF
+	case bytes.HasPrefix(head, []byte("%PDF-")):
+		return PDF
+	case len(head) >= 12 && bytes.HasPrefix(head, []byte("RIFF")):
+		return detectRIFF(head[8:12])
+	case len(head) >= 12 && bytes.Equal(head[4:8], []byte("ftyp")):
+		return detectFtyp(head)
+	case bytes.HasPrefix(head, []byte("\x1A\x45\xDF\xA3")):
+		if bytes.Contains(head, []byte("webm")) {
+			return WebM
+		}
+		return Matroska
+	case bytes.HasPrefix(head, []byte("PK\x03\x04")):
+		return detectZIP(head)
+	}
+
+	return ""
+
+}
+
+// detectRIFF returns the content type of a RIFF container given its form type
+func detectRIFF(form []byte) string {
+
+	switch string(form) {
+	case "WEBP":
+		return WebP
+	case "AVI ":
+		return AVI
+	case "WAVE":
+		return "audio/wav"
+	}
+
+	return ""
+
+}
+
+// detectFtyp returns the content type of an ISO base media file from the major and compatible
+// brands of its ftyp box, by priority. Files without any specific brand are MPEG-4 videos
+func detectFtyp(head []byte) string {
+
+	size := int(head[0])<<24 | int(head[1])<<16 | int(head[2])<<8 | int(head[3])
+	if size < 16 {
+		size = 16
+	}
+	if size > len(head) {
+		size = len(head)
+	}
+
+	// The major brand is followed by the minor version, then by the compatible brands
+	brands := []string{string(head[8:12])}
+	for i := 16; i+4 <= size; i += 4 {
+		brands = append(brands, string(head[i:i+4]))
+	}
+
+	for _, b := range ftypBrands {
+		for _, brand := range b.brands {
+			for _, got := range brands {
+				if got == brand {
+					return b.contentType
+				}
+			}
+		}
+	}
+
+	return MP4
+
+}
+
+// detectZIP returns the content type of a ZIP archive, telling Office Open XML documents apart
+// by the names of their entries found in head
+func detectZIP(head []byte) string {
+
+	if !bytes.Contains(head, []byte("[Content_Types].xml")) && !bytes.Contains(head, []byte("_rels/.rels")) {
+		return ZIP
+	}
+
+	for _, d := range officeDirs {
+		if bytes.Contains(head, []byte(d.dir)) {
+			return d.contentType
+		}
+	}
+
+	return ZIP
+
+}
+
+// detectText returns the content type of head when it is SVG, JSON or CSV text,
+// or an empty string otherwise
+func detectText(head []byte) string {
+
+	if !utf8.Valid(head[:lastRuneStart(head)]) {
+		return ""
+	}
+
+	trimmed := bytes.TrimLeft(head, " \t\r\n\xEF\xBB\xBF")
+	if len(trimmed) == 0 {
+		return ""
+	}
+
+	switch {
+	case isSVG(trimmed):
+		return SVG
+	case isJSON(trimmed):
+		return JSON
+	case isCSV(trimmed):
+		return CSV
+	}
+
+	return ""
+
+}
+
+// lastRuneStart returns the index of the last rune start of b, so that a rune cut by
+// the end of the sniffed bytes does not make valid text invalid
+func lastRuneStart(b []byte) int {
+
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			return i
+		}
+	}
+
+	return len(b)
+
+}
+
+// isSVG reports whether head is an XML document whose root element is svg
+func isSVG(head []byte) bool {
+
+	if !bytes.HasPrefix(head, []byte("<")) {
+		return false
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(head))
+	dec.Strict = false
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		if el, ok := tok.(xml.StartElement); ok {
+			return el.Name.Local == "svg"
+		}
+	}
+
+}
+
+// isJSON reports whether head is a JSON object or array, possibly cut by the end of the sniffed bytes
+func isJSON(head []byte) bool {
+
+	if head[0] != '{' && head[0] != '[' {
+		return false
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(head))
+
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			return true
+		}
+		if err != nil {
+			return err == io.ErrUnexpectedEOF
+		}
+	}
+
+}
+
+// isCSV reports whether the complete lines of head are at least two records
+// of the same number of comma separated fields, more than one
+func isCSV(head []byte) bool {
+
+	end := bytes.LastIndexByte(head, '\n')
+	if end < 0 {
+		return false
+	}
+
+	r := csv.NewReader(bytes.NewReader(head[:end+1]))
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return false
+	}
+
+	return len(records) >= 2 && len(records[0]) > 1
+
+}
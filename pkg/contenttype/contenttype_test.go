@@ -0,0 +1,123 @@
+package contenttype
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+
+	png := []byte("\x89PNG\r\n\x1A\nsome_image")
+
+	assert.Equal(t, "some/type", Resolve("some/type", png, "some.jpg"))
+	assert.Equal(t, JPEG, Resolve("", png, "some.JPG"))
+	assert.Equal(t, SVG, Resolve("", []byte("some_body"), "some_key", "some/file.svg"))
+	assert.Equal(t, PNG, Resolve("", png, "some_key", "some.unknown"))
+	assert.Equal(t, PNG, Resolve("", png))
+	assert.Equal(t, CSV, Resolve("", nil, "some.csv"))
+	assert.Equal(t, Default, Resolve("", nil))
+
+}
+
+func TestByExtension(t *testing.T) {
+
+	assert.Equal(t, JPEG, ByExtension("some/dir/some.jpeg"))
+	assert.Equal(t, HEIC, ByExtension("IMG_0001.HEIC"))
+	assert.Equal(t, XLSX, ByExtension("some.xlsx"))
+	assert.Equal(t, Text, ByExtension("some.txt"))
+	assert.Empty(t, ByExtension("some.unknown"))
+	assert.Empty(t, ByExtension("some_file"))
+	assert.Empty(t, ByExtension(""))
+
+}
+
+func TestDetect(t *testing.T) {
+
+	cases := map[string]struct {
+		head     []byte
+		expected string
+	}{
+		"jpeg":           {[]byte("\xFF\xD8\xFF\xE0\x00\x10JFIF"), JPEG},
+		"png":            {[]byte("\x89PNG\r\n\x1A\n\x00\x00\x00\x0DIHDR"), PNG},
+		"gif":            {[]byte("GIF89a\x01\x00\x01\x00"), GIF},
+		"webp":           {[]byte("RIFF\x24\x00\x00\x00WEBPVP8 "), WebP},
+		"avi":            {[]byte("RIFF\x24\x00\x00\x00AVI LIST"), AVI},
+		"heic":           {ftyp("heic", "mif1", "heic"), HEIC},
+		"heic brand":     {ftyp("mif1", "mif1", "heic"), HEIC},
+		"heif":           {ftyp("mif1", "mif1", "miaf"), HEIF},
+		"avif":           {ftyp("avif", "avif", "mif1", "miaf"), AVIF},
+		"mp4":            {ftyp("isom", "isom", "iso2", "avc1", "mp41"), MP4},
+		"quicktime":      {ftyp("qt  ", "qt  "), QuickTime},
+		"webm":           {[]byte("\x1A\x45\xDF\xA3\x9F\x42\x86\x81\x01\x42\x82\x84webm"), WebM},
+		"matroska":       {[]byte("\x1A\x45\xDF\xA3\x9F\x42\x86\x81\x01\x42\x82\x88matroska"), Matroska},
+		"pdf":            {[]byte("%PDF-1.7\n"), PDF},
+		"zip":            {zipArchive(t, "some_file.txt"), ZIP},
+		"docx":           {zipArchive(t, "[Content_Types].xml", "_rels/.rels", "word/document.xml"), DOCX},
+		"xlsx":           {zipArchive(t, "[Content_Types].xml", "_rels/.rels", "xl/workbook.xml"), XLSX},
+		"pptx":           {zipArchive(t, "[Content_Types].xml", "_rels/.rels", "ppt/presentation.xml"), PPTX},
+		"svg":            {[]byte(`<svg xmlns="http://www.w3.org/2000/svg" width="1" height="1"></svg>`), SVG},
+		"svg prolog":     {[]byte("<?xml version=\"1.0\"?>\n<!-- some comment -->\n<!DOCTYPE svg>\n<svg>"), SVG},
+		"xml":            {[]byte(`<?xml version="1.0"?><some_root></some_root>`), "text/xml; charset=utf-8"},
+		"html":           {[]byte("<html><body></body></html>"), "text/html; charset=utf-8"},
+		"json":           {[]byte(`{"some_key": ["some_value", 1, true]}`), JSON},
+		"json array":     {[]byte("\n  [1, 2, 3]"), JSON},
+		"json truncated": {[]byte(`{"some_key": "some_val`), JSON},
+		"not json":       {[]byte("[some_section]\nsome_key=some_value\n"), Text},
+		"csv":            {[]byte("name,age\nsome_name,42\nother_name,24"), CSV},
+		"csv one line":   {[]byte("name,age\n"), Text},
+		"csv uneven":     {[]byte("name,age\nsome_name\n"), Text},
+		"text":           {[]byte("some_body"), Text},
+		"binary":         {[]byte("\x00\x01\x02\x03"), Default},
+		"empty":          {nil, Default},
+	}
+
+	for name, c := range cases {
+		assert.Equal(t, c.expected, Detect(c.head), name)
+	}
+
+	long := append([]byte("["), bytes.Repeat([]byte(`"some_value",`), SniffLen)...)
+
+	assert.Equal(t, JSON, Detect(long))
+
+	cut := []byte("a" + strings.Repeat("é", SniffLen/2))
+
+	assert.Equal(t, Text, Detect(cut))
+
+}
+
+func ftyp(major string, compatible ...string) []byte {
+
+	box := []byte{0, 0, 0, byte(16 + 4*len(compatible))}
+	box = append(box, "ftyp"+major+"\x00\x00\x00\x00"...)
+
+	for _, brand := range compatible {
+		box = append(box, brand...)
+	}
+
+	return append(box, "\x00\x00\x00\x08free"...)
+
+}
+
+func zipArchive(t *testing.T, names ...string) []byte {
+
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+
+	for _, name := range names {
+		f, err := w.Create(name)
+		assert.NoError(t, err)
+		_, err = f.Write([]byte("some_content"))
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, w.Close())
+
+	return buf.Bytes()
+
+}
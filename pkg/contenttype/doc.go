@@ -0,0 +1,5 @@
+// Package contenttype resolves the content type of objects from an explicit override,
+// the extension of their name and the signature of their first bytes, covering the
+// formats http.DetectContentType gets wrong, like SVG, JSON, CSV, WebP, HEIC, video
+// containers and Office documents
+package contenttype
@@ -1,24 +1,32 @@
 package testdata
 
 import (
+	"path/filepath"
+	"runtime"
 	"testing"
-	"github.com/easynetwork/aws-sdk-go-bindings/internal/configuration"
-	"github.com/stretchr/testify/assert"
+
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/easynetwork/aws-sdk-go-bindings/pkg/config"
 )
 
 const (
 	dynamodbStringType = "S"
 	dynamodbHashType = "HASH"
+	confFileName = "configuration.json"
 )
 
-func MockConfiguration(t *testing.T) *configuration.Configuration {
+func MockConfiguration(t *testing.T) *config.Configuration {
 
 	t.Helper()
 
-	cfg, err := configuration.Get()
+	_, filename, _, _ := runtime.Caller(0)
+
+	cfg, err := config.Load(filepath.Join(filepath.Dir(filename), confFileName))
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, cfg)
@@ -27,13 +35,14 @@ func MockConfiguration(t *testing.T) *configuration.Configuration {
 
 }
 
-func MockDynamoDB(t *testing.T, cfg *configuration.Configuration) *dynamodb.DynamoDB {
+func MockDynamoDB(t *testing.T, cfg *config.Configuration) *dynamodb.DynamoDB {
 
 	t.Helper()
 
 	conf := &aws.Config{
-		Region:   aws.String(cfg.Region),
-		Endpoint: aws.String(cfg.DynamoDB.Endpoint),
+		Region:      aws.String(cfg.Region),
+		Endpoint:    aws.String(cfg.DynamoDB.Endpoint),
+		Credentials: credentials.NewStaticCredentials("some_key", "some_secret", ""),
 	}
 
 	dynamoSession, err := session.NewSession(conf)
@@ -43,7 +52,7 @@ func MockDynamoDB(t *testing.T, cfg *configuration.Configuration) *dynamodb.Dyna
 
 }
 
-func MockDynamoDBTable(t *testing.T, svc *dynamodb.DynamoDB, tableName string, cfg *configuration.Configuration) {
+func MockDynamoDBTable(t *testing.T, svc *dynamodb.DynamoDB, tableName string, cfg *config.Configuration) {
 
 	t.Helper()
 